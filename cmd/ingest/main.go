@@ -0,0 +1,103 @@
+// Command ingest crawls the match history of a list of players into the
+// matches table. Players are PUUIDs or Riot IDs (gameName#tagLine), given
+// as arguments or one per line in a file. Matches already stored are
+// skipped and every player crawled in full is checkpointed, so running the
+// same command again resumes an interrupted crawl and later picks up only
+// new games.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/config"
+	"github.com/gvieiragoulart/draft-visualizer/internal/database"
+	"github.com/gvieiragoulart/draft-visualizer/internal/riot"
+	"github.com/gvieiragoulart/draft-visualizer/internal/service"
+)
+
+func main() {
+	databaseURL := flag.String("database-url", os.Getenv("DATABASE_URL"), "Postgres connection string")
+	apiKey := flag.String("api-key", os.Getenv("RIOT_API_KEY"), "Riot API key")
+	file := flag.String("file", "", "file with one PUUID or Riot ID per line")
+	platform := flag.String("platform", "", "platform Riot IDs are looked up on, e.g. kr or euw1")
+	region := flag.String("region", "", "routing region to list match histories from (default: the platform's)")
+	queue := flag.Int("queue", -1, "only crawl this queue ID, e.g. 420 for ranked solo")
+	matchType := flag.String("type", "", "only crawl this match type: ranked, normal, tourney or tutorial")
+	since := flag.String("since", "", "only crawl matches played after this RFC 3339 time")
+	concurrency := flag.Int("concurrency", service.DefaultIngestConcurrency, "matches fetched at once")
+	flag.Parse()
+
+	if *databaseURL == "" {
+		log.Fatal("DATABASE_URL or -database-url is required")
+	}
+	if *apiKey == "" {
+		log.Fatal("RIOT_API_KEY or -api-key is required")
+	}
+
+	players := flag.Args()
+	if *file != "" {
+		listed, err := config.ReadPlayers(*file)
+		if err != nil {
+			log.Fatalf("Failed to read players: %v", err)
+		}
+		players = append(players, listed...)
+	}
+
+	req := service.IngestRequest{
+		Players:     players,
+		Platform:    *platform,
+		Region:      *region,
+		Type:        *matchType,
+		Concurrency: *concurrency,
+	}
+	if *queue >= 0 {
+		req.Queue = queue
+	}
+	if *since != "" {
+		startTime, err := time.Parse(time.RFC3339, *since)
+		if err != nil {
+			log.Fatalf("Invalid -since: %v", err)
+		}
+		req.StartTime = startTime
+	}
+
+	dbClient, err := database.NewClient(*databaseURL)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer dbClient.Close()
+
+	// stop between matches on Ctrl+C; the next run resumes from there
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ingestService := service.NewIngestService(riot.NewClient(*apiKey), dbClient)
+	var mu sync.Mutex
+	var lastLog time.Time
+	progress, err := ingestService.Run(ctx, req, func(p service.IngestProgress) {
+		mu.Lock()
+		defer mu.Unlock()
+		if time.Since(lastLog) < 5*time.Second {
+			return
+		}
+		lastLog = time.Now()
+		log.Printf("Players %d/%d, matches %d found, %d stored, %d already stored, %d failed",
+			p.Crawled, p.Players, p.Found, p.Stored, p.Skipped, p.Failed)
+	})
+	for _, message := range progress.Errors {
+		log.Printf("Error: %s", message)
+	}
+	if err != nil {
+		log.Fatalf("Ingest stopped: %v", err)
+	}
+
+	log.Printf("Crawled %d players: %d matches stored, %d already stored, %d failed",
+		progress.Crawled, progress.Stored, progress.Skipped, progress.Failed)
+}
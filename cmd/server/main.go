@@ -3,20 +3,27 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
+	"github.com/gvieiragoulart/draft-visualizer/internal/champions"
 	"github.com/gvieiragoulart/draft-visualizer/internal/clients/cargo"
 	"github.com/gvieiragoulart/draft-visualizer/internal/clients/esports"
 	"github.com/gvieiragoulart/draft-visualizer/internal/config"
 	"github.com/gvieiragoulart/draft-visualizer/internal/controller"
+	"github.com/gvieiragoulart/draft-visualizer/internal/database"
 	"github.com/gvieiragoulart/draft-visualizer/internal/riot"
 	"github.com/gvieiragoulart/draft-visualizer/internal/service"
+	"github.com/gvieiragoulart/draft-visualizer/internal/stream"
+	"github.com/gvieiragoulart/draft-visualizer/internal/winprob"
 )
 
 type Server struct {
@@ -32,11 +39,35 @@ func main() {
 
 	// Initialize Riot API client
 	riotClient := riot.NewClient(cfg.RiotAPIKey)
+	riotClient.SetRegion(cfg.RiotRegion)
 	esportsClient := esports.NewClient(cfg.EsportsAPIKey)
 	cargoClient := cargo.NewClient()
 
+	// Postgres only backs stored drafts, ingestion and player history, so
+	// the server still starts without it and those routes answer 503
+	dbClient, err := database.NewClient(cfg.DatabaseURL)
+	if err != nil {
+		log.Printf("Warning: failed to connect to database, stored data is unavailable: %v", err)
+		dbClient = nil
+	} else {
+		defer dbClient.Close()
+	}
+
+	catalogue, err := champions.Load(cfg.ChampionsDataDir)
+	if err != nil {
+		log.Fatalf("Failed to load champion catalogue: %v", err)
+	}
+	if missing := catalogue.MissingReleases(); len(missing) > 0 {
+		names := make([]string, len(missing))
+		for i, champion := range missing {
+			names[i] = champion.Name
+		}
+		log.Printf("Warning: no release patch for %s, they are allowed on every patch", strings.Join(names, ", "))
+	}
+
 	// Initialize service
 	svc := service.NewService(riotClient)
+	svc.SetCatalogue(catalogue)
 
 	// Initialize controller
 	scheduleHandler := controller.NewScheduleHandler(
@@ -45,12 +76,70 @@ func main() {
 		),
 	)
 
-	cargoHandler := controller.NewCargoHandler(
-		service.NewCargoService(
-			cargoClient,
-		),
+	cargoService := service.NewCargoService(cargoClient)
+	cargoService.SetCatalogue(catalogue)
+	cargoHandler := controller.NewCargoHandler(cargoService)
+
+	statsService := service.NewStatsService(cargoClient)
+	statsService.SetCatalogue(catalogue)
+	statsHandler := controller.NewStatsHandler(statsService)
+	matrixService := service.NewMatrixService(statsService, dbClient)
+	matrixHandler := controller.NewMatrixHandler(matrixService)
+	teamHandler := controller.NewTeamHandler(
+		service.NewTeamProfileService(esportsClient, statsService),
 	)
 
+	broker := stream.NewBroker(500)
+	draftSessionService := service.NewDraftSessionServiceWithBroker(broker)
+	draftSessionService.SetCatalogue(catalogue)
+	poolService := service.NewPoolService(cargoClient, catalogue)
+	draftSessionService.SetPools(poolService)
+	liveDraftService := service.NewLiveDraftService(esportsClient, broker)
+
+	championHandler := controller.NewChampionHandler(catalogue)
+	poolHandler := controller.NewPoolHandler(poolService)
+	recommendService := service.NewRecommendService(matrixService, draftSessionService)
+	recommendService.SetCatalogue(catalogue)
+	recommendHandler := controller.NewRecommendHandler(recommendService)
+
+	winProbService := service.NewWinProbService(draftSessionService)
+	winProbService.SetCatalogue(catalogue)
+	if cfg.WinProbModelPath != "" {
+		model, err := winprob.Load(cfg.WinProbModelPath)
+		if err != nil {
+			log.Fatalf("Failed to load win probability model: %v", err)
+		}
+		winProbService.SetModel(model)
+		log.Printf("Loaded win probability model %s", model.Version)
+	}
+	winProbHandler := controller.NewWinProbHandler(winProbService)
+
+	renderService := service.NewRenderService(draftSessionService, esportsClient)
+	renderService.SetCatalogue(catalogue)
+	renderHandler := controller.NewRenderHandler(renderService)
+
+	convertService := service.NewConvertService()
+	convertService.SetCatalogue(catalogue)
+	convertHandler := controller.NewConvertHandler(convertService)
+
+	diffService := service.NewDiffService(draftSessionService, dbClient)
+	diffService.SetCatalogue(catalogue)
+	diffHandler := controller.NewDiffHandler(diffService)
+
+	roleService := service.NewRoleService(draftSessionService, matrixService, cargoClient)
+	roleService.SetCatalogue(catalogue)
+	roleHandler := controller.NewRoleHandler(roleService)
+
+	ingestHandler := controller.NewIngestHandler(service.NewIngestService(riotClient, dbClient))
+
+	playerHistoryService := service.NewPlayerHistoryService(riotClient, dbClient)
+	playerHistoryService.SetCatalogue(catalogue)
+	playerHistoryHandler := controller.NewPlayerHistoryHandler(playerHistoryService)
+
+	draftHandler := controller.NewDraftHandler(draftSessionService)
+	liveHandler := controller.NewLiveHandler(liveDraftService)
+	streamHandler := controller.NewStreamHandler(broker, draftSessionService, liveDraftService)
+
 	// Create server
 	server := &Server{service: svc}
 
@@ -60,8 +149,40 @@ func main() {
 	mux.HandleFunc("/summoner", server.summonerHandler)
 	mux.HandleFunc("/matches", server.matchesHandler)
 	mux.HandleFunc("/match", server.matchHandler)
+	mux.HandleFunc("/match/timeline", server.matchTimelineHandler)
+	mux.HandleFunc("/ingest", ingestHandler.IngestHandler)
+	mux.HandleFunc("/players/snapshots", playerHistoryHandler.SnapshotHandler)
+	mux.HandleFunc("/players/league-history", playerHistoryHandler.LeagueHistoryHandler)
+	mux.HandleFunc("/players/mastery-history", playerHistoryHandler.MasteryHistoryHandler)
 	mux.HandleFunc("/schedule", scheduleHandler.ScheduleHandler)
 	mux.HandleFunc("/news-latest", cargoHandler.GetNewsLatest)
+	mux.HandleFunc("/picks-and-bans", cargoHandler.GetPicksAndBans)
+	mux.HandleFunc("/picks-and-bans/series", cargoHandler.ValidateSeries)
+	mux.HandleFunc("/picks-and-bans/export", cargoHandler.ExportPicksAndBans)
+	mux.HandleFunc("/champions", championHandler.ChampionsHandler)
+	mux.HandleFunc("/champions/pool", poolHandler.PoolHandler)
+	mux.HandleFunc("/stats/champions", statsHandler.ChampionStatsHandler)
+	mux.HandleFunc("/stats/sync", matrixHandler.SyncHandler)
+	mux.HandleFunc("/stats/synergy", matrixHandler.SynergyHandler)
+	mux.HandleFunc("/stats/counters", matrixHandler.CountersHandler)
+	mux.HandleFunc("/stats/flex", roleHandler.FlexHandler)
+	mux.HandleFunc("/teams/profile", teamHandler.ProfileHandler)
+	mux.HandleFunc("/drafts", draftHandler.DraftsHandler)
+	mux.HandleFunc("/drafts/actions", draftHandler.ActionsHandler)
+	mux.HandleFunc("/drafts/swaps", draftHandler.SwapsHandler)
+	mux.HandleFunc("/drafts/hovers", draftHandler.HoversHandler)
+	mux.HandleFunc("/drafts/timeline", draftHandler.TimelineHandler)
+	mux.HandleFunc("/drafts/games", draftHandler.GamesHandler)
+	mux.HandleFunc("/drafts/locked", draftHandler.LockedHandler)
+	mux.HandleFunc("/drafts/recommendations", recommendHandler.RecommendationsHandler)
+	mux.HandleFunc("/drafts/win-probability", winProbHandler.WinProbabilityHandler)
+	mux.HandleFunc("/drafts/image", renderHandler.ImageHandler)
+	mux.HandleFunc("/drafts/convert", convertHandler.ConvertHandler)
+	mux.HandleFunc("/drafts/diff", diffHandler.DiffHandler)
+	mux.HandleFunc("/drafts/roles", roleHandler.RolesHandler)
+	mux.HandleFunc("/live", liveHandler.LiveHandler)
+	mux.HandleFunc("/stream", streamHandler.SSEHandler)
+	mux.HandleFunc("/stream/ws", streamHandler.WebSocketHandler)
 
 	// Create HTTP server
 	httpServer := &http.Server{
@@ -106,6 +227,23 @@ func (s *Server) healthHandler(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// riotErrorStatus maps an error from a Riot API call to the status to answer
+// with. Rate limits that outlast every retry are reported as 503 rather than
+// passed on as the caller's own 429.
+func riotErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, riot.ErrInvalidRiotID), errors.Is(err, riot.ErrUnknownPlatform),
+		errors.Is(err, riot.ErrUnknownRegion), errors.Is(err, riot.ErrInvalidMatchFilter):
+		return http.StatusBadRequest
+	case riot.IsNotFound(err):
+		return http.StatusNotFound
+	case riot.IsRateLimited(err):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 func (s *Server) summonerHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -113,23 +251,30 @@ func (s *Server) summonerHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	region := r.URL.Query().Get("region")
-	name := r.URL.Query().Get("name")
+	riotID := r.URL.Query().Get("riotId")
+	if riotID == "" {
+		// name is the pre Riot ID parameter, kept as an alias
+		riotID = r.URL.Query().Get("name")
+	}
 
-	if region == "" || name == "" {
-		http.Error(w, "region and name parameters are required", http.StatusBadRequest)
+	if region == "" || riotID == "" {
+		http.Error(w, "region and riotId parameters are required", http.StatusBadRequest)
 		return
 	}
 
 	ctx := r.Context()
-	summoner, err := s.service.GetSummoner(ctx, region, name)
+	player, err := s.service.GetPlayer(ctx, region, riotID)
 	if err != nil {
-		log.Printf("Error getting summoner: %v", err)
-		http.Error(w, fmt.Sprintf("Error getting summoner: %v", err), http.StatusInternalServerError)
+		status := riotErrorStatus(err)
+		if status == http.StatusInternalServerError {
+			log.Printf("Error getting summoner: %v", err)
+		}
+		http.Error(w, fmt.Sprintf("Error getting summoner: %v", err), status)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(summoner)
+	json.NewEncoder(w).Encode(player)
 }
 
 func (s *Server) matchesHandler(w http.ResponseWriter, r *http.Request) {
@@ -144,16 +289,23 @@ func (s *Server) matchesHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	count := 20 // default
-	if countParam := r.URL.Query().Get("count"); countParam != "" {
-		fmt.Sscanf(countParam, "%d", &count)
+	filter, err := parseMatchFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
+	// region is optional and takes a routing region or a platform
+	region := r.URL.Query().Get("region")
+
 	ctx := r.Context()
-	matches, err := s.service.GetMatches(ctx, puuid, count)
+	matches, err := s.service.GetMatchHistory(ctx, region, puuid, filter)
 	if err != nil {
-		log.Printf("Error getting matches: %v", err)
-		http.Error(w, fmt.Sprintf("Error getting matches: %v", err), http.StatusInternalServerError)
+		status := riotErrorStatus(err)
+		if status == http.StatusInternalServerError {
+			log.Printf("Error getting matches: %v", err)
+		}
+		http.Error(w, fmt.Sprintf("Error getting matches: %v", err), status)
 		return
 	}
 
@@ -161,6 +313,90 @@ func (s *Server) matchesHandler(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(matches)
 }
 
+// maxMatchCount caps count on /matches so one request pages through at most
+// five calls to match-v5
+const maxMatchCount = 500
+
+// maxAllMatchesWindow is the longest time window count=all may cover, so a
+// request cannot page through a player's whole history behind the rate
+// limiter
+const maxAllMatchesWindow = 31 * 24 * time.Hour
+
+// parseMatchFilter reads the match history filters of /matches. count
+// defaults to 20 and is at most maxMatchCount; "all" returns every match that
+// passes the filters and needs a startTime within maxAllMatchesWindow.
+// startTime and endTime take epoch seconds or RFC 3339 times.
+func parseMatchFilter(r *http.Request) (riot.MatchFilter, error) {
+	query := r.URL.Query()
+	filter := riot.MatchFilter{Count: riot.DefaultMatchCount}
+
+	if start := query.Get("start"); start != "" {
+		n, err := strconv.Atoi(start)
+		if err != nil || n < 0 {
+			return filter, fmt.Errorf("start must be a non-negative integer")
+		}
+		filter.Start = n
+	}
+
+	all := false
+	switch count := query.Get("count"); count {
+	case "":
+	case "all":
+		all = true
+		filter.Count = 0
+	default:
+		n, err := strconv.Atoi(count)
+		if err != nil || n < 1 || n > maxMatchCount {
+			return filter, fmt.Errorf("count must be an integer from 1 to %d, or all", maxMatchCount)
+		}
+		filter.Count = n
+	}
+
+	if queue := query.Get("queue"); queue != "" {
+		n, err := strconv.Atoi(queue)
+		if err != nil || n < 0 {
+			return filter, fmt.Errorf("queue must be a queue ID")
+		}
+		filter.Queue = &n
+	}
+	filter.Type = query.Get("type")
+
+	var err error
+	if filter.StartTime, err = parseTimeParam(query.Get("startTime")); err != nil {
+		return filter, fmt.Errorf("startTime must be epoch seconds or an RFC 3339 time")
+	}
+	if filter.EndTime, err = parseTimeParam(query.Get("endTime")); err != nil {
+		return filter, fmt.Errorf("endTime must be epoch seconds or an RFC 3339 time")
+	}
+
+	if all {
+		end := filter.EndTime
+		if end.IsZero() {
+			end = time.Now()
+		}
+		if filter.StartTime.IsZero() || end.Sub(filter.StartTime) > maxAllMatchesWindow {
+			return filter, fmt.Errorf("count=all needs a startTime no more than %d days before endTime or now", int(maxAllMatchesWindow.Hours()/24))
+		}
+	}
+
+	if err := filter.Validate(); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
+// parseTimeParam parses epoch seconds or an RFC 3339 time, returning the
+// zero time for an empty value
+func parseTimeParam(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0), nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
 func (s *Server) matchHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -173,14 +409,80 @@ func (s *Server) matchHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// format=notation or csv returns only the draft, like /drafts/convert
+	if format := strings.ToLower(r.URL.Query().Get("format")); format != "" && format != service.DraftJSON {
+		s.matchDraftHandler(w, r, matchID, format)
+		return
+	}
+
 	ctx := r.Context()
-	match, err := s.service.GetMatch(ctx, matchID)
+	match, err := s.service.GetMatchDetails(ctx, matchID)
 	if err != nil {
-		log.Printf("Error getting match: %v", err)
-		http.Error(w, fmt.Sprintf("Error getting match: %v", err), http.StatusInternalServerError)
+		status := riotErrorStatus(err)
+		if status == http.StatusInternalServerError {
+			log.Printf("Error getting match: %v", err)
+		}
+		http.Error(w, fmt.Sprintf("Error getting match: %v", err), status)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(match)
 }
+
+func (s *Server) matchDraftHandler(w http.ResponseWriter, r *http.Request, matchID, format string) {
+	data, err := s.service.ExportMatchDraft(r.Context(), matchID, format)
+	if err != nil {
+		var status int
+		switch {
+		case errors.Is(err, service.ErrInvalidConversion):
+			status = http.StatusBadRequest
+		case errors.Is(err, service.ErrNoMatchDraft):
+			status = http.StatusNotFound
+		default:
+			status = riotErrorStatus(err)
+		}
+		if status == http.StatusInternalServerError {
+			log.Printf("Error exporting match draft: %v", err)
+		}
+		http.Error(w, fmt.Sprintf("Error exporting match draft: %v", err), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", service.ContentType(format))
+	w.Write(data)
+}
+
+func (s *Server) matchTimelineHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	matchID := r.URL.Query().Get("id")
+	if matchID == "" {
+		http.Error(w, "id parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	// events optionally filters the events by type, e.g.
+	// events=CHAMPION_KILL,WARD_PLACED
+	var eventTypes []string
+	if events := r.URL.Query().Get("events"); events != "" {
+		eventTypes = strings.Split(events, ",")
+	}
+
+	ctx := r.Context()
+	timeline, err := s.service.GetMatchTimeline(ctx, matchID, eventTypes)
+	if err != nil {
+		status := riotErrorStatus(err)
+		if status == http.StatusInternalServerError {
+			log.Printf("Error getting match timeline: %v", err)
+		}
+		http.Error(w, fmt.Sprintf("Error getting match timeline: %v", err), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(timeline)
+}
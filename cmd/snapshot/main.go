@@ -0,0 +1,84 @@
+// Command snapshot records the ranked standing and champion mastery of a
+// list of players, so their LP and champion pool can be charted over a
+// split. Players are PUUIDs or Riot IDs (gameName#tagLine), given as
+// arguments or one per line in a file. With -every it keeps running and
+// takes a snapshot at that interval; otherwise it takes one and exits, for
+// cron.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/config"
+	"github.com/gvieiragoulart/draft-visualizer/internal/database"
+	"github.com/gvieiragoulart/draft-visualizer/internal/riot"
+	"github.com/gvieiragoulart/draft-visualizer/internal/service"
+)
+
+func main() {
+	databaseURL := flag.String("database-url", os.Getenv("DATABASE_URL"), "Postgres connection string")
+	apiKey := flag.String("api-key", os.Getenv("RIOT_API_KEY"), "Riot API key")
+	file := flag.String("file", "", "file with one PUUID or Riot ID per line")
+	platform := flag.String("platform", "", "platform the players play on, e.g. kr or euw1")
+	every := flag.Duration("every", 0, "take a snapshot at this interval, e.g. 6h, instead of once")
+	flag.Parse()
+
+	if *databaseURL == "" {
+		log.Fatal("DATABASE_URL or -database-url is required")
+	}
+	if *apiKey == "" {
+		log.Fatal("RIOT_API_KEY or -api-key is required")
+	}
+
+	players := flag.Args()
+	if *file != "" {
+		listed, err := config.ReadPlayers(*file)
+		if err != nil {
+			log.Fatalf("Failed to read players: %v", err)
+		}
+		players = append(players, listed...)
+	}
+	req := service.SnapshotRequest{Players: players, Platform: *platform}
+	if err := req.Validate(); err != nil {
+		log.Fatalf("Invalid request: %v", err)
+	}
+
+	dbClient, err := database.NewClient(*databaseURL)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer dbClient.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	historyService := service.NewPlayerHistoryService(riot.NewClient(*apiKey), dbClient)
+	if *every <= 0 {
+		report, err := historyService.Snapshot(ctx, req)
+		logReport(report, err)
+		if err != nil {
+			os.Exit(1)
+		}
+		return
+	}
+
+	log.Printf("Taking a snapshot of %d players every %s", len(players), *every)
+	historyService.RunPeriodic(ctx, req, *every, logReport)
+}
+
+func logReport(report service.SnapshotReport, err error) {
+	for _, message := range report.Errors {
+		log.Printf("Error: %s", message)
+	}
+	if err != nil {
+		log.Printf("Snapshot failed: %v", err)
+		return
+	}
+	log.Printf("Snapshot of %d/%d players: %d league entries, %d masteries",
+		report.Snapshotted, report.Players, report.LeagueEntries, report.Masteries)
+}
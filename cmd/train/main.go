@@ -0,0 +1,71 @@
+// Command train fits the draft win probability model on the pro games stored
+// in Postgres and writes it as a versioned JSON file for the server to load
+// through WINPROB_MODEL_PATH.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/analytics"
+	"github.com/gvieiragoulart/draft-visualizer/internal/database"
+	"github.com/gvieiragoulart/draft-visualizer/internal/service"
+	"github.com/gvieiragoulart/draft-visualizer/internal/winprob"
+)
+
+func main() {
+	config := winprob.DefaultTrainingConfig
+
+	databaseURL := flag.String("database-url", os.Getenv("DATABASE_URL"), "Postgres connection string")
+	outDir := flag.String("out", "models", "directory the model file is written to")
+	tournament := flag.String("tournament", "", "only train on this tournament overview page")
+	league := flag.String("league", "", "only train on this league")
+	patchFrom := flag.String("patch-from", "", "first patch to train on, inclusive")
+	patchTo := flag.String("patch-to", "", "last patch to train on, inclusive")
+	flag.IntVar(&config.MinPicks, "min-picks", config.MinPicks, "drop champions picked fewer times")
+	flag.IntVar(&config.Bootstrap, "bootstrap", config.Bootstrap, "number of bootstrap models in the ensemble")
+	flag.IntVar(&config.Epochs, "epochs", config.Epochs, "gradient descent epochs per model")
+	flag.Float64Var(&config.LearningRate, "learning-rate", config.LearningRate, "gradient descent step size")
+	flag.Float64Var(&config.L2, "l2", config.L2, "L2 regularization strength")
+	flag.Int64Var(&config.Seed, "seed", config.Seed, "random seed for bootstrap resampling")
+	flag.Parse()
+
+	if *databaseURL == "" {
+		log.Fatal("DATABASE_URL or -database-url is required")
+	}
+
+	dbClient, err := database.NewClient(*databaseURL)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer dbClient.Close()
+
+	window := analytics.PatchWindow{From: *patchFrom, To: *patchTo}
+	games, err := service.NewMatrixService(nil, dbClient).StoredGames(database.DraftGameFilter{
+		Tournament: *tournament,
+		League:     *league,
+	}, window)
+	if err != nil {
+		log.Fatalf("Failed to load games: %v", err)
+	}
+	log.Printf("Loaded %d games", len(games))
+
+	model, err := winprob.Train(games, config, winprob.TrainingData{Patches: window})
+	if err != nil {
+		log.Fatalf("Failed to train model: %v", err)
+	}
+
+	if err := os.MkdirAll(*outDir, 0o755); err != nil {
+		log.Fatalf("Failed to create output directory: %v", err)
+	}
+	path := filepath.Join(*outDir, fmt.Sprintf("winprob-%s.json", model.Version))
+	if err := model.Save(path); err != nil {
+		log.Fatalf("Failed to save model: %v", err)
+	}
+
+	log.Printf("Trained model %s on %d games with %d features, written to %s",
+		model.Version, model.Data.Games, len(model.Features), path)
+}
@@ -3,16 +3,19 @@ module github.com/gvieiragoulart/draft-visualizer
 go 1.24.7
 
 require (
+	cgt.name/pkg/go-mwclient v1.3.0
 	github.com/DATA-DOG/go-sqlmock v1.5.2
+	github.com/gorilla/websocket v1.5.3
+	github.com/joho/godotenv v1.5.1
 	github.com/lib/pq v1.10.9
 	github.com/redis/go-redis/v9 v9.14.0
+	golang.org/x/image v0.25.0
 )
 
 require (
-	cgt.name/pkg/go-mwclient v1.3.0 // indirect
 	github.com/antonholmquist/jason v1.0.0 // indirect
 	github.com/cespare/xxhash/v2 v2.3.0 // indirect
 	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
-	github.com/joho/godotenv v1.5.1 // indirect
 	github.com/mrjones/oauth v0.0.0-20190623134757-126b35219450 // indirect
+	golang.org/x/text v0.23.0 // indirect
 )
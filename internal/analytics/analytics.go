@@ -0,0 +1,213 @@
+package analytics
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/draft"
+)
+
+// Game is one completed pro draft with the context needed to aggregate it
+type Game struct {
+	GameID     string     `json:"gameId"`
+	MatchID    string     `json:"matchId"`
+	Tournament string     `json:"tournament"`
+	League     string     `json:"league"`
+	Patch      string     `json:"patch"`
+	Date       *time.Time `json:"date"`
+	BlueTeam   string     `json:"blueTeam"`
+	RedTeam    string     `json:"redTeam"`
+
+	// Winner is empty when the result is not known
+	Winner draft.Side `json:"winner"`
+
+	Blue draft.Selections `json:"blue"`
+	Red  draft.Selections `json:"red"`
+}
+
+// Selections returns the bans, picks and roles of one side
+func (g Game) Selections(side draft.Side) draft.Selections {
+	if side == draft.Red {
+		return g.Red
+	}
+	return g.Blue
+}
+
+// Team returns the team that played on side
+func (g Game) Team(side draft.Side) string {
+	if side == draft.Red {
+		return g.RedTeam
+	}
+	return g.BlueTeam
+}
+
+// ChampionStats aggregates how often a champion was picked, banned and won.
+// Rates are fractions between 0 and 1; win rates are over the champion's
+// own picks in games whose result is known, which Decided counts.
+type ChampionStats struct {
+	Champion string `json:"champion"`
+	Picks    int    `json:"picks"`
+	Bans     int    `json:"bans"`
+	Presence int    `json:"presence"`
+	Wins     int    `json:"wins"`
+	Decided  int    `json:"decided"`
+
+	PickRate     float64 `json:"pickRate"`
+	BanRate      float64 `json:"banRate"`
+	PresenceRate float64 `json:"presenceRate"`
+	WinRate      float64 `json:"winRate"`
+
+	BluePicks   int     `json:"bluePicks"`
+	BlueWins    int     `json:"blueWins"`
+	BlueDecided int     `json:"blueDecided"`
+	BlueWinRate float64 `json:"blueWinRate"`
+	RedPicks    int     `json:"redPicks"`
+	RedWins     int     `json:"redWins"`
+	RedDecided  int     `json:"redDecided"`
+	RedWinRate  float64 `json:"redWinRate"`
+}
+
+// ChampionReport is the result of aggregating a set of games
+type ChampionReport struct {
+	Games     int             `json:"games"`
+	Champions []ChampionStats `json:"champions"`
+}
+
+// Sort keys accepted by SortChampionStats
+const (
+	SortPresence = "presence"
+	SortPicks    = "picks"
+	SortBans     = "bans"
+	SortWinRate  = "win_rate"
+	SortChampion = "champion"
+)
+
+// ChampionStatistics computes pick, ban, presence and win statistics for
+// every champion that appears in games, sorted by presence
+func ChampionStatistics(games []Game) ChampionReport {
+	byChampion := make(map[string]*ChampionStats)
+	get := func(champion string) *ChampionStats {
+		stats, ok := byChampion[champion]
+		if !ok {
+			stats = &ChampionStats{Champion: champion}
+			byChampion[champion] = stats
+		}
+		return stats
+	}
+
+	for _, game := range games {
+		present := make(map[string]bool)
+
+		for _, side := range []draft.Side{draft.Blue, draft.Red} {
+			selections := game.Selections(side)
+
+			for _, champion := range selections.Bans {
+				if champion == "" || champion == draft.NoBan {
+					continue
+				}
+				get(champion).Bans++
+				present[champion] = true
+			}
+
+			decided := game.Winner != ""
+			won := game.Winner == side
+			for _, champion := range selections.Picks {
+				if champion == "" {
+					continue
+				}
+				stats := get(champion)
+				stats.Picks++
+				if side == draft.Blue {
+					stats.BluePicks++
+				} else {
+					stats.RedPicks++
+				}
+				if decided {
+					stats.Decided++
+					if side == draft.Blue {
+						stats.BlueDecided++
+					} else {
+						stats.RedDecided++
+					}
+				}
+				if won {
+					stats.Wins++
+					if side == draft.Blue {
+						stats.BlueWins++
+					} else {
+						stats.RedWins++
+					}
+				}
+				present[champion] = true
+			}
+		}
+
+		for champion := range present {
+			get(champion).Presence++
+		}
+	}
+
+	report := ChampionReport{
+		Games:     len(games),
+		Champions: make([]ChampionStats, 0, len(byChampion)),
+	}
+	for _, stats := range byChampion {
+		stats.PickRate = rate(stats.Picks, report.Games)
+		stats.BanRate = rate(stats.Bans, report.Games)
+		stats.PresenceRate = rate(stats.Presence, report.Games)
+		stats.WinRate = rate(stats.Wins, stats.Decided)
+		stats.BlueWinRate = rate(stats.BlueWins, stats.BlueDecided)
+		stats.RedWinRate = rate(stats.RedWins, stats.RedDecided)
+		report.Champions = append(report.Champions, *stats)
+	}
+
+	SortChampionStats(report.Champions, SortPresence, true)
+	return report
+}
+
+// SortChampionStats orders stats by key. Ties are broken by champion name
+// so the output is stable.
+func SortChampionStats(stats []ChampionStats, key string, descending bool) error {
+	var value func(ChampionStats) float64
+	switch strings.ToLower(key) {
+	case "", SortPresence:
+		value = func(s ChampionStats) float64 { return float64(s.Presence) }
+	case SortPicks, "pick_rate":
+		value = func(s ChampionStats) float64 { return float64(s.Picks) }
+	case SortBans, "ban_rate":
+		value = func(s ChampionStats) float64 { return float64(s.Bans) }
+	case SortWinRate:
+		value = func(s ChampionStats) float64 { return s.WinRate }
+	case SortChampion:
+		sort.SliceStable(stats, func(i, j int) bool {
+			if descending {
+				return stats[i].Champion > stats[j].Champion
+			}
+			return stats[i].Champion < stats[j].Champion
+		})
+		return nil
+	default:
+		return fmt.Errorf("unknown sort key %q", key)
+	}
+
+	sort.SliceStable(stats, func(i, j int) bool {
+		a, b := value(stats[i]), value(stats[j])
+		if a == b {
+			return stats[i].Champion < stats[j].Champion
+		}
+		if descending {
+			return a > b
+		}
+		return a < b
+	})
+	return nil
+}
+
+func rate(count, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(count) / float64(total)
+}
@@ -0,0 +1,133 @@
+package analytics
+
+import (
+	"testing"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/draft"
+)
+
+func testGames() []Game {
+	return []Game{
+		{
+			BlueTeam: "T1",
+			RedTeam:  "Gen.G",
+			Winner:   draft.Blue,
+			Blue:     draft.Selections{Bans: []string{"Azir", "None"}, Picks: []string{"Ahri", "Rell"}},
+			Red:      draft.Selections{Bans: []string{"Kalista"}, Picks: []string{"Corki", "Vi"}},
+		},
+		{
+			BlueTeam: "Gen.G",
+			RedTeam:  "T1",
+			Winner:   draft.Red,
+			Blue:     draft.Selections{Bans: []string{"Ahri"}, Picks: []string{"Azir", "Vi"}},
+			Red:      draft.Selections{Bans: []string{"Rell"}, Picks: []string{"Corki", "Kalista"}},
+		},
+	}
+}
+
+func findStats(t *testing.T, report ChampionReport, champion string) ChampionStats {
+	t.Helper()
+	for _, stats := range report.Champions {
+		if stats.Champion == champion {
+			return stats
+		}
+	}
+	t.Fatalf("expected stats for %s", champion)
+	return ChampionStats{}
+}
+
+func TestChampionStatistics(t *testing.T) {
+	report := ChampionStatistics(testGames())
+
+	if report.Games != 2 {
+		t.Errorf("expected 2 games, got %d", report.Games)
+	}
+
+	tests := []struct {
+		champion string
+		picks    int
+		bans     int
+		presence int
+		winRate  float64
+	}{
+		{"Azir", 1, 1, 2, 0},
+		{"Ahri", 1, 1, 2, 1},
+		{"Corki", 2, 0, 2, 0.5},
+		{"Vi", 2, 0, 2, 0},
+		{"Kalista", 1, 1, 2, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.champion, func(t *testing.T) {
+			stats := findStats(t, report, tt.champion)
+			if stats.Picks != tt.picks || stats.Bans != tt.bans || stats.Presence != tt.presence {
+				t.Errorf("expected %d picks, %d bans, %d presence, got %d, %d, %d",
+					tt.picks, tt.bans, tt.presence, stats.Picks, stats.Bans, stats.Presence)
+			}
+			if stats.WinRate != tt.winRate {
+				t.Errorf("expected win rate %.2f, got %.2f", tt.winRate, stats.WinRate)
+			}
+			if stats.PresenceRate != float64(tt.presence)/2 {
+				t.Errorf("expected presence rate %.2f, got %.2f", float64(tt.presence)/2, stats.PresenceRate)
+			}
+		})
+	}
+
+	for _, stats := range report.Champions {
+		if stats.Champion == draft.NoBan {
+			t.Error("expected skipped bans to be ignored")
+		}
+	}
+}
+
+func TestChampionStatistics_SideSplit(t *testing.T) {
+	corki := findStats(t, ChampionStatistics(testGames()), "Corki")
+
+	if corki.BluePicks != 0 || corki.RedPicks != 2 {
+		t.Errorf("expected Corki picked twice on red, got %d blue and %d red", corki.BluePicks, corki.RedPicks)
+	}
+	if corki.RedWins != 1 || corki.RedWinRate != 0.5 {
+		t.Errorf("expected Corki to win once on red, got %d wins at %.2f", corki.RedWins, corki.RedWinRate)
+	}
+}
+
+func TestChampionStatistics_UnknownResult(t *testing.T) {
+	games := append(testGames(), Game{
+		BlueTeam: "T1",
+		RedTeam:  "Gen.G",
+		Blue:     draft.Selections{Picks: []string{"Ahri"}},
+		Red:      draft.Selections{Picks: []string{"Corki"}},
+	})
+	report := ChampionStatistics(games)
+
+	ahri := findStats(t, report, "Ahri")
+	if ahri.Picks != 2 || ahri.Decided != 1 || ahri.WinRate != 1 || ahri.BlueWinRate != 1 {
+		t.Errorf("expected the unknown result to count as a pick but not a loss, got %+v", ahri)
+	}
+	corki := findStats(t, report, "Corki")
+	if corki.Picks != 3 || corki.RedDecided != 2 || corki.RedWinRate != 0.5 {
+		t.Errorf("expected Corki to keep a 50%% red win rate, got %+v", corki)
+	}
+}
+
+func TestSortChampionStats(t *testing.T) {
+	report := ChampionStatistics(testGames())
+
+	if err := SortChampionStats(report.Champions, SortPicks, true); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if report.Champions[0].Champion != "Corki" || report.Champions[1].Champion != "Vi" {
+		t.Errorf("expected Corki then Vi by picks, got %s then %s", report.Champions[0].Champion, report.Champions[1].Champion)
+	}
+
+	if err := SortChampionStats(report.Champions, SortChampion, false); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if report.Champions[0].Champion != "Ahri" {
+		t.Errorf("expected Ahri first by name, got %s", report.Champions[0].Champion)
+	}
+
+	if err := SortChampionStats(report.Champions, "popularity", true); err == nil {
+		t.Error("expected error for unknown sort key")
+	}
+}
@@ -0,0 +1,286 @@
+package analytics
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/draft"
+)
+
+// DefaultMinGames is the sample size below which pairs are left out of a
+// matrix
+const DefaultMinGames = 5
+
+// wilsonZ is the normal quantile for a 95% confidence interval
+const wilsonZ = 1.96
+
+// PatchWindow selects games by patch, inclusive on both ends. Patches are
+// compared numerically part by part, so 14.9 comes before 14.10.
+type PatchWindow struct {
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+}
+
+// Contains reports whether patch falls inside the window. Games without a
+// patch only match an open window.
+func (w PatchWindow) Contains(patch string) bool {
+	if w.From == "" && w.To == "" {
+		return true
+	}
+	if patch == "" {
+		return false
+	}
+	if w.From != "" && ComparePatches(patch, w.From) < 0 {
+		return false
+	}
+	if w.To != "" && ComparePatches(patch, w.To) > 0 {
+		return false
+	}
+	return true
+}
+
+// ComparePatches orders two patch strings such as "14.9" and "14.10"
+func ComparePatches(a, b string) int {
+	aParts := strings.Split(a, ".")
+	bParts := strings.Split(b, ".")
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		var x, y int
+		if i < len(aParts) {
+			x, _ = strconv.Atoi(aParts[i])
+		}
+		if i < len(bParts) {
+			y, _ = strconv.Atoi(bParts[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// FilterByPatch keeps the games played inside window
+func FilterByPatch(games []Game, window PatchWindow) []Game {
+	filtered := []Game{}
+	for _, game := range games {
+		if window.Contains(game.Patch) {
+			filtered = append(filtered, game)
+		}
+	}
+	return filtered
+}
+
+// PairStats is the record of Champion when paired with Other, either on the
+// same team (synergy) or against it in the same role (counter). Lift is the
+// pair's win rate minus Champion's win rate over all its games, and the
+// confidence interval is a 95% Wilson interval around the pair's win rate.
+type PairStats struct {
+	Champion    string  `json:"champion"`
+	Other       string  `json:"other"`
+	Role        string  `json:"role,omitempty"`
+	Games       int     `json:"games"`
+	Wins        int     `json:"wins"`
+	WinRate     float64 `json:"winRate"`
+	Baseline    float64 `json:"baseline"`
+	Lift        float64 `json:"lift"`
+	CILow       float64 `json:"ciLow"`
+	CIHigh      float64 `json:"ciHigh"`
+	Significant bool    `json:"significant"`
+}
+
+type pairKey struct {
+	champion string
+	other    string
+	role     string
+}
+
+type tally struct {
+	games int
+	wins  int
+}
+
+// SynergyMatrix computes the win-rate lift of every pair of champions picked
+// by the same team in at least minGames games. Games without a known winner
+// are ignored. Each pair appears once per champion, so the matrix can be
+// read row by row.
+func SynergyMatrix(games []Game, minGames int) []PairStats {
+	baselines := championBaselines(games)
+	pairs := map[pairKey]*tally{}
+
+	for _, game := range games {
+		if game.Winner == "" {
+			continue
+		}
+		for _, side := range []draft.Side{draft.Blue, draft.Red} {
+			picks := game.Selections(side).Picks
+			won := game.Winner == side
+			for i, champion := range picks {
+				for j, other := range picks {
+					if i == j {
+						continue
+					}
+					count(pairs, pairKey{champion: champion, other: other}, won)
+				}
+			}
+		}
+	}
+
+	return buildPairStats(pairs, baselines, minGames)
+}
+
+// CounterMatrix computes the win-rate lift of every champion against each
+// champion the opponent played in the same role, for pairs seen in at least
+// minGames games. Picks without a role are ignored.
+func CounterMatrix(games []Game, minGames int) []PairStats {
+	baselines := championBaselines(games)
+	pairs := map[pairKey]*tally{}
+
+	for _, game := range games {
+		if game.Winner == "" {
+			continue
+		}
+		blue := picksByRole(game.Blue)
+		red := picksByRole(game.Red)
+		for role, blueChampion := range blue {
+			redChampion, ok := red[role]
+			if !ok {
+				continue
+			}
+			count(pairs, pairKey{champion: blueChampion, other: redChampion, role: role}, game.Winner == draft.Blue)
+			count(pairs, pairKey{champion: redChampion, other: blueChampion, role: role}, game.Winner == draft.Red)
+		}
+	}
+
+	return buildPairStats(pairs, baselines, minGames)
+}
+
+// PairsFor keeps the rows of a matrix for one champion, and optionally one
+// role, sorted from highest to lowest lift
+func PairsFor(matrix []PairStats, champion, role string) []PairStats {
+	role = NormalizeRole(role)
+	rows := []PairStats{}
+	for _, pair := range matrix {
+		if !strings.EqualFold(pair.Champion, champion) {
+			continue
+		}
+		if role != "" && pair.Role != role {
+			continue
+		}
+		rows = append(rows, pair)
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].Lift > rows[j].Lift
+	})
+	return rows
+}
+
+// WilsonInterval returns the 95% Wilson score interval for wins out of games
+func WilsonInterval(wins, games int) (float64, float64) {
+	if games == 0 {
+		return 0, 1
+	}
+	n := float64(games)
+	p := float64(wins) / n
+	z2 := wilsonZ * wilsonZ
+
+	center := (p + z2/(2*n)) / (1 + z2/n)
+	margin := wilsonZ * math.Sqrt(p*(1-p)/n+z2/(4*n*n)) / (1 + z2/n)
+	return math.Max(0, center-margin), math.Min(1, center+margin)
+}
+
+func championBaselines(games []Game) map[string]float64 {
+	tallies := map[string]*tally{}
+	for _, game := range games {
+		if game.Winner == "" {
+			continue
+		}
+		for _, side := range []draft.Side{draft.Blue, draft.Red} {
+			for _, champion := range game.Selections(side).Picks {
+				t, ok := tallies[champion]
+				if !ok {
+					t = &tally{}
+					tallies[champion] = t
+				}
+				t.games++
+				if game.Winner == side {
+					t.wins++
+				}
+			}
+		}
+	}
+
+	baselines := make(map[string]float64, len(tallies))
+	for champion, t := range tallies {
+		baselines[champion] = rate(t.wins, t.games)
+	}
+	return baselines
+}
+
+func picksByRole(selections draft.Selections) map[string]string {
+	byRole := map[string]string{}
+	for i, champion := range selections.Picks {
+		if i >= len(selections.Roles) {
+			break
+		}
+		if role := NormalizeRole(selections.Roles[i]); role != "" {
+			byRole[role] = champion
+		}
+	}
+	return byRole
+}
+
+func count(pairs map[pairKey]*tally, key pairKey, won bool) {
+	t, ok := pairs[key]
+	if !ok {
+		t = &tally{}
+		pairs[key] = t
+	}
+	t.games++
+	if won {
+		t.wins++
+	}
+}
+
+func buildPairStats(pairs map[pairKey]*tally, baselines map[string]float64, minGames int) []PairStats {
+	if minGames < 1 {
+		minGames = 1
+	}
+
+	stats := []PairStats{}
+	for key, t := range pairs {
+		if t.games < minGames {
+			continue
+		}
+		low, high := WilsonInterval(t.wins, t.games)
+		winRate := rate(t.wins, t.games)
+		baseline := baselines[key.champion]
+		stats = append(stats, PairStats{
+			Champion:    key.champion,
+			Other:       key.other,
+			Role:        key.role,
+			Games:       t.games,
+			Wins:        t.wins,
+			WinRate:     winRate,
+			Baseline:    baseline,
+			Lift:        winRate - baseline,
+			CILow:       low,
+			CIHigh:      high,
+			Significant: baseline < low || baseline > high,
+		})
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Champion != stats[j].Champion {
+			return stats[i].Champion < stats[j].Champion
+		}
+		if stats[i].Lift != stats[j].Lift {
+			return stats[i].Lift > stats[j].Lift
+		}
+		return stats[i].Other < stats[j].Other
+	})
+	return stats
+}
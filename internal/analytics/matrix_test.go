@@ -0,0 +1,115 @@
+package analytics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/draft"
+)
+
+func matrixGame(patch string, winner draft.Side, blue, red []string) Game {
+	roles := []string{"Top", "Jungle", "Mid", "Bot", "Support"}
+	return Game{
+		Patch:  patch,
+		Winner: winner,
+		Blue:   draft.Selections{Picks: blue, Roles: roles[:len(blue)]},
+		Red:    draft.Selections{Picks: red, Roles: roles[:len(red)]},
+	}
+}
+
+func matrixGames() []Game {
+	return []Game{
+		matrixGame("14.9", draft.Blue, []string{"Jax", "Vi"}, []string{"Aatrox", "Sejuani"}),
+		matrixGame("14.10", draft.Blue, []string{"Jax", "Vi"}, []string{"Aatrox", "Maokai"}),
+		matrixGame("14.10", draft.Red, []string{"Jax", "Sejuani"}, []string{"Aatrox", "Vi"}),
+		matrixGame("14.11", draft.Red, []string{"Aatrox", "Maokai"}, []string{"Jax", "Sejuani"}),
+	}
+}
+
+func findPair(t *testing.T, pairs []PairStats, other string) PairStats {
+	t.Helper()
+	for _, pair := range pairs {
+		if pair.Other == other {
+			return pair
+		}
+	}
+	t.Fatalf("expected a pair with %s", other)
+	return PairStats{}
+}
+
+func TestSynergyMatrix(t *testing.T) {
+	pairs := PairsFor(SynergyMatrix(matrixGames(), 1), "Jax", "")
+
+	vi := findPair(t, pairs, "Vi")
+	if vi.Games != 2 || vi.Wins != 2 || vi.WinRate != 1 {
+		t.Errorf("expected Jax and Vi to win both games together, got %+v", vi)
+	}
+	if vi.Baseline != 0.75 {
+		t.Errorf("expected Jax baseline 0.75, got %.2f", vi.Baseline)
+	}
+	if math.Abs(vi.Lift-0.25) > 1e-9 {
+		t.Errorf("expected lift 0.25, got %.2f", vi.Lift)
+	}
+	if pairs[0].Other != "Vi" {
+		t.Errorf("expected Vi to be the best synergy, got %s", pairs[0].Other)
+	}
+}
+
+func TestSynergyMatrix_MinGames(t *testing.T) {
+	pairs := PairsFor(SynergyMatrix(matrixGames(), 2), "Jax", "")
+	for _, pair := range pairs {
+		if pair.Games < 2 {
+			t.Errorf("expected pairs below the threshold to be dropped, got %+v", pair)
+		}
+	}
+}
+
+func TestCounterMatrix(t *testing.T) {
+	pairs := PairsFor(CounterMatrix(matrixGames(), 1), "Jax", "top")
+
+	aatrox := findPair(t, pairs, "Aatrox")
+	if aatrox.Games != 4 || aatrox.Wins != 3 || aatrox.Role != "top" {
+		t.Errorf("expected Jax to beat Aatrox in 3 of 4 top matchups, got %+v", aatrox)
+	}
+
+	if len(PairsFor(CounterMatrix(matrixGames(), 1), "Jax", "jungle")) != 0 {
+		t.Error("expected no jungle matchups for Jax")
+	}
+}
+
+func TestPatchWindow(t *testing.T) {
+	window := PatchWindow{From: "14.9", To: "14.10"}
+
+	tests := []struct {
+		patch    string
+		expected bool
+	}{
+		{"14.9", true},
+		{"14.10", true},
+		{"14.11", false},
+		{"14.8", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := window.Contains(tt.patch); got != tt.expected {
+			t.Errorf("expected Contains(%q) to be %v, got %v", tt.patch, tt.expected, got)
+		}
+	}
+
+	if len(FilterByPatch(matrixGames(), window)) != 3 {
+		t.Errorf("expected 3 games in the window, got %d", len(FilterByPatch(matrixGames(), window)))
+	}
+}
+
+func TestWilsonInterval(t *testing.T) {
+	low, high := WilsonInterval(50, 100)
+	if math.Abs(low-0.404) > 0.001 || math.Abs(high-0.596) > 0.001 {
+		t.Errorf("expected roughly [0.404, 0.596], got [%.3f, %.3f]", low, high)
+	}
+
+	low, high = WilsonInterval(0, 0)
+	if low != 0 || high != 1 {
+		t.Errorf("expected [0, 1] without games, got [%.3f, %.3f]", low, high)
+	}
+}
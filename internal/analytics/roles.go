@@ -0,0 +1,230 @@
+package analytics
+
+import (
+	"math"
+	"sort"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/draft"
+)
+
+// Roles lists the five positions a team has to fill, in the order a team
+// is displayed
+var Roles = []string{"top", "jungle", "mid", "bottom", "support"}
+
+// Where an assigned role came from, from most to least reliable
+const (
+	RoleSourceScoreboard = "scoreboard"
+	RoleSourceDraft      = "draft"
+	RoleSourceHistory    = "history"
+)
+
+// RoleFrequency is how often a champion was played in one role
+type RoleFrequency struct {
+	Role  string  `json:"role"`
+	Games int     `json:"games"`
+	Rate  float64 `json:"rate"`
+}
+
+// ChampionRoles is a champion's role distribution. A champion played in two
+// or more roles is a flex pick. Roles are ordered by games played.
+type ChampionRoles struct {
+	Champion string          `json:"champion"`
+	Games    int             `json:"games"`
+	Flex     bool            `json:"flex"`
+	Roles    []RoleFrequency `json:"roles"`
+}
+
+// Rate returns the fraction of the champion's games played in role
+func (c ChampionRoles) Rate(role string) float64 {
+	for _, frequency := range c.Roles {
+		if frequency.Role == role {
+			return frequency.Rate
+		}
+	}
+	return 0
+}
+
+// RoleDistribution holds the role distribution of every champion picked in
+// a set of games, keyed by champion
+type RoleDistribution map[string]ChampionRoles
+
+// RoleStatistics counts the roles each champion was picked in. Picks without
+// a known role are left out.
+func RoleStatistics(games []Game) RoleDistribution {
+	counts := make(map[string]map[string]int)
+	for _, game := range games {
+		for _, selections := range []draft.Selections{game.Blue, game.Red} {
+			for i, champion := range selections.Picks {
+				if champion == "" || i >= len(selections.Roles) {
+					continue
+				}
+				role := NormalizeRole(selections.Roles[i])
+				if role == "" {
+					continue
+				}
+				if counts[champion] == nil {
+					counts[champion] = make(map[string]int)
+				}
+				counts[champion][role]++
+			}
+		}
+	}
+
+	distribution := make(RoleDistribution, len(counts))
+	for champion, roles := range counts {
+		stats := ChampionRoles{Champion: champion, Roles: []RoleFrequency{}}
+		for _, games := range roles {
+			stats.Games += games
+		}
+		for _, role := range Roles {
+			if roles[role] > 0 {
+				stats.Roles = append(stats.Roles, RoleFrequency{
+					Role:  role,
+					Games: roles[role],
+					Rate:  float64(roles[role]) / float64(stats.Games),
+				})
+			}
+		}
+		sort.SliceStable(stats.Roles, func(i, j int) bool {
+			return stats.Roles[i].Games > stats.Roles[j].Games
+		})
+		stats.Flex = len(stats.Roles) >= 2
+		distribution[champion] = stats
+	}
+	return distribution
+}
+
+// FlexPicks returns the flex picks with at least minGames games, most played
+// first
+func (d RoleDistribution) FlexPicks(minGames int) []ChampionRoles {
+	flex := []ChampionRoles{}
+	for _, stats := range d {
+		if stats.Flex && stats.Games >= minGames {
+			flex = append(flex, stats)
+		}
+	}
+	sort.Slice(flex, func(i, j int) bool {
+		if flex[i].Games != flex[j].Games {
+			return flex[i].Games > flex[j].Games
+		}
+		return flex[i].Champion < flex[j].Champion
+	})
+	return flex
+}
+
+// RoleAssignment is the role one pick was played or is expected to be played
+// in. Confidence is 1 for known roles and the champion's historical rate in
+// the role otherwise.
+type RoleAssignment struct {
+	Champion   string  `json:"champion"`
+	Role       string  `json:"role"`
+	Source     string  `json:"source,omitempty"`
+	Confidence float64 `json:"confidence"`
+	Flex       bool    `json:"flex"`
+}
+
+// AssignRoles gives every pick of one side a role. Picks that already have a
+// role keep it; the others share the remaining roles in the way that best
+// fits their historical distribution. Champions with no history fit every
+// role equally. The result is ordered by position, with any pick left
+// without a role last.
+func AssignRoles(picks []RoleAssignment, distribution RoleDistribution) []RoleAssignment {
+	assigned := make([]RoleAssignment, len(picks))
+	taken := make(map[string]bool)
+	unknown := []int{}
+	for i, pick := range picks {
+		assigned[i] = pick
+		assigned[i].Flex = distribution[pick.Champion].Flex
+		if role := NormalizeRole(pick.Role); role != "" {
+			assigned[i].Role = role
+			assigned[i].Confidence = 1
+			taken[role] = true
+			continue
+		}
+		assigned[i].Role, assigned[i].Source, assigned[i].Confidence = "", "", 0
+		unknown = append(unknown, i)
+	}
+
+	free := []string{}
+	for _, role := range Roles {
+		if !taken[role] {
+			free = append(free, role)
+		}
+	}
+
+	best := bestRoles(unknown, free, func(i int, role string) float64 {
+		return roleLikelihood(distribution[picks[i].Champion], role)
+	})
+	for n, i := range unknown {
+		if role := best[n]; role != "" {
+			assigned[i].Role = role
+			assigned[i].Source = RoleSourceHistory
+			assigned[i].Confidence = math.Round(distribution[picks[i].Champion].Rate(role)*1000) / 1000
+		}
+	}
+
+	position := make(map[string]int, len(Roles))
+	for i, role := range Roles {
+		position[role] = i
+	}
+	sort.SliceStable(assigned, func(i, j int) bool {
+		pi, ok := position[assigned[i].Role]
+		if !ok {
+			pi = len(Roles)
+		}
+		pj, ok := position[assigned[j].Role]
+		if !ok {
+			pj = len(Roles)
+		}
+		return pi < pj
+	})
+	return assigned
+}
+
+// roleLikelihood is the smoothed log probability of a champion being played
+// in role, so roles it was never seen in stay possible
+func roleLikelihood(stats ChampionRoles, role string) float64 {
+	games := 0
+	for _, frequency := range stats.Roles {
+		if frequency.Role == role {
+			games = frequency.Games
+		}
+	}
+	return math.Log(float64(games+1) / float64(stats.Games+len(Roles)))
+}
+
+// bestRoles tries every way of giving the picks distinct free roles and
+// returns the roles of the most likely one, indexed like picks. When there
+// are more picks than roles the least likely picks are left without one.
+func bestRoles(picks []int, free []string, likelihood func(int, string) float64) []string {
+	best := make([]string, len(picks))
+	current := make([]string, len(picks))
+	used := make([]bool, len(free))
+	bestScore := math.Inf(-1)
+
+	var search func(n, rolesLeft int, score float64)
+	search = func(n, rolesLeft int, score float64) {
+		if n == len(picks) {
+			if score > bestScore {
+				bestScore = score
+				copy(best, current)
+			}
+			return
+		}
+		for r, role := range free {
+			if used[r] {
+				continue
+			}
+			used[r] = true
+			current[n] = role
+			search(n+1, rolesLeft-1, score+likelihood(picks[n], role))
+			used[r] = false
+		}
+		if len(picks)-n > rolesLeft {
+			current[n] = ""
+			search(n+1, rolesLeft, score)
+		}
+	}
+	search(0, len(free), 0)
+	return best
+}
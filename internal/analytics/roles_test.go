@@ -0,0 +1,124 @@
+package analytics
+
+import (
+	"testing"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/draft"
+)
+
+func roleGames() []Game {
+	return []Game{
+		{
+			Blue: draft.Selections{Picks: []string{"Gragas", "Vi", "Azir"}, Roles: []string{"Top", "Jungle", "Mid"}},
+			Red:  draft.Selections{Picks: []string{"Jax", "Gragas", "Orianna"}, Roles: []string{"Top", "Jungle", "Mid"}},
+		},
+		{
+			Blue: draft.Selections{Picks: []string{"Gragas", "Kai'Sa"}, Roles: []string{"Jungle", "Bot"}},
+			Red:  draft.Selections{Picks: []string{"Rell", "Azir"}, Roles: []string{"Support"}},
+		},
+	}
+}
+
+func TestRoleStatistics(t *testing.T) {
+	distribution := RoleStatistics(roleGames())
+
+	gragas := distribution["Gragas"]
+	if gragas.Games != 3 || !gragas.Flex {
+		t.Errorf("expected Gragas to be a flex pick in 3 games, got %+v", gragas)
+	}
+	if gragas.Roles[0].Role != "jungle" || gragas.Roles[0].Games != 2 {
+		t.Errorf("expected jungle to be Gragas's main role, got %+v", gragas.Roles)
+	}
+	if rate := gragas.Rate("top"); rate < 0.333 || rate > 0.334 {
+		t.Errorf("expected Gragas top rate 1/3, got %.3f", rate)
+	}
+
+	if azir := distribution["Azir"]; azir.Games != 1 || azir.Flex {
+		t.Errorf("expected Azir's pick without a role to be left out, got %+v", azir)
+	}
+	if kaisa := distribution["Kai'Sa"]; kaisa.Roles[0].Role != "bottom" {
+		t.Errorf("expected Bot to normalize to bottom, got %+v", kaisa.Roles)
+	}
+}
+
+func TestFlexPicks(t *testing.T) {
+	distribution := RoleStatistics(roleGames())
+
+	flex := distribution.FlexPicks(1)
+	if len(flex) != 1 || flex[0].Champion != "Gragas" {
+		t.Errorf("expected only Gragas to be flex, got %+v", flex)
+	}
+	if flex := distribution.FlexPicks(4); len(flex) != 0 {
+		t.Errorf("expected no flex pick with 4 games, got %+v", flex)
+	}
+}
+
+func TestAssignRoles(t *testing.T) {
+	distribution := RoleStatistics(roleGames())
+
+	tests := []struct {
+		name     string
+		picks    []RoleAssignment
+		expected map[string]string
+	}{
+		{
+			name:     "history",
+			picks:    []RoleAssignment{{Champion: "Kai'Sa"}, {Champion: "Gragas"}, {Champion: "Azir"}},
+			expected: map[string]string{"Kai'Sa": "bottom", "Gragas": "jungle", "Azir": "mid"},
+		},
+		{
+			name:     "known role moves the flex pick",
+			picks:    []RoleAssignment{{Champion: "Vi", Role: "Jungle", Source: RoleSourceDraft}, {Champion: "Gragas"}},
+			expected: map[string]string{"Vi": "jungle", "Gragas": "top"},
+		},
+		{
+			name:     "no history",
+			picks:    []RoleAssignment{{Champion: "Smolder"}},
+			expected: map[string]string{"Smolder": "top"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assigned := AssignRoles(tt.picks, distribution)
+			if len(assigned) != len(tt.picks) {
+				t.Fatalf("expected %d assignments, got %d", len(tt.picks), len(assigned))
+			}
+			for _, assignment := range assigned {
+				if assignment.Role != tt.expected[assignment.Champion] {
+					t.Errorf("expected %s in %s, got %s", assignment.Champion, tt.expected[assignment.Champion], assignment.Role)
+				}
+			}
+		})
+	}
+}
+
+func TestAssignRoles_SourcesAndOrder(t *testing.T) {
+	distribution := RoleStatistics(roleGames())
+
+	assigned := AssignRoles([]RoleAssignment{
+		{Champion: "Rell", Role: "Support", Source: RoleSourceScoreboard},
+		{Champion: "Gragas"},
+	}, distribution)
+
+	if assigned[0].Champion != "Gragas" || assigned[1].Champion != "Rell" {
+		t.Fatalf("expected picks ordered by position, got %+v", assigned)
+	}
+	if assigned[0].Source != RoleSourceHistory || !assigned[0].Flex || assigned[0].Confidence != 0.667 {
+		t.Errorf("expected Gragas inferred from history at 0.667, got %+v", assigned[0])
+	}
+	if assigned[1].Source != RoleSourceScoreboard || assigned[1].Confidence != 1 {
+		t.Errorf("expected Rell's scoreboard role to be kept, got %+v", assigned[1])
+	}
+}
+
+func TestAssignRoles_MorePicksThanRoles(t *testing.T) {
+	picks := []RoleAssignment{
+		{Champion: "A", Role: "top"}, {Champion: "B", Role: "jungle"}, {Champion: "C", Role: "mid"},
+		{Champion: "D", Role: "bottom"}, {Champion: "E"}, {Champion: "F"},
+	}
+
+	assigned := AssignRoles(picks, RoleDistribution{})
+	if assigned[4].Role != "support" || assigned[5].Role != "" {
+		t.Errorf("expected one pick in support and one left without a role, got %+v", assigned[4:])
+	}
+}
@@ -0,0 +1,255 @@
+package analytics
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/draft"
+)
+
+// ChampionCount is how many times a champion appeared in some context, and
+// the share of the games considered that it represents
+type ChampionCount struct {
+	Champion string  `json:"champion"`
+	Count    int     `json:"count"`
+	Rate     float64 `json:"rate"`
+}
+
+// PickTiming splits a champion's picks into blind picks and counter-picks.
+// A pick is a counter-pick when the opponent had already locked in a
+// champion for the same role.
+type PickTiming struct {
+	Champion string `json:"champion"`
+	Blind    int    `json:"blind"`
+	Counter  int    `json:"counter"`
+}
+
+// RosterPlayer is a player whose champion pool is inferred from the team's
+// picks in their role
+type RosterPlayer struct {
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+// PlayerBans counts opponent bans on champions a player has played for the
+// team in the games considered
+type PlayerBans struct {
+	Player    string          `json:"player"`
+	Role      string          `json:"role"`
+	Bans      int             `json:"bans"`
+	Champions []ChampionCount `json:"champions"`
+}
+
+// BanPhases compares what a team bans before and after the first pick phase
+type BanPhases struct {
+	Phase1 []ChampionCount `json:"phase1"`
+	Phase2 []ChampionCount `json:"phase2"`
+
+	// Phase2Only lists champions the team bans in phase 2 but never in phase 1
+	Phase2Only []string `json:"phase2Only"`
+}
+
+// TeamProfile summarizes how a team drafts
+type TeamProfile struct {
+	Team        string                         `json:"team"`
+	Games       int                            `json:"games"`
+	GamesBySide map[draft.Side]int             `json:"gamesBySide"`
+	FirstPicks  map[draft.Side][]ChampionCount `json:"firstPicks"`
+	Bans        map[draft.Side][]ChampionCount `json:"bans"`
+	PickTiming  []PickTiming                   `json:"pickTiming"`
+	BansAgainst []PlayerBans                   `json:"bansAgainst"`
+	BanPhases   BanPhases                      `json:"banPhases"`
+}
+
+// Draft rebuilds the game's draft in tournament order
+func (g Game) Draft() (*draft.Draft, error) {
+	return draft.Assemble(g.BlueTeam, g.RedTeam, g.Blue, g.Red)
+}
+
+// BuildTeamProfile aggregates the drafts team played in games. Games the team
+// did not play, or whose draft cannot be rebuilt, are skipped. roster maps
+// opponent bans to the player whose role pool the banned champion is in.
+func BuildTeamProfile(team string, games []Game, roster []RosterPlayer) TeamProfile {
+	profile := TeamProfile{
+		Team:        team,
+		GamesBySide: map[draft.Side]int{},
+		FirstPicks:  map[draft.Side][]ChampionCount{},
+		Bans:        map[draft.Side][]ChampionCount{},
+	}
+
+	firstPicks := map[draft.Side]map[string]int{draft.Blue: {}, draft.Red: {}}
+	bans := map[draft.Side]map[string]int{draft.Blue: {}, draft.Red: {}}
+	phaseBans := map[draft.Phase]map[string]int{draft.BanPhase1: {}, draft.BanPhase2: {}}
+	timing := map[string]*PickTiming{}
+	pools := map[string]map[string]bool{}
+	opponentBans := []string{}
+
+	for _, game := range games {
+		var side draft.Side
+		switch {
+		case strings.EqualFold(game.BlueTeam, team):
+			side = draft.Blue
+		case strings.EqualFold(game.RedTeam, team):
+			side = draft.Red
+		default:
+			continue
+		}
+
+		d, err := game.Draft()
+		if err != nil {
+			continue
+		}
+		profile.Games++
+		profile.GamesBySide[side]++
+
+		order := d.Order()
+		firstPickSeen := false
+		lockedRoles := map[string]bool{}
+		for i, action := range d.Actions {
+			role := NormalizeRole(action.Role)
+
+			if action.Side != side {
+				if action.Type == draft.Ban && action.Champion != draft.NoBan {
+					opponentBans = append(opponentBans, action.Champion)
+				}
+				if action.Type == draft.Pick && role != "" {
+					lockedRoles[role] = true
+				}
+				continue
+			}
+
+			if action.Type == draft.Ban {
+				if action.Champion == draft.NoBan {
+					continue
+				}
+				bans[side][action.Champion]++
+				phaseBans[order[i].Phase][action.Champion]++
+				continue
+			}
+
+			if !firstPickSeen {
+				firstPicks[side][action.Champion]++
+				firstPickSeen = true
+			}
+
+			if role == "" {
+				continue
+			}
+			if pools[role] == nil {
+				pools[role] = map[string]bool{}
+			}
+			pools[role][action.Champion] = true
+
+			t, ok := timing[action.Champion]
+			if !ok {
+				t = &PickTiming{Champion: action.Champion}
+				timing[action.Champion] = t
+			}
+			if lockedRoles[role] {
+				t.Counter++
+			} else {
+				t.Blind++
+			}
+		}
+	}
+
+	for _, side := range []draft.Side{draft.Blue, draft.Red} {
+		profile.FirstPicks[side] = rankCounts(firstPicks[side], profile.GamesBySide[side])
+		profile.Bans[side] = rankCounts(bans[side], profile.GamesBySide[side])
+	}
+
+	profile.PickTiming = make([]PickTiming, 0, len(timing))
+	for _, t := range timing {
+		profile.PickTiming = append(profile.PickTiming, *t)
+	}
+	sort.Slice(profile.PickTiming, func(i, j int) bool {
+		a, b := profile.PickTiming[i], profile.PickTiming[j]
+		if a.Blind+a.Counter != b.Blind+b.Counter {
+			return a.Blind+a.Counter > b.Blind+b.Counter
+		}
+		return a.Champion < b.Champion
+	})
+
+	profile.BansAgainst = bansAgainst(roster, pools, opponentBans, profile.Games)
+
+	profile.BanPhases = BanPhases{
+		Phase1:     rankCounts(phaseBans[draft.BanPhase1], profile.Games),
+		Phase2:     rankCounts(phaseBans[draft.BanPhase2], profile.Games),
+		Phase2Only: []string{},
+	}
+	for _, count := range profile.BanPhases.Phase2 {
+		if phaseBans[draft.BanPhase1][count.Champion] == 0 {
+			profile.BanPhases.Phase2Only = append(profile.BanPhases.Phase2Only, count.Champion)
+		}
+	}
+
+	return profile
+}
+
+func bansAgainst(roster []RosterPlayer, pools map[string]map[string]bool, opponentBans []string, games int) []PlayerBans {
+	result := []PlayerBans{}
+	for _, player := range roster {
+		role := NormalizeRole(player.Role)
+		pool := pools[role]
+		if len(pool) == 0 {
+			continue
+		}
+
+		counts := map[string]int{}
+		total := 0
+		for _, champion := range opponentBans {
+			if pool[champion] {
+				counts[champion]++
+				total++
+			}
+		}
+		result = append(result, PlayerBans{
+			Player:    player.Name,
+			Role:      role,
+			Bans:      total,
+			Champions: rankCounts(counts, games),
+		})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Bans > result[j].Bans
+	})
+	return result
+}
+
+// rankCounts sorts champion counts from most to least frequent
+func rankCounts(counts map[string]int, games int) []ChampionCount {
+	ranked := make([]ChampionCount, 0, len(counts))
+	for champion, count := range counts {
+		ranked = append(ranked, ChampionCount{
+			Champion: champion,
+			Count:    count,
+			Rate:     rate(count, games),
+		})
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Count != ranked[j].Count {
+			return ranked[i].Count > ranked[j].Count
+		}
+		return ranked[i].Champion < ranked[j].Champion
+	})
+	return ranked
+}
+
+// NormalizeRole maps Leaguepedia and lolesports role names onto one
+// spelling: top, jungle, mid, bottom, support
+func NormalizeRole(role string) string {
+	switch strings.ToLower(strings.TrimSpace(role)) {
+	case "top":
+		return "top"
+	case "jungle", "jng", "jungler":
+		return "jungle"
+	case "mid", "middle":
+		return "mid"
+	case "bot", "bottom", "adc", "ad carry":
+		return "bottom"
+	case "support", "sup", "supp":
+		return "support"
+	}
+	return ""
+}
@@ -0,0 +1,115 @@
+package analytics
+
+import (
+	"testing"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/draft"
+)
+
+func profileGames() []Game {
+	return []Game{
+		{
+			BlueTeam: "T1",
+			RedTeam:  "Gen.G",
+			Winner:   draft.Blue,
+			Blue: draft.Selections{
+				Bans:  []string{"Azir", "Ahri", "Rell", "Vi", "Nautilus"},
+				Picks: []string{"Ashe", "Sejuani", "Taliyah", "K'Sante", "Lulu"},
+				Roles: []string{"Bot", "Jungle", "Mid", "Top", "Support"},
+			},
+			Red: draft.Selections{
+				Bans:  []string{"Kalista", "Varus", "Orianna", "Jax", "Rakan"},
+				Picks: []string{"Xayah", "Maokai", "Corki", "Jayce", "Braum"},
+				Roles: []string{"Bot", "Jungle", "Mid", "Top", "Support"},
+			},
+		},
+		{
+			BlueTeam: "Gen.G",
+			RedTeam:  "T1",
+			Blue:     draft.Selections{Bans: []string{"Ashe"}},
+			Red:      draft.Selections{Bans: []string{"Azir"}},
+		},
+		{
+			BlueTeam: "Hanwha Life Esports",
+			RedTeam:  "Dplus KIA",
+			Blue:     draft.Selections{Bans: []string{"Azir"}},
+		},
+	}
+}
+
+func TestBuildTeamProfile(t *testing.T) {
+	roster := []RosterPlayer{
+		{Name: "Gumayusi", Role: "bottom"},
+		{Name: "Faker", Role: "mid"},
+	}
+	profile := BuildTeamProfile("T1", profileGames(), roster)
+
+	if profile.Games != 2 {
+		t.Fatalf("expected 2 games, got %d", profile.Games)
+	}
+	if profile.GamesBySide[draft.Blue] != 1 || profile.GamesBySide[draft.Red] != 1 {
+		t.Errorf("expected one game per side, got %v", profile.GamesBySide)
+	}
+
+	firstPicks := profile.FirstPicks[draft.Blue]
+	if len(firstPicks) != 1 || firstPicks[0].Champion != "Ashe" || firstPicks[0].Rate != 1 {
+		t.Errorf("expected Ashe as the only blue first pick, got %+v", firstPicks)
+	}
+
+	redBans := profile.Bans[draft.Red]
+	if len(redBans) != 1 || redBans[0].Champion != "Azir" {
+		t.Errorf("expected Azir as the only red side ban, got %+v", redBans)
+	}
+}
+
+func TestBuildTeamProfile_PickTiming(t *testing.T) {
+	profile := BuildTeamProfile("T1", profileGames(), nil)
+
+	expected := map[string]PickTiming{
+		"Ashe":    {Champion: "Ashe", Blind: 1},
+		"Sejuani": {Champion: "Sejuani", Counter: 1},
+		"Taliyah": {Champion: "Taliyah", Blind: 1},
+		"K'Sante": {Champion: "K'Sante", Counter: 1},
+		"Lulu":    {Champion: "Lulu", Blind: 1},
+	}
+
+	if len(profile.PickTiming) != len(expected) {
+		t.Fatalf("expected %d picks, got %d", len(expected), len(profile.PickTiming))
+	}
+	for _, timing := range profile.PickTiming {
+		if timing != expected[timing.Champion] {
+			t.Errorf("expected %+v, got %+v", expected[timing.Champion], timing)
+		}
+	}
+}
+
+func TestBuildTeamProfile_BansAgainst(t *testing.T) {
+	roster := []RosterPlayer{
+		{Name: "Gumayusi", Role: "bottom"},
+		{Name: "Faker", Role: "mid"},
+	}
+	profile := BuildTeamProfile("T1", profileGames(), roster)
+
+	if len(profile.BansAgainst) != 2 {
+		t.Fatalf("expected both players to have a pool, got %+v", profile.BansAgainst)
+	}
+	gumayusi := profile.BansAgainst[0]
+	if gumayusi.Player != "Gumayusi" || gumayusi.Bans != 1 || gumayusi.Champions[0].Champion != "Ashe" {
+		t.Errorf("expected Ashe ban aimed at Gumayusi, got %+v", gumayusi)
+	}
+	if profile.BansAgainst[1].Bans != 0 {
+		t.Errorf("expected no bans aimed at Faker, got %+v", profile.BansAgainst[1])
+	}
+}
+
+func TestBuildTeamProfile_BanPhases(t *testing.T) {
+	profile := BuildTeamProfile("T1", profileGames(), nil)
+
+	if len(profile.BanPhases.Phase1) != 3 || profile.BanPhases.Phase1[0].Champion != "Azir" {
+		t.Errorf("expected Azir to lead three phase 1 bans, got %+v", profile.BanPhases.Phase1)
+	}
+	phase2Only := profile.BanPhases.Phase2Only
+	if len(phase2Only) != 2 || phase2Only[0] != "Nautilus" || phase2Only[1] != "Vi" {
+		t.Errorf("expected [Nautilus Vi] as phase 2 only bans, got %v", phase2Only)
+	}
+}
@@ -0,0 +1,351 @@
+package champions
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/draft"
+)
+
+// DataFile is the Data Dragon file the catalogue is loaded from
+const DataFile = "champion.json"
+
+// IconBaseURL is where Data Dragon serves champion square icons
+const IconBaseURL = "https://ddragon.leagueoflegends.com/cdn"
+
+var ErrUnknownChampion = errors.New("unknown champion")
+
+//go:embed data/champion.json
+var bundled embed.FS
+
+// aliases maps shorthands that do not normalize to a champion ID or display
+// name to the champion ID
+var aliases = map[string]string{
+	"willump": "Nunu",
+	"mundo":   "DrMundo",
+	"j4":      "JarvanIV",
+	"jarvan":  "JarvanIV",
+	"tf":      "TwistedFate",
+	"mf":      "MissFortune",
+	"asol":    "AurelionSol",
+	"yi":      "MasterYi",
+	"xin":     "XinZhao",
+	"lee":     "LeeSin",
+	"kog":     "KogMaw",
+	"tahm":    "TahmKench",
+	"cho":     "Chogath",
+	"kha":     "Khazix",
+	"fiddle":  "Fiddlesticks",
+	"heimer":  "Heimerdinger",
+	"cass":    "Cassiopeia",
+	"morde":   "Mordekaiser",
+	"gp":      "Gangplank",
+	"voli":    "Volibear",
+	"blitz":   "Blitzcrank",
+	"naut":    "Nautilus",
+}
+
+// releases maps champions released since patch 10.15 to their release
+// patch. Data Dragon has no release information, and older champions are
+// legal on every patch a draft could be played on. The table is kept by
+// hand and covers the bundled snapshot; champions released after it are
+// legal on every patch until they are added, see MissingReleases.
+var releases = map[string]string{
+	"Lillia":    "10.15",
+	"Yone":      "10.16",
+	"Samira":    "10.18",
+	"Seraphine": "10.22",
+	"Rell":      "10.23",
+	"Viego":     "11.2",
+	"Gwen":      "11.8",
+	"Akshan":    "11.15",
+	"Vex":       "11.19",
+	"Zeri":      "12.2",
+	"Renata":    "12.4",
+	"Belveth":   "12.11",
+	"Nilah":     "12.13",
+	"KSante":    "12.21",
+	"Milio":     "13.5",
+	"Naafiri":   "13.7",
+	"Briar":     "13.18",
+	"Hwei":      "13.24",
+	"Smolder":   "14.3",
+	"Aurora":    "14.14",
+	"Ambessa":   "14.22",
+}
+
+// Champion is one entry of the catalogue. ID is the Data Dragon and Riot
+// match-v5 identifier ("MonkeyKing"), Key the numeric ID used by livestats
+// and the static data, Name the display name Leaguepedia also uses ("Wukong")
+type Champion struct {
+	ID    string   `json:"id"`
+	Key   int      `json:"key"`
+	Name  string   `json:"name"`
+	Title string   `json:"title"`
+	Tags  []string `json:"tags"`
+	Image string   `json:"image"`
+	Icon  string   `json:"icon"`
+
+	// ReleasePatch is the patch the champion came out on, when it is recent
+	// enough to matter for patch validation
+	ReleasePatch string `json:"releasePatch,omitempty"`
+}
+
+// HasTag reports whether the champion carries the Data Dragon class tag
+func (c Champion) HasTag(tag string) bool {
+	for _, t := range c.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+type dataDragonFile struct {
+	Version string `json:"version"`
+	Data    map[string]struct {
+		ID    string   `json:"id"`
+		Key   string   `json:"key"`
+		Name  string   `json:"name"`
+		Title string   `json:"title"`
+		Tags  []string `json:"tags"`
+		Image struct {
+			Full string `json:"full"`
+		} `json:"image"`
+	} `json:"data"`
+}
+
+// Catalogue resolves every spelling of a champion to one entry
+type Catalogue struct {
+	version   string
+	champions []Champion
+	byKey     map[int]int
+	byName    map[string]int
+}
+
+// Load reads champion.json from dir, which should point at a Data Dragon
+// data/<locale> directory. An empty dir loads the bundled snapshot.
+func Load(dir string) (*Catalogue, error) {
+	if dir == "" {
+		return LoadBundled()
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, DataFile))
+	if err != nil {
+		return nil, fmt.Errorf("error reading champion data: %w", err)
+	}
+	return Parse(data)
+}
+
+// LoadBundled loads the Data Dragon snapshot compiled into the binary
+func LoadBundled() (*Catalogue, error) {
+	data, err := bundled.ReadFile("data/" + DataFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading bundled champion data: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse builds a catalogue from the contents of a Data Dragon champion.json
+func Parse(data []byte) (*Catalogue, error) {
+	var file dataDragonFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("error decoding champion data: %w", err)
+	}
+	if len(file.Data) == 0 {
+		return nil, fmt.Errorf("champion data has no champions")
+	}
+
+	c := &Catalogue{
+		version: file.Version,
+		byKey:   make(map[int]int),
+		byName:  make(map[string]int),
+	}
+
+	for _, entry := range file.Data {
+		key, err := strconv.Atoi(entry.Key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %q for champion %s: %w", entry.Key, entry.ID, err)
+		}
+		c.champions = append(c.champions, Champion{
+			ID:    entry.ID,
+			Key:   key,
+			Name:  entry.Name,
+			Title: entry.Title,
+			Tags:  entry.Tags,
+			Image: entry.Image.Full,
+			Icon:  fmt.Sprintf("%s/%s/img/champion/%s", IconBaseURL, file.Version, entry.Image.Full),
+
+			ReleasePatch: releases[entry.ID],
+		})
+	}
+	sort.Slice(c.champions, func(i, j int) bool {
+		return c.champions[i].Name < c.champions[j].Name
+	})
+
+	byID := make(map[string]int)
+	for i, champion := range c.champions {
+		c.byKey[champion.Key] = i
+		c.byName[normalize(champion.ID)] = i
+		c.byName[normalize(champion.Name)] = i
+		byID[champion.ID] = i
+	}
+	for alias, id := range aliases {
+		if i, ok := byID[id]; ok {
+			if _, taken := c.byName[alias]; !taken {
+				c.byName[alias] = i
+			}
+		}
+	}
+
+	return c, nil
+}
+
+// Version is the Data Dragon patch the catalogue was built from
+func (c *Catalogue) Version() string {
+	return c.version
+}
+
+// MissingReleases returns the champions newer than the bundled snapshot
+// that the releases table has no patch for. Patch validation cannot tell
+// when they came out and treats them as always available.
+func (c *Catalogue) MissingReleases() []Champion {
+	var file dataDragonFile
+	if data, err := bundled.ReadFile("data/" + DataFile); err == nil {
+		_ = json.Unmarshal(data, &file)
+	}
+
+	missing := []Champion{}
+	for _, champion := range c.champions {
+		if _, ok := file.Data[champion.ID]; !ok && champion.ReleasePatch == "" {
+			missing = append(missing, champion)
+		}
+	}
+	return missing
+}
+
+// All returns every champion sorted by display name
+func (c *Catalogue) All() []Champion {
+	champions := make([]Champion, len(c.champions))
+	copy(champions, c.champions)
+	return champions
+}
+
+// ByKey looks a champion up by its numeric key
+func (c *Catalogue) ByKey(key int) (Champion, bool) {
+	i, ok := c.byKey[key]
+	if !ok {
+		return Champion{}, false
+	}
+	return c.champions[i], true
+}
+
+// Resolve finds a champion from any of its spellings: ID ("MonkeyKing"),
+// numeric key ("62"), display name ("Wukong") or a Leaguepedia or shorthand
+// spelling ("Nunu", "J4"). Case, spaces and punctuation are ignored.
+func (c *Catalogue) Resolve(name string) (Champion, bool) {
+	name = strings.TrimSpace(name)
+	if key, err := strconv.Atoi(name); err == nil {
+		return c.ByKey(key)
+	}
+
+	i, ok := c.byName[normalize(name)]
+	if !ok {
+		return Champion{}, false
+	}
+	return c.champions[i], true
+}
+
+// Canonical returns the display name for any spelling of a champion. Empty
+// names and NoBan are returned as NoBan, since they mark a skipped ban.
+func (c *Catalogue) Canonical(name string) (string, error) {
+	if strings.TrimSpace(name) == "" || strings.EqualFold(name, draft.NoBan) {
+		return draft.NoBan, nil
+	}
+
+	champion, ok := c.Resolve(name)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownChampion, name)
+	}
+	return champion.Name, nil
+}
+
+// Search returns the champions whose name or ID contains query and that
+// carry tag. Either filter may be empty.
+func (c *Catalogue) Search(query, tag string) []Champion {
+	query = normalize(query)
+
+	champions := []Champion{}
+	for _, champion := range c.champions {
+		if tag != "" && !champion.HasTag(tag) {
+			continue
+		}
+		if query != "" &&
+			!strings.Contains(normalize(champion.Name), query) &&
+			!strings.Contains(normalize(champion.ID), query) {
+			continue
+		}
+		champions = append(champions, champion)
+	}
+	return champions
+}
+
+// NormalizeNames maps a list of spellings, such as a DisabledChampions
+// field, to display names. Unknown names are reported together.
+func (c *Catalogue) NormalizeNames(names []string) ([]string, error) {
+	normalized := make([]string, 0, len(names))
+	var errs []error
+	for _, name := range names {
+		canonical, err := c.Canonical(name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		normalized = append(normalized, canonical)
+	}
+	return normalized, errors.Join(errs...)
+}
+
+// NormalizeDraft rewrites every action and swap of d to display names
+func (c *Catalogue) NormalizeDraft(d *draft.Draft) error {
+	var errs []error
+	for i, action := range d.Actions {
+		canonical, err := c.Canonical(action.Champion)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		d.Actions[i].Champion = canonical
+	}
+	for i, swap := range d.Swaps {
+		for j, champion := range swap.Champions {
+			canonical, err := c.Canonical(champion)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			d.Swaps[i].Champions[j] = canonical
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// normalize lowercases a spelling and drops everything but letters and
+// digits, so "Kai'Sa", "KaiSa" and "kaisa" compare equal
+func normalize(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,187 @@
+package champions
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/draft"
+)
+
+func bundledCatalogue(t *testing.T) *Catalogue {
+	t.Helper()
+	c, err := LoadBundled()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	return c
+}
+
+func TestLoadBundled(t *testing.T) {
+	c := bundledCatalogue(t)
+
+	if c.Version() == "" {
+		t.Error("expected bundled snapshot to have a version")
+	}
+	if len(c.All()) < 160 {
+		t.Errorf("expected at least 160 champions, got %d", len(c.All()))
+	}
+}
+
+func TestResolve(t *testing.T) {
+	c := bundledCatalogue(t)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"data dragon id", "MonkeyKing", "Wukong"},
+		{"display name", "Wukong", "Wukong"},
+		{"numeric key", "62", "Wukong"},
+		{"leaguepedia spelling", "Kai'Sa", "Kai'Sa"},
+		{"lowercase id", "kaisa", "Kai'Sa"},
+		{"riot match casing", "FiddleSticks", "Fiddlesticks"},
+		{"ampersand name", "Nunu & Willump", "Nunu & Willump"},
+		{"short name", "Nunu", "Nunu & Willump"},
+		{"renata", "Renata", "Renata Glasc"},
+		{"shorthand", "J4", "Jarvan IV"},
+		{"spaces", "  lee sin ", "Lee Sin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			champion, ok := c.Resolve(tt.input)
+			if !ok {
+				t.Fatalf("expected %q to resolve", tt.input)
+			}
+			if champion.Name != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, champion.Name)
+			}
+		})
+	}
+
+	if _, ok := c.Resolve("Not A Champion"); ok {
+		t.Error("expected unknown champion not to resolve")
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	c := bundledCatalogue(t)
+
+	name, err := c.Canonical("")
+	if err != nil || name != draft.NoBan {
+		t.Errorf("expected empty name to be %s, got %q (%v)", draft.NoBan, name, err)
+	}
+
+	_, err = c.Canonical("Teemo2")
+	if !errors.Is(err, ErrUnknownChampion) {
+		t.Errorf("expected ErrUnknownChampion, got %v", err)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	c := bundledCatalogue(t)
+
+	supports := c.Search("", "support")
+	if len(supports) == 0 {
+		t.Fatal("expected support champions")
+	}
+	for _, champion := range supports {
+		if !champion.HasTag("Support") {
+			t.Errorf("expected %s to be tagged Support", champion.Name)
+		}
+	}
+
+	results := c.Search("sin", "")
+	found := false
+	for _, champion := range results {
+		if champion.Name == "Lee Sin" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected Lee Sin to match query sin")
+	}
+}
+
+func TestNormalizeDraft(t *testing.T) {
+	c := bundledCatalogue(t)
+
+	d := draft.New("T1", "Gen.G")
+	d.Actions = []draft.Action{
+		{Side: draft.Blue, Type: draft.Ban, Champion: "kaisa"},
+		{Side: draft.Red, Type: draft.Ban, Champion: ""},
+		{Side: draft.Blue, Type: draft.Ban, Champion: "MonkeyKing"},
+	}
+
+	if err := c.NormalizeDraft(d); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := []string{"Kai'Sa", draft.NoBan, "Wukong"}
+	for i, action := range d.Actions {
+		if action.Champion != expected[i] {
+			t.Errorf("expected action %d to be %s, got %s", i, expected[i], action.Champion)
+		}
+	}
+}
+
+func TestNormalizeNames(t *testing.T) {
+	c := bundledCatalogue(t)
+
+	names, err := c.NormalizeNames([]string{"Ksante", "Unknown", "Belveth"})
+	if !errors.Is(err, ErrUnknownChampion) {
+		t.Errorf("expected ErrUnknownChampion, got %v", err)
+	}
+	if len(names) != 2 || names[0] != "K'Sante" || names[1] != "Bel'Veth" {
+		t.Errorf("expected [K'Sante Bel'Veth], got %v", names)
+	}
+}
+
+func TestLoad_Directory(t *testing.T) {
+	dir := t.TempDir()
+	data := `{"version":"1.0.0","data":{"Ahri":{"id":"Ahri","key":"103","name":"Ahri","tags":["Mage"],"image":{"full":"Ahri.png"}}}}`
+	if err := os.WriteFile(filepath.Join(dir, DataFile), []byte(data), 0o644); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	c, err := Load(dir)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	champion, ok := c.ByKey(103)
+	if !ok {
+		t.Fatal("expected key 103 to resolve")
+	}
+	if champion.Icon != IconBaseURL+"/1.0.0/img/champion/Ahri.png" {
+		t.Errorf("unexpected icon url %s", champion.Icon)
+	}
+}
+
+func TestMissingReleases(t *testing.T) {
+	if missing := bundledCatalogue(t).MissingReleases(); len(missing) != 0 {
+		t.Errorf("expected the releases table to cover the bundled snapshot, got %v", missing)
+	}
+
+	c, err := Parse([]byte(`{"version":"15.5.1","data":{
+		"Ahri":{"id":"Ahri","key":"103","name":"Ahri"},
+		"Ambessa":{"id":"Ambessa","key":"799","name":"Ambessa"},
+		"Mel":{"id":"Mel","key":"800","name":"Mel"}}}`))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	missing := c.MissingReleases()
+	if len(missing) != 1 || missing[0].ID != "Mel" {
+		t.Errorf("expected only Mel to miss release data, got %v", missing)
+	}
+}
+
+func TestParse_InvalidKey(t *testing.T) {
+	_, err := Parse([]byte(`{"version":"1.0.0","data":{"Ahri":{"id":"Ahri","key":"x","name":"Ahri"}}}`))
+	if err == nil {
+		t.Error("expected error for non-numeric key")
+	}
+}
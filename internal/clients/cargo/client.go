@@ -82,31 +82,39 @@ func (c *Client) SetBaseURL(url string) {
 }
 
 func (c *Client) Query(query *cargo_query.CargoQuery) (*CargoResponse, error) {
-	queryString := query.ToQuery()
-	fullURL := fmt.Sprintf("%s?format=json&%s", c.BaseURL, queryString)
+	var cargoResponse CargoResponse
+	if err := c.queryInto(query, &cargoResponse); err != nil {
+		return nil, err
+	}
+
+	return &cargoResponse, nil
+}
+
+// queryInto runs a cargo query and decodes the response into out, so tables
+// other than NewsItems can use their own title structs
+func (c *Client) queryInto(query *cargo_query.CargoQuery, out interface{}) error {
+	fullURL := fmt.Sprintf("%s?format=json&%s", c.BaseURL, query.ToQuery())
 
 	req, err := http.NewRequest("GET", fullURL, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error creating request: %w", err)
+		return fmt.Errorf("error creating request: %w", err)
 	}
 
 	resp, err := c.HttpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("error making request: %w", err)
+		return fmt.Errorf("error making request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := ioutil.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
 	}
 
-	var cargoResponse CargoResponse
-	if err := json.NewDecoder(resp.Body).Decode(&cargoResponse); err != nil {
-		return nil, fmt.Errorf("error decoding response: %w", err)
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("error decoding response: %w", err)
 	}
-
-	return &cargoResponse, nil
+	return nil
 }
 
 func (c *Client) GetNewsLatest() ([]news_items.NewsItems, error) {
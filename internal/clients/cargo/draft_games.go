@@ -0,0 +1,90 @@
+package cargo
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/clients/cargo/model/cargo_query"
+	"github.com/gvieiragoulart/draft-visualizer/internal/clients/cargo/model/draft_games"
+)
+
+type DraftGamesResponse struct {
+	CargoQuery []DraftGamesItem `json:"cargoquery"`
+}
+
+type DraftGamesItem struct {
+	Title DraftGamesTitle `json:"title"`
+}
+
+type DraftGamesTitle struct {
+	PicksAndBansTitle
+	Patch       string `json:"Patch"`
+	DateTimeUTC string `json:"DateTime UTC"`
+	League      string `json:"League"`
+}
+
+// GetDraftGames returns the drafts matching filter together with their patch,
+// date and league, following cargo's offset pagination. It returns
+// ErrTooManyRows rather than a partial list when the page cap is reached.
+func (c *Client) GetDraftGames(filter draft_games.Filter) ([]draft_games.DraftGame, error) {
+	where := draftGamesWhere(filter)
+
+	games := []draft_games.DraftGame{}
+	for page := 0; page < cargoMaxPages; page++ {
+		query := cargo_query.NewCargoQuery(
+			draft_games.Tables(),
+			draft_games.GetFields(),
+			url.QueryEscape(where),
+			url.QueryEscape(draft_games.JoinOn()),
+			"",
+			"",
+			url.QueryEscape("SG.DateTime_UTC,PB.N_GameInMatch"),
+			page*cargoPageSize,
+			cargoPageSize,
+		)
+
+		var response DraftGamesResponse
+		if err := c.queryInto(query, &response); err != nil {
+			return nil, fmt.Errorf("error querying draft games: %w", err)
+		}
+
+		for _, item := range response.CargoQuery {
+			games = append(games, draft_games.DraftGame{
+				PicksAndBans: c.parsePicksAndBans(item.Title.PicksAndBansTitle),
+				Patch:        item.Title.Patch,
+				DateTimeUTC:  c.parseTimeString(item.Title.DateTimeUTC),
+				League:       item.Title.League,
+			})
+		}
+
+		if len(response.CargoQuery) < cargoPageSize {
+			return games, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: more than %d draft games, narrow the filter", ErrTooManyRows, cargoMaxPages*cargoPageSize)
+}
+
+func draftGamesWhere(filter draft_games.Filter) string {
+	conditions := []string{}
+	if filter.Team != "" {
+		team := cargoEscape(filter.Team)
+		conditions = append(conditions, fmt.Sprintf("(PB.Team1=\"%s\" OR PB.Team2=\"%s\")", team, team))
+	}
+	if filter.Tournament != "" {
+		conditions = append(conditions, fmt.Sprintf("PB.OverviewPage=\"%s\"", cargoEscape(filter.Tournament)))
+	}
+	if filter.League != "" {
+		conditions = append(conditions, fmt.Sprintf("T.League=\"%s\"", cargoEscape(filter.League)))
+	}
+	if filter.Patch != "" {
+		conditions = append(conditions, fmt.Sprintf("SG.Patch=\"%s\"", cargoEscape(filter.Patch)))
+	}
+	if filter.From != nil {
+		conditions = append(conditions, fmt.Sprintf("SG.DateTime_UTC>=\"%s\"", filter.From.UTC().Format("2006-01-02 15:04:05")))
+	}
+	if filter.To != nil {
+		conditions = append(conditions, fmt.Sprintf("SG.DateTime_UTC<=\"%s\"", filter.To.UTC().Format("2006-01-02 15:04:05")))
+	}
+	return strings.Join(conditions, " AND ")
+}
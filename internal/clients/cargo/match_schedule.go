@@ -0,0 +1,165 @@
+package cargo
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/clients/cargo/model/cargo_query"
+	match_schedule "github.com/gvieiragoulart/draft-visualizer/internal/clients/cargo/model/match_shedule"
+)
+
+type MatchScheduleResponse struct {
+	CargoQuery []MatchScheduleItem `json:"cargoquery"`
+}
+
+// MatchScheduleItem keeps the title generic because MatchSchedule has too
+// many columns to mirror one by one
+type MatchScheduleItem struct {
+	Title map[string]interface{} `json:"title"`
+}
+
+// GetMatchSchedule returns the matches of a tournament overview page
+func (c *Client) GetMatchSchedule(tournament string) ([]match_schedule.MatchSchedule, error) {
+	return c.queryMatchSchedule(fmt.Sprintf("OverviewPage=\"%s\"", cargoEscape(tournament)))
+}
+
+// GetMatchScheduleByID returns the schedule row of a single match, or nil
+// when Leaguepedia has no match with that ID
+func (c *Client) GetMatchScheduleByID(matchID string) (*match_schedule.MatchSchedule, error) {
+	matches, err := c.queryMatchSchedule(fmt.Sprintf("MatchId=\"%s\"", cargoEscape(matchID)))
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		return nil, nil
+	}
+	return &matches[0], nil
+}
+
+// queryMatchSchedule follows cargo's offset pagination like the other
+// queries, returning ErrTooManyRows when the page cap is reached
+func (c *Client) queryMatchSchedule(where string) ([]match_schedule.MatchSchedule, error) {
+	matches := []match_schedule.MatchSchedule{}
+	for page := 0; page < cargoMaxPages; page++ {
+		query := cargo_query.NewCargoQuery(
+			[]string{"MatchSchedule"},
+			match_schedule.GetFields(),
+			url.QueryEscape(where),
+			"",
+			"",
+			"",
+			url.QueryEscape("N_Page,N_TabInPage,N_MatchInPage"),
+			page*cargoPageSize,
+			cargoPageSize,
+		)
+
+		var response MatchScheduleResponse
+		if err := c.queryInto(query, &response); err != nil {
+			return nil, fmt.Errorf("error querying match schedule: %w", err)
+		}
+
+		for _, item := range response.CargoQuery {
+			matches = append(matches, c.parseMatchSchedule(item.Title))
+		}
+
+		if len(response.CargoQuery) < cargoPageSize {
+			return matches, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: more than %d scheduled matches", ErrTooManyRows, cargoMaxPages*cargoPageSize)
+}
+
+func (c *Client) parseMatchSchedule(title map[string]interface{}) match_schedule.MatchSchedule {
+	str := func(name string) string { return parseString(cargoField(title, name)) }
+	num := func(name string) *int { return parseInt(cargoField(title, name)) }
+	flag := func(name string) *bool { return parseBool(cargoField(title, name)) }
+	list := func(name string) []string { return parseStringSlice(cargoField(title, name)) }
+
+	return match_schedule.MatchSchedule{
+		Team1:       str("Team1"),
+		Team2:       str("Team2"),
+		Team1Final:  str("Team1Final"),
+		Team2Final:  str("Team2Final"),
+		Winner:      str("Winner"),
+		Team1Poster: str("Team1Poster"),
+		Team2Poster: str("Team2Poster"),
+
+		Team1Points:    num("Team1Points"),
+		Team2Points:    num("Team2Points"),
+		Team1PointsTB:  num("Team1PointsTB"),
+		Team2PointsTB:  num("Team2PointsTB"),
+		Team1Score:     num("Team1Score"),
+		Team2Score:     num("Team2Score"),
+		Team1Advantage: num("Team1Advantage"),
+		Team2Advantage: num("Team2Advantage"),
+
+		FF:          num("FF"),
+		IsNullified: flag("IsNullified"),
+
+		Player1: str("Player1"),
+		Player2: str("Player2"),
+
+		MatchDay:        num("MatchDay"),
+		DateTimeUTC:     c.parseTimeString(str("DateTime_UTC")),
+		HasTime:         flag("HasTime"),
+		DST:             str("DST"),
+		IsFlexibleStart: flag("IsFlexibleStart"),
+		IsReschedulable: flag("IsReschedulable"),
+
+		OverrideAllowPredictions:    flag("OverrideAllowPredictions"),
+		OverrideDisallowPredictions: flag("OverrideDisallowPredictions"),
+		IsTiebreaker:                flag("IsTiebreaker"),
+		BestOf:                      num("BestOf"),
+
+		OverviewPage: str("OverviewPage"),
+		ShownName:    str("ShownName"),
+		ShownRound:   str("ShownRound"),
+		Round:        str("Round"),
+		Phase:        str("Phase"),
+		GroupName:    str("GroupName"),
+
+		N_MatchInPage:       num("N_MatchInPage"),
+		Tab:                 str("Tab"),
+		N_MatchInTab:        num("N_MatchInTab"),
+		N_TabInPage:         num("N_TabInPage"),
+		N_Page:              num("N_Page"),
+		InitialN_MatchInTab: num("InitialN_MatchInTab"),
+		InitialPageAndTab:   str("InitialPageAndTab"),
+
+		Patch:             str("Patch"),
+		LegacyPatch:       str("LegacyPatch"),
+		PatchPage:         str("PatchPage"),
+		Hotfix:            str("Hotfix"),
+		DisabledChampions: list("DisabledChampions"),
+		PatchFootnote:     str("PatchFootnote"),
+
+		Stream:        str("Stream"),
+		StreamDisplay: str("StreamDisplay"),
+		Venue:         str("Venue"),
+		CastersPBP:    str("CastersPBP"),
+		CastersColor:  str("CastersColor"),
+		Casters:       list("Casters"),
+
+		MVP:       str("MVP"),
+		MVPPoints: num("MVPPoints"),
+
+		VodInterview:  str("VodInterview"),
+		VodHighlights: str("VodHighlights"),
+		InterviewWith: list("InterviewWith"),
+		Recap:         str("Recap"),
+		Reddit:        str("Reddit"),
+
+		QQ:            num("QQ"),
+		Wanplus:       str("Wanplus"),
+		WanplusId:     num("WanplusId"),
+		PageAndTeam1:  str("PageAndTeam1"),
+		PageAndTeam2:  str("PageAndTeam2"),
+		Team1Footnote: str("Team1Footnote"),
+		Team2Footnote: str("Team2Footnote"),
+		Footnote:      str("Footnote"),
+
+		UniqueMatch: str("UniqueMatch"),
+		MatchId:     str("MatchId"),
+		Tags:        list("Tags"),
+	}
+}
@@ -0,0 +1,71 @@
+package draft_games
+
+import (
+	"time"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/clients/cargo/model/picks_and_bans"
+)
+
+// Table aliases used when PicksAndBansS7 is joined with ScoreboardGames and
+// Tournaments
+const (
+	PicksAndBansAlias    = "PB"
+	ScoreboardGamesAlias = "SG"
+	TournamentsAlias     = "T"
+)
+
+// DraftGame is a PicksAndBansS7 row enriched with the patch and date of the
+// game and the league of its tournament
+type DraftGame struct {
+	picks_and_bans.PicksAndBans
+
+	Patch       string     `json:"Patch"`
+	DateTimeUTC *time.Time `json:"DateTime_UTC"`
+	League      string     `json:"League"`
+}
+
+// Filter narrows the games returned by the cargo client. Empty fields are
+// ignored.
+type Filter struct {
+	// Team matches games where the team played on either side
+	Team       string     `json:"team"`
+	Tournament string     `json:"tournament"`
+	League     string     `json:"league"`
+	Patch      string     `json:"patch"`
+	From       *time.Time `json:"from"`
+	To         *time.Time `json:"to"`
+}
+
+// Empty reports whether no filter is set
+func (f Filter) Empty() bool {
+	return f.Team == "" && f.Tournament == "" && f.League == "" && f.Patch == "" && f.From == nil && f.To == nil
+}
+
+// Tables returns the joined tables with their aliases
+func Tables() []string {
+	return []string{
+		"PicksAndBansS7=" + PicksAndBansAlias,
+		"ScoreboardGames=" + ScoreboardGamesAlias,
+		"Tournaments=" + TournamentsAlias,
+	}
+}
+
+// JoinOn links a draft to its scoreboard game and tournament
+func JoinOn() string {
+	return PicksAndBansAlias + ".GameId=" + ScoreboardGamesAlias + ".GameId," +
+		PicksAndBansAlias + ".OverviewPage=" + TournamentsAlias + ".OverviewPage"
+}
+
+// GetFields returns every PicksAndBansS7 field plus the joined columns,
+// prefixed with their table alias
+func GetFields() []string {
+	fields := []string{}
+	for _, field := range picks_and_bans.GetFields() {
+		fields = append(fields, PicksAndBansAlias+"."+field)
+	}
+	return append(fields,
+		ScoreboardGamesAlias+".Patch",
+		ScoreboardGamesAlias+".DateTime_UTC",
+		TournamentsAlias+".League",
+	)
+}
@@ -0,0 +1,32 @@
+package draft_games
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/clients/cargo/model/picks_and_bans"
+)
+
+func TestGetFields(t *testing.T) {
+	fields := GetFields()
+	if len(fields) != len(picks_and_bans.GetFields())+3 {
+		t.Errorf("expected %d fields, got %d", len(picks_and_bans.GetFields())+3, len(fields))
+	}
+
+	for _, field := range fields {
+		if !strings.HasPrefix(field, PicksAndBansAlias+".") &&
+			!strings.HasPrefix(field, ScoreboardGamesAlias+".") &&
+			!strings.HasPrefix(field, TournamentsAlias+".") {
+			t.Errorf("expected field %s to be prefixed with a table alias", field)
+		}
+	}
+}
+
+func TestFilter_Empty(t *testing.T) {
+	if !(Filter{}).Empty() {
+		t.Error("expected zero filter to be empty")
+	}
+	if (Filter{Patch: "14.13"}).Empty() {
+		t.Error("expected filter with a patch not to be empty")
+	}
+}
@@ -0,0 +1,67 @@
+package picks_and_bans
+
+// Leaguepedia always stores the blue side team as Team1 in PicksAndBansS7
+const (
+	SideBlue = "Blue"
+	SideRed  = "Red"
+)
+
+type PicksAndBans struct {
+	Team1  string `json:"Team1"`
+	Team2  string `json:"Team2"`
+	Winner *int   `json:"Winner"`
+
+	Team1Score *int `json:"Team1Score"`
+	Team2Score *int `json:"Team2Score"`
+
+	Team1Side string `json:"Team1Side"`
+	Team2Side string `json:"Team2Side"`
+
+	// Bans and picks are kept in the order each team locked them in
+	Team1Bans  []string `json:"Team1Bans"`
+	Team2Bans  []string `json:"Team2Bans"`
+	Team1Picks []string `json:"Team1Picks"`
+	Team2Picks []string `json:"Team2Picks"`
+
+	// Team1Roles[i] is the role played by Team1Picks[i]
+	Team1Roles []string `json:"Team1Roles"`
+	Team2Roles []string `json:"Team2Roles"`
+
+	Team1PicksByRoleOrder []string `json:"Team1PicksByRoleOrder"`
+	Team2PicksByRoleOrder []string `json:"Team2PicksByRoleOrder"`
+
+	OverviewPage string `json:"OverviewPage"`
+	Phase        string `json:"Phase"`
+	Tab          string `json:"Tab"`
+	UniqueLine   string `json:"UniqueLine"`
+
+	N_Page        *int `json:"N_Page"`
+	N_TabInPage   *int `json:"N_TabInPage"`
+	N_MatchInPage *int `json:"N_MatchInPage"`
+	N_GameInMatch *int `json:"N_GameInMatch"`
+
+	IsComplete  *bool `json:"IsComplete"`
+	IsFilled    *bool `json:"IsFilled"`
+	IsNullified *bool `json:"IsNullified"`
+
+	GameId      string `json:"GameId"`
+	MatchId     string `json:"MatchId"`
+	GameID_Wiki string `json:"GameID_Wiki"`
+}
+
+// GetFields returns all field names for PicksAndBansS7
+func GetFields() []string {
+	return []string{
+		"Team1", "Team2", "Winner", "Team1Score", "Team2Score",
+		"Team1Role1", "Team1Role2", "Team1Role3", "Team1Role4", "Team1Role5",
+		"Team2Role1", "Team2Role2", "Team2Role3", "Team2Role4", "Team2Role5",
+		"Team1Ban1", "Team1Ban2", "Team1Ban3", "Team1Ban4", "Team1Ban5",
+		"Team2Ban1", "Team2Ban2", "Team2Ban3", "Team2Ban4", "Team2Ban5",
+		"Team1Pick1", "Team1Pick2", "Team1Pick3", "Team1Pick4", "Team1Pick5",
+		"Team2Pick1", "Team2Pick2", "Team2Pick3", "Team2Pick4", "Team2Pick5",
+		"Team1PicksByRoleOrder", "Team2PicksByRoleOrder",
+		"OverviewPage", "Phase", "UniqueLine", "IsComplete", "IsFilled",
+		"IsNullified", "Tab", "N_Page", "N_TabInPage", "N_MatchInPage",
+		"N_GameInMatch", "GameId", "MatchId", "GameID_Wiki",
+	}
+}
@@ -0,0 +1,26 @@
+package picks_and_bans
+
+import (
+	"testing"
+)
+
+func TestGetFields(t *testing.T) {
+	fields := GetFields()
+	if len(fields) != 51 {
+		t.Errorf("expected 51 fields, got %d", len(fields))
+	}
+
+	fieldMap := make(map[string]bool)
+	for _, field := range fields {
+		fieldMap[field] = true
+	}
+	expectedFields := []string{
+		"Team1Ban1", "Team2Ban5", "Team1Pick1", "Team2Pick5",
+		"Team1Role1", "N_GameInMatch", "GameId", "MatchId",
+	}
+	for _, expected := range expectedFields {
+		if !fieldMap[expected] {
+			t.Errorf("expected field '%s' not found", expected)
+		}
+	}
+}
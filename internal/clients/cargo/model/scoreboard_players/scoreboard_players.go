@@ -0,0 +1,33 @@
+package scoreboard_players
+
+// ScoreboardPlayer is one player's line of a Leaguepedia game scoreboard:
+// the champion they played and the role they played it in
+type ScoreboardPlayer struct {
+	OverviewPage string `json:"OverviewPage"`
+	Link         string `json:"Link"`
+	Team         string `json:"Team"`
+	Champion     string `json:"Champion"`
+	Role         string `json:"Role"`
+	IngameRole   string `json:"IngameRole"`
+	// Side is 1 for blue and 2 for red
+	Side       *int   `json:"Side"`
+	RoleNumber *int   `json:"Role_Number"`
+	GameId     string `json:"GameId"`
+	MatchId    string `json:"MatchId"`
+}
+
+// PlayedRole returns the role the champion was actually played in, which
+// differs from the player's listed role when a flex pick was swapped
+func (p *ScoreboardPlayer) PlayedRole() string {
+	if p.IngameRole != "" {
+		return p.IngameRole
+	}
+	return p.Role
+}
+
+func GetFields() []string {
+	return []string{
+		"OverviewPage", "Link", "Team", "Champion", "Role", "IngameRole",
+		"Side", "Role_Number", "GameId", "MatchId",
+	}
+}
@@ -0,0 +1,29 @@
+package scoreboard_players
+
+import "testing"
+
+func TestGetFields(t *testing.T) {
+	fields := GetFields()
+	if len(fields) != 10 {
+		t.Errorf("expected 10 fields, got %d", len(fields))
+	}
+}
+
+func TestPlayedRole(t *testing.T) {
+	tests := []struct {
+		name     string
+		player   ScoreboardPlayer
+		expected string
+	}{
+		{"listed role", ScoreboardPlayer{Role: "Top"}, "Top"},
+		{"ingame role wins", ScoreboardPlayer{Role: "Top", IngameRole: "Mid"}, "Mid"},
+		{"no role", ScoreboardPlayer{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.player.PlayedRole(); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package cargo
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/clients/cargo/model/cargo_query"
+	"github.com/gvieiragoulart/draft-visualizer/internal/clients/cargo/model/picks_and_bans"
+)
+
+type PicksAndBansResponse struct {
+	CargoQuery []PicksAndBansItem `json:"cargoquery"`
+}
+
+type PicksAndBansItem struct {
+	Title PicksAndBansTitle `json:"title"`
+}
+
+// Cargo replaces underscores with spaces in the returned field names
+type PicksAndBansTitle struct {
+	Team1                 string `json:"Team1"`
+	Team2                 string `json:"Team2"`
+	Winner                string `json:"Winner"`
+	Team1Score            string `json:"Team1Score"`
+	Team2Score            string `json:"Team2Score"`
+	Team1Role1            string `json:"Team1Role1"`
+	Team1Role2            string `json:"Team1Role2"`
+	Team1Role3            string `json:"Team1Role3"`
+	Team1Role4            string `json:"Team1Role4"`
+	Team1Role5            string `json:"Team1Role5"`
+	Team2Role1            string `json:"Team2Role1"`
+	Team2Role2            string `json:"Team2Role2"`
+	Team2Role3            string `json:"Team2Role3"`
+	Team2Role4            string `json:"Team2Role4"`
+	Team2Role5            string `json:"Team2Role5"`
+	Team1Ban1             string `json:"Team1Ban1"`
+	Team1Ban2             string `json:"Team1Ban2"`
+	Team1Ban3             string `json:"Team1Ban3"`
+	Team1Ban4             string `json:"Team1Ban4"`
+	Team1Ban5             string `json:"Team1Ban5"`
+	Team2Ban1             string `json:"Team2Ban1"`
+	Team2Ban2             string `json:"Team2Ban2"`
+	Team2Ban3             string `json:"Team2Ban3"`
+	Team2Ban4             string `json:"Team2Ban4"`
+	Team2Ban5             string `json:"Team2Ban5"`
+	Team1Pick1            string `json:"Team1Pick1"`
+	Team1Pick2            string `json:"Team1Pick2"`
+	Team1Pick3            string `json:"Team1Pick3"`
+	Team1Pick4            string `json:"Team1Pick4"`
+	Team1Pick5            string `json:"Team1Pick5"`
+	Team2Pick1            string `json:"Team2Pick1"`
+	Team2Pick2            string `json:"Team2Pick2"`
+	Team2Pick3            string `json:"Team2Pick3"`
+	Team2Pick4            string `json:"Team2Pick4"`
+	Team2Pick5            string `json:"Team2Pick5"`
+	Team1PicksByRoleOrder string `json:"Team1PicksByRoleOrder"`
+	Team2PicksByRoleOrder string `json:"Team2PicksByRoleOrder"`
+	OverviewPage          string `json:"OverviewPage"`
+	Phase                 string `json:"Phase"`
+	UniqueLine            string `json:"UniqueLine"`
+	IsComplete            string `json:"IsComplete"`
+	IsFilled              string `json:"IsFilled"`
+	IsNullified           string `json:"IsNullified"`
+	Tab                   string `json:"Tab"`
+	NPage                 string `json:"N Page"`
+	NTabInPage            string `json:"N TabInPage"`
+	NMatchInPage          string `json:"N MatchInPage"`
+	NGameInMatch          string `json:"N GameInMatch"`
+	GameId                string `json:"GameId"`
+	MatchId               string `json:"MatchId"`
+	GameIDWiki            string `json:"GameID Wiki"`
+}
+
+// GetPicksAndBans returns every draft Leaguepedia has recorded for a tournament
+// overview page (e.g. "LCK/2024 Season/Summer Season"), following cargo's
+// offset pagination. It returns ErrTooManyRows rather than a partial list
+// when the page cap is reached.
+func (c *Client) GetPicksAndBans(tournament string) ([]picks_and_bans.PicksAndBans, error) {
+	where := fmt.Sprintf("OverviewPage=\"%s\"", cargoEscape(tournament))
+
+	picksAndBans := []picks_and_bans.PicksAndBans{}
+	for page := 0; page < cargoMaxPages; page++ {
+		query := cargo_query.NewCargoQuery(
+			[]string{"PicksAndBansS7"},
+			picks_and_bans.GetFields(),
+			url.QueryEscape(where),
+			"",
+			"",
+			"",
+			url.QueryEscape("N_Page,N_TabInPage,N_MatchInPage,N_GameInMatch"),
+			page*cargoPageSize,
+			cargoPageSize,
+		)
+
+		var response PicksAndBansResponse
+		if err := c.queryInto(query, &response); err != nil {
+			return nil, fmt.Errorf("error querying picks and bans: %w", err)
+		}
+
+		for _, item := range response.CargoQuery {
+			picksAndBans = append(picksAndBans, c.parsePicksAndBans(item.Title))
+		}
+
+		if len(response.CargoQuery) < cargoPageSize {
+			return picksAndBans, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: more than %d picks and bans for %s", ErrTooManyRows, cargoMaxPages*cargoPageSize, tournament)
+}
+
+func (c *Client) parsePicksAndBans(title PicksAndBansTitle) picks_and_bans.PicksAndBans {
+	return picks_and_bans.PicksAndBans{
+		Team1:      title.Team1,
+		Team2:      title.Team2,
+		Winner:     parseStringInt(title.Winner),
+		Team1Score: parseStringInt(title.Team1Score),
+		Team2Score: parseStringInt(title.Team2Score),
+		Team1Side:  picks_and_bans.SideBlue,
+		Team2Side:  picks_and_bans.SideRed,
+		Team1Bans: nonEmpty(
+			title.Team1Ban1, title.Team1Ban2, title.Team1Ban3, title.Team1Ban4, title.Team1Ban5,
+		),
+		Team2Bans: nonEmpty(
+			title.Team2Ban1, title.Team2Ban2, title.Team2Ban3, title.Team2Ban4, title.Team2Ban5,
+		),
+		Team1Picks: nonEmpty(
+			title.Team1Pick1, title.Team1Pick2, title.Team1Pick3, title.Team1Pick4, title.Team1Pick5,
+		),
+		Team2Picks: nonEmpty(
+			title.Team2Pick1, title.Team2Pick2, title.Team2Pick3, title.Team2Pick4, title.Team2Pick5,
+		),
+		Team1Roles: pickRoles(
+			[5]string{title.Team1Pick1, title.Team1Pick2, title.Team1Pick3, title.Team1Pick4, title.Team1Pick5},
+			[5]string{title.Team1Role1, title.Team1Role2, title.Team1Role3, title.Team1Role4, title.Team1Role5},
+		),
+		Team2Roles: pickRoles(
+			[5]string{title.Team2Pick1, title.Team2Pick2, title.Team2Pick3, title.Team2Pick4, title.Team2Pick5},
+			[5]string{title.Team2Role1, title.Team2Role2, title.Team2Role3, title.Team2Role4, title.Team2Role5},
+		),
+		Team1PicksByRoleOrder: parseStringSliceString(title.Team1PicksByRoleOrder),
+		Team2PicksByRoleOrder: parseStringSliceString(title.Team2PicksByRoleOrder),
+		OverviewPage:          title.OverviewPage,
+		Phase:                 title.Phase,
+		Tab:                   title.Tab,
+		UniqueLine:            title.UniqueLine,
+		N_Page:                parseStringInt(title.NPage),
+		N_TabInPage:           parseStringInt(title.NTabInPage),
+		N_MatchInPage:         parseStringInt(title.NMatchInPage),
+		N_GameInMatch:         parseStringInt(title.NGameInMatch),
+		IsComplete:            c.parseBoolString(title.IsComplete),
+		IsFilled:              c.parseBoolString(title.IsFilled),
+		IsNullified:           c.parseBoolString(title.IsNullified),
+		GameId:                title.GameId,
+		MatchId:               title.MatchId,
+		GameID_Wiki:           title.GameIDWiki,
+	}
+}
+
+// nonEmpty keeps the order of the given values, dropping unfilled slots
+func nonEmpty(values ...string) []string {
+	result := []string{}
+	for _, v := range values {
+		if v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// pickRoles keeps the role of every filled pick slot, empty when Leaguepedia
+// has none, so that the roles line up with the picks nonEmpty keeps
+func pickRoles(picks, roles [5]string) []string {
+	result := []string{}
+	for i, pick := range picks {
+		if pick != "" {
+			result = append(result, roles[i])
+		}
+	}
+	return result
+}
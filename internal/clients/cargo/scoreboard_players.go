@@ -0,0 +1,70 @@
+package cargo
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/clients/cargo/model/cargo_query"
+	"github.com/gvieiragoulart/draft-visualizer/internal/clients/cargo/model/scoreboard_players"
+)
+
+type ScoreboardPlayersResponse struct {
+	CargoQuery []ScoreboardPlayersItem `json:"cargoquery"`
+}
+
+type ScoreboardPlayersItem struct {
+	Title ScoreboardPlayersTitle `json:"title"`
+}
+
+// Cargo replaces underscores with spaces in the returned field names
+type ScoreboardPlayersTitle struct {
+	OverviewPage string `json:"OverviewPage"`
+	Link         string `json:"Link"`
+	Team         string `json:"Team"`
+	Champion     string `json:"Champion"`
+	Role         string `json:"Role"`
+	IngameRole   string `json:"IngameRole"`
+	Side         string `json:"Side"`
+	RoleNumber   string `json:"Role Number"`
+	GameId       string `json:"GameId"`
+	MatchId      string `json:"MatchId"`
+}
+
+// GetScoreboardPlayers returns the ten scoreboard lines of a game. Games
+// Leaguepedia has no scoreboard for return no players.
+func (c *Client) GetScoreboardPlayers(gameID string) ([]scoreboard_players.ScoreboardPlayer, error) {
+	query := cargo_query.NewCargoQuery(
+		[]string{"ScoreboardPlayers"},
+		scoreboard_players.GetFields(),
+		url.QueryEscape(fmt.Sprintf("GameId=\"%s\"", cargoEscape(gameID))),
+		"",
+		"",
+		"",
+		url.QueryEscape("Side,Role_Number"),
+		0,
+		10,
+	)
+
+	var response ScoreboardPlayersResponse
+	if err := c.queryInto(query, &response); err != nil {
+		return nil, fmt.Errorf("error querying scoreboard players: %w", err)
+	}
+
+	players := make([]scoreboard_players.ScoreboardPlayer, 0, len(response.CargoQuery))
+	for _, item := range response.CargoQuery {
+		title := item.Title
+		players = append(players, scoreboard_players.ScoreboardPlayer{
+			OverviewPage: title.OverviewPage,
+			Link:         title.Link,
+			Team:         title.Team,
+			Champion:     title.Champion,
+			Role:         title.Role,
+			IngameRole:   title.IngameRole,
+			Side:         parseStringInt(title.Side),
+			RoleNumber:   parseStringInt(title.RoleNumber),
+			GameId:       title.GameId,
+			MatchId:      title.MatchId,
+		})
+	}
+	return players, nil
+}
@@ -1,6 +1,38 @@
 package cargo
 
-import "time"
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cargoPageSize is the most rows cargo returns per request
+const cargoPageSize = 500
+
+// cargoMaxPages caps a single paginated query at 10000 rows
+const cargoMaxPages = 20
+
+// ErrTooManyRows is returned when a query matches more rows than
+// cargoMaxPages lets it page through, rather than silently dropping the rest
+var ErrTooManyRows = errors.New("cargo query matched too many rows")
+
+// cargoEscape keeps user input from closing a quoted cargo string.
+// Backslashes are escaped first so one in the input cannot cancel the escape
+// added before a quote.
+func cargoEscape(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	return strings.ReplaceAll(value, `"`, `\"`)
+}
+
+// cargoField reads a field from a generic cargo title. Cargo returns
+// underscores in field names as spaces, so both spellings are tried.
+func cargoField(title map[string]interface{}, name string) interface{} {
+	if v, ok := title[name]; ok {
+		return v
+	}
+	return title[strings.ReplaceAll(name, "_", " ")]
+}
 
 func parseString(v interface{}) string {
 	if v == nil {
@@ -45,6 +77,11 @@ func parseTime(v interface{}) *time.Time {
 		if err == nil {
 			return &t
 		}
+		// Cargo datetime fields use "2006-01-02 15:04:05"
+		t, err = time.Parse("2006-01-02 15:04:05", str)
+		if err == nil {
+			return &t
+		}
 	}
 	t := time.Time{}
 	return &t
@@ -63,6 +100,9 @@ func parseStringSlice(v interface{}) []string {
 		}
 		return result
 	}
+	if str, ok := v.(string); ok {
+		return parseStringSliceString(str)
+	}
 	return []string{}
 }
 
@@ -73,5 +113,15 @@ func parseInt(v interface{}) *int {
 	if num, ok := (v).(int); ok {
 		return &num
 	}
+	if num, ok := (v).(float64); ok {
+		n := int(num)
+		return &n
+	}
+	if str, ok := (v).(string); ok {
+		num, err := strconv.Atoi(str)
+		if err == nil {
+			return &num
+		}
+	}
 	return nil
 }
@@ -2,9 +2,12 @@ package esports
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
+	"net/url"
 
 	"github.com/gvieiragoulart/draft-visualizer/internal/clients"
 	"github.com/gvieiragoulart/draft-visualizer/internal/clients/esports/dto"
@@ -12,8 +15,16 @@ import (
 
 type EsportsClient struct {
 	clients.Client
+	FeedURL string
 }
 
+// ErrNoLiveData is returned while the livestats feed has nothing for a game,
+// which is the case until the game has loaded
+var ErrNoLiveData = errors.New("no live data for game yet")
+
+// ErrUnknownGame is returned when the livestats feed does not know a game
+var ErrUnknownGame = errors.New("unknown live game")
+
 func NewClient(apiKey string) *EsportsClient {
 	return &EsportsClient{
 		Client: clients.Client{
@@ -21,6 +32,7 @@ func NewClient(apiKey string) *EsportsClient {
 			HttpClient: &http.Client{},
 			BaseURL:    "https://esports-api.lolesports.com/persisted/gw",
 		},
+		FeedURL: "https://feed.lolesports.com/livestats/v1",
 	}
 }
 
@@ -72,3 +84,36 @@ func (e *EsportsClient) GetTeams() (dto.TeamsDTO, error) {
 	}
 	return teamsResponse, nil
 }
+
+// GetLiveWindow returns the latest livestats window of a game. Unlike the
+// schedule calls it reports failures to the caller, since it is polled for
+// the whole length of a game.
+func (e *EsportsClient) GetLiveWindow(gameID string) (dto.WindowDTO, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/window/%s", e.FeedURL, url.PathEscape(gameID)), nil)
+	if err != nil {
+		return dto.WindowDTO{}, fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := e.HttpClient.Do(req)
+	if err != nil {
+		return dto.WindowDTO{}, fmt.Errorf("error making request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNoContent:
+		return dto.WindowDTO{}, ErrNoLiveData
+	case http.StatusNotFound, http.StatusBadRequest:
+		return dto.WindowDTO{}, fmt.Errorf("%w: %s", ErrUnknownGame, gameID)
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return dto.WindowDTO{}, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+	}
+
+	var window dto.WindowDTO
+	if err := json.NewDecoder(resp.Body).Decode(&window); err != nil {
+		return dto.WindowDTO{}, fmt.Errorf("error decoding response: %w", err)
+	}
+	return window, nil
+}
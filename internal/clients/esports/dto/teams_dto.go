@@ -31,6 +31,8 @@
 
 package dto
 
+import "strings"
+
 type TeamsDTO struct {
 	Data struct {
 		Teams []struct {
@@ -93,3 +95,14 @@ func (t *TeamsDTO) ToTeams() []Teams {
 	}
 	return teams
 }
+
+// FindTeam looks a team up by its slug, code or name, ignoring case
+func (t *TeamsDTO) FindTeam(slugOrCode string) (Teams, bool) {
+	for _, team := range t.ToTeams() {
+		if strings.EqualFold(team.Slug, slugOrCode) || strings.EqualFold(team.Code, slugOrCode) ||
+			strings.EqualFold(team.Name, slugOrCode) {
+			return team, true
+		}
+	}
+	return Teams{}, false
+}
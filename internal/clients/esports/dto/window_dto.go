@@ -0,0 +1,90 @@
+/*
+{
+  "esportsGameId": "string",
+  "esportsMatchId": "string",
+  "gameMetadata": {
+    "patchVersion": "string",
+    "blueTeamMetadata": {
+      "esportsTeamId": "string",
+      "participantMetadata": [
+        {
+          "participantId": 1,
+          "esportsPlayerId": "string",
+          "summonerName": "string",
+          "championId": "string",
+          "role": "top"
+        }
+      ]
+    },
+    "redTeamMetadata": {}
+  },
+  "frames": [
+    {
+      "rfc460Timestamp": "2025-10-05T16:27:59Z",
+      "gameState": "in_game",
+      "blueTeam": {
+        "totalGold": 0,
+        "inhibitors": 0,
+        "towers": 0,
+        "barons": 0,
+        "totalKills": 0,
+        "dragons": ["string"]
+      },
+      "redTeam": {}
+    }
+  ]
+}
+*/
+
+package dto
+
+type WindowDTO struct {
+	EsportsGameID  string       `json:"esportsGameId"`
+	EsportsMatchID string       `json:"esportsMatchId"`
+	GameMetadata   GameMetadata `json:"gameMetadata"`
+	Frames         []Frame      `json:"frames"`
+}
+
+type GameMetadata struct {
+	PatchVersion     string       `json:"patchVersion"`
+	BlueTeamMetadata TeamMetadata `json:"blueTeamMetadata"`
+	RedTeamMetadata  TeamMetadata `json:"redTeamMetadata"`
+}
+
+type TeamMetadata struct {
+	EsportsTeamID       string                `json:"esportsTeamId"`
+	ParticipantMetadata []ParticipantMetadata `json:"participantMetadata"`
+}
+
+type ParticipantMetadata struct {
+	ParticipantID   int    `json:"participantId"`
+	EsportsPlayerID string `json:"esportsPlayerId"`
+	SummonerName    string `json:"summonerName"`
+	ChampionID      string `json:"championId"`
+	Role            string `json:"role"`
+}
+
+type Frame struct {
+	Timestamp string    `json:"rfc460Timestamp"`
+	GameState string    `json:"gameState"`
+	BlueTeam  TeamFrame `json:"blueTeam"`
+	RedTeam   TeamFrame `json:"redTeam"`
+}
+
+type TeamFrame struct {
+	TotalGold  int      `json:"totalGold"`
+	Inhibitors int      `json:"inhibitors"`
+	Towers     int      `json:"towers"`
+	Barons     int      `json:"barons"`
+	TotalKills int      `json:"totalKills"`
+	Dragons    []string `json:"dragons"`
+}
+
+// GameState returns the state of the latest frame, or an empty string when
+// the game has not started
+func (w *WindowDTO) GameState() string {
+	if len(w.Frames) == 0 {
+		return ""
+	}
+	return w.Frames[len(w.Frames)-1].GameState
+}
@@ -1,10 +1,13 @@
 package config
 
 import (
+	"bufio"
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
+	"github.com/gvieiragoulart/draft-visualizer/internal/riot"
 	"github.com/joho/godotenv"
 )
 
@@ -18,6 +21,20 @@ type Config struct {
 	ServerPort    int
 	WikiUsername  string
 	WikiPassword  string
+
+	// RiotRegion is the routing region of Riot API requests that do not
+	// name one, such as match histories; it defaults to americas
+	RiotRegion riot.Region
+
+	// ChampionsDataDir points at a Data Dragon data/<locale> directory; the
+	// bundled champion snapshot is used when it is empty. Release patches
+	// are not part of Data Dragon and only cover the bundled snapshot, so
+	// newer champions are not checked against a match's patch.
+	ChampionsDataDir string
+
+	// WinProbModelPath is a model file written by cmd/train; win probability
+	// predictions are disabled when it is empty
+	WinProbModelPath string
 }
 
 // Load loads configuration from environment variables
@@ -30,6 +47,15 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("RIOT_API_KEY environment variable is required")
 	}
 
+	riotRegion := riot.Americas
+	if name := os.Getenv("RIOT_REGION"); name != "" {
+		region, err := riot.ParseRegion(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RIOT_REGION: %w", err)
+		}
+		riotRegion = region
+	}
+
 	esportsAPIKey := os.Getenv("ESPORTS_API_KEY")
 	if esportsAPIKey == "" {
 		return nil, fmt.Errorf("ESPORTS_API_KEY environment variable is required")
@@ -63,6 +89,9 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("WIKI_USERNAME and WIKI_PASSWORD environment variables are required")
 	}
 
+	championsDataDir := os.Getenv("CHAMPIONS_DATA_DIR")
+	winProbModelPath := os.Getenv("WINPROB_MODEL_PATH")
+
 	return &Config{
 		RiotAPIKey:    riotAPIKey,
 		EsportsAPIKey: esportsAPIKey,
@@ -72,5 +101,30 @@ func Load() (*Config, error) {
 		ServerPort:    serverPort,
 		WikiUsername:  wikiUsername,
 		WikiPassword:  wikiPassword,
+
+		RiotRegion:       riotRegion,
+		ChampionsDataDir: championsDataDir,
+		WinProbModelPath: winProbModelPath,
 	}, nil
 }
+
+// ReadPlayers reads a player list for the commands, one player per line,
+// skipping blank lines and lines starting with #
+func ReadPlayers(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var players []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		players = append(players, line)
+	}
+	return players, scanner.Err()
+}
@@ -2,7 +2,11 @@ package config
 
 import (
 	"os"
+	"path/filepath"
+	"slices"
 	"testing"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/riot"
 )
 
 func TestLoad_Success(t *testing.T) {
@@ -181,3 +185,60 @@ func TestLoad_InvalidServerPort(t *testing.T) {
 		t.Fatal("expected error for invalid SERVER_PORT, got nil")
 	}
 }
+
+func TestLoad_RiotRegion(t *testing.T) {
+	os.Setenv("RIOT_API_KEY", "test-api-key")
+	os.Setenv("ESPORTS_API_KEY", "test-esports-api-key")
+	os.Setenv("DATABASE_URL", "postgres://localhost:5432/test")
+	os.Setenv("WIKI_USERNAME", "test-user")
+	os.Setenv("WIKI_PASSWORD", "test-password")
+	defer func() {
+		os.Unsetenv("RIOT_API_KEY")
+		os.Unsetenv("ESPORTS_API_KEY")
+		os.Unsetenv("DATABASE_URL")
+		os.Unsetenv("WIKI_USERNAME")
+		os.Unsetenv("WIKI_PASSWORD")
+		os.Unsetenv("RIOT_REGION")
+	}()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cfg.RiotRegion != riot.Americas {
+		t.Errorf("expected the region to default to americas, got %s", cfg.RiotRegion)
+	}
+
+	os.Setenv("RIOT_REGION", "kr")
+	if cfg, err = Load(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cfg.RiotRegion != riot.Asia {
+		t.Errorf("expected kr to resolve to asia, got %s", cfg.RiotRegion)
+	}
+
+	os.Setenv("RIOT_REGION", "mars")
+	if _, err := Load(); err == nil {
+		t.Error("expected an error for an unknown region")
+	}
+}
+
+func TestReadPlayers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "players.txt")
+	content := "# pros\nHide on bush#KR1\n\n  puuid-1  \n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write players: %v", err)
+	}
+
+	players, err := ReadPlayers(path)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !slices.Equal(players, []string{"Hide on bush#KR1", "puuid-1"}) {
+		t.Errorf("expected comments and blank lines to be skipped, got %v", players)
+	}
+
+	if _, err := ReadPlayers(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
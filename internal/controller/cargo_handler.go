@@ -2,13 +2,19 @@ package controller
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
+	"github.com/gvieiragoulart/draft-visualizer/internal/clients/cargo"
+	"github.com/gvieiragoulart/draft-visualizer/internal/draft"
 	"github.com/gvieiragoulart/draft-visualizer/internal/service"
 )
 
 type CargoHandler interface {
 	GetNewsLatest(w http.ResponseWriter, r *http.Request)
+	GetPicksAndBans(w http.ResponseWriter, r *http.Request)
+	ExportPicksAndBans(w http.ResponseWriter, r *http.Request)
+	ValidateSeries(w http.ResponseWriter, r *http.Request)
 }
 
 type CargoHandlerImpl struct {
@@ -33,3 +39,97 @@ func (h *CargoHandlerImpl) GetNewsLatest(w http.ResponseWriter, r *http.Request)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(newsItems)
 }
+
+func (h *CargoHandlerImpl) GetPicksAndBans(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	tournament := r.URL.Query().Get("tournament")
+	if tournament == "" {
+		http.Error(w, "tournament parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	picksAndBans, err := h.service.GetPicksAndBans(tournament)
+	if err != nil {
+		http.Error(w, err.Error(), cargoErrorStatus(err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(picksAndBans)
+}
+
+// ExportPicksAndBans returns a tournament's drafts in draft notation, or in
+// JSON or CSV with ?format=
+func (h *CargoHandlerImpl) ExportPicksAndBans(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	tournament := r.URL.Query().Get("tournament")
+	if tournament == "" {
+		http.Error(w, "tournament parameter is required", http.StatusBadRequest)
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = service.DraftNotation
+	}
+
+	records, err := h.service.ExportDrafts(tournament)
+	if err != nil {
+		http.Error(w, err.Error(), cargoErrorStatus(err))
+		return
+	}
+
+	data, err := service.EncodeDrafts(records, format)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrInvalidConversion) {
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	w.Header().Set("Content-Type", service.ContentType(format))
+	w.Write(data)
+}
+
+func (h *CargoHandlerImpl) ValidateSeries(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	tournament := r.URL.Query().Get("tournament")
+	if tournament == "" {
+		http.Error(w, "tournament parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	ruleset, err := draft.ParseRuleset(r.URL.Query().Get("ruleset"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	reports, err := h.service.ValidateSeries(tournament, ruleset)
+	if err != nil {
+		http.Error(w, err.Error(), cargoErrorStatus(err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(reports)
+}
+
+// cargoErrorStatus maps a Leaguepedia query error to a status, asking for a
+// narrower query when cargo matched more rows than can be paged through
+func cargoErrorStatus(err error) int {
+	if errors.Is(err, cargo.ErrTooManyRows) {
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusInternalServerError
+}
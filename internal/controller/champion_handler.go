@@ -0,0 +1,45 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/champions"
+)
+
+type ChampionHandler struct {
+	catalogue *champions.Catalogue
+}
+
+func NewChampionHandler(catalogue *champions.Catalogue) *ChampionHandler {
+	return &ChampionHandler{
+		catalogue: catalogue,
+	}
+}
+
+// ChampionsHandler lists the champion catalogue, optionally filtered by a
+// name query (?q=) and a class tag (?tag=). ?name= resolves a single
+// spelling instead.
+func (ch *ChampionHandler) ChampionsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if name := r.URL.Query().Get("name"); name != "" {
+		champion, ok := ch.catalogue.Resolve(name)
+		if !ok {
+			http.Error(w, "Champion not found", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(champion)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"version":   ch.catalogue.Version(),
+		"champions": ch.catalogue.Search(r.URL.Query().Get("q"), r.URL.Query().Get("tag")),
+	})
+}
@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/service"
+)
+
+// maxConvertBody caps the size of a posted draft export
+const maxConvertBody = 4 << 20
+
+type ConvertHandler struct {
+	service *service.ConvertService
+}
+
+func NewConvertHandler(service *service.ConvertService) *ConvertHandler {
+	return &ConvertHandler{
+		service: service,
+	}
+}
+
+// ConvertHandler converts the drafts posted in the body between draft
+// notation, JSON and CSV, given by ?from= and ?to=
+func (ch *ConvertHandler) ConvertHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	from, to := r.URL.Query().Get("from"), r.URL.Query().Get("to")
+	if from == "" || to == "" {
+		http.Error(w, "from and to parameters are required", http.StatusBadRequest)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxConvertBody))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	data, err := ch.service.Convert(body, from, to)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrInvalidConversion) {
+			status = http.StatusUnprocessableEntity
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", service.ContentType(to))
+	w.Write(data)
+}
@@ -0,0 +1,52 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/service"
+)
+
+type DiffHandler struct {
+	service *service.DiffService
+}
+
+func NewDiffHandler(service *service.DiffService) *DiffHandler {
+	return &DiffHandler{
+		service: service,
+	}
+}
+
+// DiffHandler compares the two drafts named in the body
+func (dh *DiffHandler) DiffHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req service.DiffRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	diff, err := dh.service.Compare(r.Context(), req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, service.ErrSessionNotFound), errors.Is(err, service.ErrDraftGameNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, service.ErrInvalidDiff):
+			status = http.StatusUnprocessableEntity
+		case errors.Is(err, service.ErrDatabaseUnavailable):
+			status = http.StatusServiceUnavailable
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(diff)
+}
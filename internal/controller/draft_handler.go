@@ -0,0 +1,266 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/draft"
+	"github.com/gvieiragoulart/draft-visualizer/internal/service"
+)
+
+type DraftHandler struct {
+	service *service.DraftSessionService
+}
+
+func NewDraftHandler(service *service.DraftSessionService) *DraftHandler {
+	return &DraftHandler{
+		service: service,
+	}
+}
+
+// DraftsHandler creates a session on POST and returns its board on GET
+func (dh *DraftHandler) DraftsHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		dh.createSession(w, r)
+	case http.MethodGet:
+		dh.getSession(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (dh *DraftHandler) createSession(w http.ResponseWriter, r *http.Request) {
+	var req service.CreateDraftSessionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	session, err := dh.service.CreateSession(r.Context(), req)
+	if err != nil {
+		writeDraftError(w, "Error creating draft session", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(session)
+}
+
+func (dh *DraftHandler) getSession(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		http.Error(w, "id parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	session, err := dh.service.GetSession(r.Context(), id)
+	if err != nil {
+		writeDraftError(w, "Error getting draft session", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(session)
+}
+
+// ActionsHandler submits the next ban or pick of a session
+func (dh *DraftHandler) ActionsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		http.Error(w, "id parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	var action draft.Action
+	if err := json.NewDecoder(r.Body).Decode(&action); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	session, err := dh.service.SubmitAction(r.Context(), id, action)
+	if err != nil {
+		writeDraftError(w, "Error submitting draft action", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(session)
+}
+
+// SwapsHandler trades two champions between teammates
+func (dh *DraftHandler) SwapsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		http.Error(w, "id parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	var req service.SwapRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	session, err := dh.service.SubmitSwap(r.Context(), id, req)
+	if err != nil {
+		writeDraftError(w, "Error submitting swap", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(session)
+}
+
+// HoversHandler shows the champion the side to play is considering
+func (dh *DraftHandler) HoversHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		http.Error(w, "id parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	var req service.HoverRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	session, err := dh.service.SubmitHover(r.Context(), id, req)
+	if err != nil {
+		writeDraftError(w, "Error submitting hover", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(session)
+}
+
+// TimelineHandler returns the ordered, timed replay of a session's game.
+// ?game= picks a game of the series and defaults to the current one.
+func (dh *DraftHandler) TimelineHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		http.Error(w, "id parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	game := 0
+	if value := r.URL.Query().Get("game"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			http.Error(w, "game must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		game = n
+	}
+
+	timeline, err := dh.service.GetTimeline(r.Context(), id, game)
+	if err != nil {
+		writeDraftError(w, "Error getting draft timeline", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(timeline)
+}
+
+// GamesHandler finishes the current game of a series and opens the next one
+func (dh *DraftHandler) GamesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		http.Error(w, "id parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	var req service.NextGameRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	session, err := dh.service.NextGame(r.Context(), id, req)
+	if err != nil {
+		writeDraftError(w, "Error starting next game", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(session)
+}
+
+// LockedHandler lists the champions each team can no longer pick in the
+// series
+func (dh *DraftHandler) LockedHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		http.Error(w, "id parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	locked, err := dh.service.GetLockedChampions(r.Context(), id)
+	if err != nil {
+		writeDraftError(w, "Error getting locked champions", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(locked)
+}
+
+// writeDraftError maps session errors to status codes: unknown sessions are
+// 404s, moves the draft order rejects are 422s and creating a session past
+// the cap is a 503
+func writeDraftError(w http.ResponseWriter, message string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, service.ErrSessionNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, service.ErrIllegalAction):
+		status = http.StatusUnprocessableEntity
+	case errors.Is(err, service.ErrInvalidSession):
+		status = http.StatusBadRequest
+	case errors.Is(err, service.ErrTooManySessions):
+		status = http.StatusServiceUnavailable
+	}
+
+	if status == http.StatusInternalServerError {
+		log.Printf("%s: %v", message, err)
+	}
+	http.Error(w, fmt.Sprintf("%s: %v", message, err), status)
+}
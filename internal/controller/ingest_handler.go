@@ -0,0 +1,81 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/service"
+)
+
+type IngestHandler struct {
+	service *service.IngestService
+}
+
+func NewIngestHandler(service *service.IngestService) *IngestHandler {
+	return &IngestHandler{
+		service: service,
+	}
+}
+
+// IngestHandler starts a match history crawl (POST), reports its progress
+// (GET ?id=) or cancels it (DELETE ?id=)
+func (ih *IngestHandler) IngestHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		ih.startJob(w, r)
+	case http.MethodGet:
+		ih.jobProgress(w, r, ih.service.Progress)
+	case http.MethodDelete:
+		ih.jobProgress(w, r, ih.service.Cancel)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (ih *IngestHandler) startJob(w http.ResponseWriter, r *http.Request) {
+	var req service.IngestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	progress, err := ih.service.Start(req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, service.ErrInvalidIngestRequest):
+			status = http.StatusUnprocessableEntity
+		case errors.Is(err, service.ErrDatabaseUnavailable):
+			status = http.StatusServiceUnavailable
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(progress)
+}
+
+func (ih *IngestHandler) jobProgress(w http.ResponseWriter, r *http.Request, lookup func(string) (service.IngestProgress, error)) {
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		http.Error(w, "id parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	progress, err := lookup(id)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrIngestJobNotFound) {
+			status = http.StatusNotFound
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(progress)
+}
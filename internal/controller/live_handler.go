@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/service"
+)
+
+type LiveHandler struct {
+	service *service.LiveDraftService
+}
+
+func NewLiveHandler(service *service.LiveDraftService) *LiveHandler {
+	return &LiveHandler{
+		service: service,
+	}
+}
+
+// LiveHandler starts tracking a pro game on POST and returns what has been
+// seen of it on GET
+func (lh *LiveHandler) LiveHandler(w http.ResponseWriter, r *http.Request) {
+	gameID := r.URL.Query().Get("gameId")
+	if gameID == "" {
+		http.Error(w, "gameId parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+		game, err := lh.service.Track(gameID)
+		if err != nil {
+			status := http.StatusInternalServerError
+			switch {
+			case errors.Is(err, service.ErrInvalidLiveGame):
+				status = http.StatusUnprocessableEntity
+			case errors.Is(err, service.ErrTooManyLiveGames):
+				status = http.StatusServiceUnavailable
+			}
+			http.Error(w, fmt.Sprintf("Error tracking live game: %v", err), status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(game)
+	case http.MethodGet:
+		game, err := lh.service.GetLiveGame(gameID)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Error getting live game: %v", err), http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(game)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
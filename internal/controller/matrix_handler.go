@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/analytics"
+	"github.com/gvieiragoulart/draft-visualizer/internal/database"
+	"github.com/gvieiragoulart/draft-visualizer/internal/service"
+)
+
+type MatrixHandler struct {
+	service *service.MatrixService
+}
+
+func NewMatrixHandler(service *service.MatrixService) *MatrixHandler {
+	return &MatrixHandler{
+		service: service,
+	}
+}
+
+// SyncHandler imports the Leaguepedia drafts matching the /stats/champions
+// filters into Postgres
+func (mh *MatrixHandler) SyncHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	filter, err := parseDraftGamesFilter(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	saved, err := mh.service.SyncGames(filter)
+	if err != nil {
+		status := cargoErrorStatus(err)
+		switch {
+		case errors.Is(err, service.ErrInvalidStatsQuery):
+			status = http.StatusBadRequest
+		case errors.Is(err, service.ErrDatabaseUnavailable):
+			status = http.StatusServiceUnavailable
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]int{
+		"saved": saved,
+	})
+}
+
+// SynergyHandler returns the champions that win more or less often when
+// picked alongside ?champion=
+func (mh *MatrixHandler) SynergyHandler(w http.ResponseWriter, r *http.Request) {
+	mh.serveMatrix(w, r, mh.service.GetSynergies)
+}
+
+// CountersHandler returns how ?champion= fares against each champion played
+// in the same role, optionally limited to ?role=
+func (mh *MatrixHandler) CountersHandler(w http.ResponseWriter, r *http.Request) {
+	mh.serveMatrix(w, r, mh.service.GetCounters)
+}
+
+func (mh *MatrixHandler) serveMatrix(w http.ResponseWriter, r *http.Request, get func(service.MatrixQuery) ([]analytics.PairStats, error)) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query, err := parseMatrixQuery(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	pairs, err := get(query)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, service.ErrInvalidMatrixQuery):
+			status = http.StatusBadRequest
+		case errors.Is(err, service.ErrDatabaseUnavailable):
+			status = http.StatusServiceUnavailable
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(pairs)
+}
+
+func parseMatrixQuery(values url.Values) (service.MatrixQuery, error) {
+	query := service.MatrixQuery{
+		Champion: values.Get("champion"),
+		Role:     values.Get("role"),
+		Window: analytics.PatchWindow{
+			From: values.Get("patchFrom"),
+			To:   values.Get("patchTo"),
+		},
+		Filter: database.DraftGameFilter{
+			Tournament: values.Get("tournament"),
+			League:     values.Get("league"),
+		},
+		MinGames: analytics.DefaultMinGames,
+	}
+	if query.Champion == "" {
+		return query, fmt.Errorf("champion parameter is required")
+	}
+
+	if minGames := values.Get("minGames"); minGames != "" {
+		n, err := strconv.Atoi(minGames)
+		if err != nil || n < 1 {
+			return query, fmt.Errorf("minGames must be a positive integer")
+		}
+		query.MinGames = n
+	}
+
+	filter, err := parseDraftGamesFilter(values)
+	if err != nil {
+		return query, err
+	}
+	query.Filter.From = filter.From
+	query.Filter.To = filter.To
+
+	return query, nil
+}
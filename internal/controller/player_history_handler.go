@@ -0,0 +1,131 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/champions"
+	"github.com/gvieiragoulart/draft-visualizer/internal/service"
+)
+
+type PlayerHistoryHandler struct {
+	service *service.PlayerHistoryService
+}
+
+func NewPlayerHistoryHandler(service *service.PlayerHistoryService) *PlayerHistoryHandler {
+	return &PlayerHistoryHandler{
+		service: service,
+	}
+}
+
+// SnapshotHandler records the current ranked standing and champion mastery
+// of the players in the body, for a scheduler to call periodically
+func (ph *PlayerHistoryHandler) SnapshotHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req service.SnapshotRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	report, err := ph.service.Snapshot(r.Context(), req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, service.ErrInvalidSnapshotRequest):
+			status = http.StatusUnprocessableEntity
+		case errors.Is(err, service.ErrDatabaseUnavailable):
+			status = http.StatusServiceUnavailable
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+// LeagueHistoryHandler returns a player's ranked standing per queue over
+// time. Snapshots are filtered by queue (solo, flex or a league-v4 queue
+// type), from and to (YYYY-MM-DD or RFC 3339).
+func (ph *PlayerHistoryHandler) LeagueHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	query, ok := parseHistoryQuery(w, r)
+	if !ok {
+		return
+	}
+
+	history, err := ph.service.GetLeagueHistory(query)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrDatabaseUnavailable) {
+			status = http.StatusServiceUnavailable
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(history)
+}
+
+// MasteryHistoryHandler returns a player's mastery per champion over time.
+// Snapshots are filtered by champion, from and to (YYYY-MM-DD or RFC 3339).
+func (ph *PlayerHistoryHandler) MasteryHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	query, ok := parseHistoryQuery(w, r)
+	if !ok {
+		return
+	}
+
+	history, err := ph.service.GetMasteryHistory(query)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, champions.ErrUnknownChampion):
+			status = http.StatusBadRequest
+		case errors.Is(err, service.ErrDatabaseUnavailable):
+			status = http.StatusServiceUnavailable
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(history)
+}
+
+// parseHistoryQuery checks the method and reads the query shared by both
+// history endpoints, answering the request itself when it is invalid
+func parseHistoryQuery(w http.ResponseWriter, r *http.Request) (service.HistoryQuery, bool) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return service.HistoryQuery{}, false
+	}
+
+	values := r.URL.Query()
+	query := service.HistoryQuery{
+		PUUID:    values.Get("puuid"),
+		Queue:    values.Get("queue"),
+		Champion: values.Get("champion"),
+	}
+	if query.PUUID == "" {
+		http.Error(w, "puuid parameter is required", http.StatusBadRequest)
+		return query, false
+	}
+
+	var err error
+	if query.From, err = parseDateParam(values, "from", false); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return query, false
+	}
+	if query.To, err = parseDateParam(values, "to", true); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return query, false
+	}
+	return query, true
+}
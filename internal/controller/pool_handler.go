@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/service"
+)
+
+type PoolHandler struct {
+	service *service.PoolService
+}
+
+func NewPoolHandler(service *service.PoolService) *PoolHandler {
+	return &PoolHandler{
+		service: service,
+	}
+}
+
+// PoolHandler returns the champions legal in a Leaguepedia match given by
+// ?match=, or on a ?patch= with the comma separated ?disabled= champions
+// removed
+func (ph *PoolHandler) PoolHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	matchID, patch := query.Get("match"), query.Get("patch")
+	if matchID == "" && patch == "" {
+		http.Error(w, "match or patch parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	var pool *service.ChampionPool
+	if matchID != "" {
+		var err error
+		pool, err = ph.service.GetMatchPool(matchID)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, service.ErrMatchNotFound) {
+				status = http.StatusNotFound
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+	} else {
+		var disabled []string
+		if value := query.Get("disabled"); value != "" {
+			disabled = strings.Split(value, ",")
+		}
+		pool = ph.service.GetPatchPool(patch, disabled)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(pool)
+}
@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/service"
+)
+
+type RecommendHandler struct {
+	service *service.RecommendService
+}
+
+func NewRecommendHandler(service *service.RecommendService) *RecommendHandler {
+	return &RecommendHandler{
+		service: service,
+	}
+}
+
+// RecommendationsHandler ranks champions for the next ban or pick of a
+// board posted in the body, or of a draft session given by ?session=
+func (rh *RecommendHandler) RecommendationsHandler(w http.ResponseWriter, r *http.Request) {
+	var req service.RecommendRequest
+	switch r.Method {
+	case http.MethodGet:
+		req.SessionID = r.URL.Query().Get("session")
+		if req.SessionID == "" {
+			http.Error(w, "session parameter is required", http.StatusBadRequest)
+			return
+		}
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+			return
+		}
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	result, err := rh.service.Recommend(r.Context(), req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, service.ErrSessionNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, service.ErrInvalidRecommendation):
+			status = http.StatusUnprocessableEntity
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/service"
+)
+
+type RenderHandler struct {
+	service *service.RenderService
+}
+
+func NewRenderHandler(service *service.RenderService) *RenderHandler {
+	return &RenderHandler{
+		service: service,
+	}
+}
+
+// ImageHandler renders a draft as an SVG or PNG image, for a draft session
+// given by ?session= or a board posted in the body. ?format=, ?title=,
+// ?patch= and ?game= set the image format and labels; a posted body may set
+// them as fields instead.
+func (rh *RenderHandler) ImageHandler(w http.ResponseWriter, r *http.Request) {
+	var req service.RenderRequest
+	switch r.Method {
+	case http.MethodGet:
+		req.SessionID = r.URL.Query().Get("session")
+		if req.SessionID == "" {
+			http.Error(w, "session parameter is required", http.StatusBadRequest)
+			return
+		}
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+			return
+		}
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	if format := query.Get("format"); format != "" {
+		req.Format = format
+	}
+	if title := query.Get("title"); title != "" {
+		req.Title = title
+	}
+	if patch := query.Get("patch"); patch != "" {
+		req.Patch = patch
+	}
+	if game := query.Get("game"); game != "" {
+		number, err := strconv.Atoi(game)
+		if err != nil || number < 1 {
+			http.Error(w, "game must be a positive number", http.StatusBadRequest)
+			return
+		}
+		req.GameNumber = number
+	}
+
+	image, err := rh.service.Render(r.Context(), req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, service.ErrSessionNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, service.ErrInvalidRender):
+			status = http.StatusUnprocessableEntity
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", image.ContentType)
+	w.Write(image.Data)
+}
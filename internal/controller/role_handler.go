@@ -0,0 +1,107 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/analytics"
+	"github.com/gvieiragoulart/draft-visualizer/internal/database"
+	"github.com/gvieiragoulart/draft-visualizer/internal/service"
+)
+
+type RoleHandler struct {
+	service *service.RoleService
+}
+
+func NewRoleHandler(service *service.RoleService) *RoleHandler {
+	return &RoleHandler{
+		service: service,
+	}
+}
+
+// RolesHandler assigns a role to every pick of the draft named in the body
+// and returns each side ordered by position
+func (rh *RoleHandler) RolesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req service.RoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	roles, err := rh.service.AssignRoles(r.Context(), req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, service.ErrSessionNotFound), errors.Is(err, service.ErrDraftGameNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, service.ErrInvalidRoleRequest):
+			status = http.StatusUnprocessableEntity
+		case errors.Is(err, service.ErrDatabaseUnavailable):
+			status = http.StatusServiceUnavailable
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(roles)
+}
+
+// FlexHandler returns the champions played in two or more roles, with how
+// often they were played in each. Games are filtered like the matrices.
+func (rh *RoleHandler) FlexHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	values := r.URL.Query()
+	query := service.FlexQuery{
+		Window: analytics.PatchWindow{
+			From: values.Get("patchFrom"),
+			To:   values.Get("patchTo"),
+		},
+		Filter: database.DraftGameFilter{
+			Tournament: values.Get("tournament"),
+			League:     values.Get("league"),
+		},
+		MinGames: 1,
+	}
+	if minGames := values.Get("minGames"); minGames != "" {
+		n, err := strconv.Atoi(minGames)
+		if err != nil || n < 1 {
+			http.Error(w, "minGames must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		query.MinGames = n
+	}
+
+	filter, err := parseDraftGamesFilter(values)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	query.Filter.From = filter.From
+	query.Filter.To = filter.To
+
+	flex, err := rh.service.GetFlexPicks(query)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrInvalidRoleRequest) {
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(flex)
+}
@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/clients/cargo/model/draft_games"
+	"github.com/gvieiragoulart/draft-visualizer/internal/service"
+)
+
+type StatsHandler struct {
+	service *service.StatsService
+}
+
+func NewStatsHandler(service *service.StatsService) *StatsHandler {
+	return &StatsHandler{
+		service: service,
+	}
+}
+
+// ChampionStatsHandler returns per-champion pick, ban, presence and win
+// rates. Games are filtered by tournament, league, patch, from and to
+// (YYYY-MM-DD or RFC 3339); sort and order control the ordering.
+func (sh *StatsHandler) ChampionStatsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	filter, err := parseDraftGamesFilter(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	order := r.URL.Query().Get("order")
+	if order != "" && order != "asc" && order != "desc" {
+		http.Error(w, "order must be asc or desc", http.StatusBadRequest)
+		return
+	}
+
+	report, err := sh.service.GetChampionStats(service.ChampionStatsQuery{
+		Filter:     filter,
+		Sort:       r.URL.Query().Get("sort"),
+		Descending: order != "asc",
+	})
+	if err != nil {
+		status := cargoErrorStatus(err)
+		if errors.Is(err, service.ErrInvalidStatsQuery) {
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+func parseDraftGamesFilter(query url.Values) (draft_games.Filter, error) {
+	filter := draft_games.Filter{
+		Tournament: query.Get("tournament"),
+		League:     query.Get("league"),
+		Patch:      query.Get("patch"),
+	}
+
+	var err error
+	if filter.From, err = parseDateParam(query, "from", false); err != nil {
+		return filter, err
+	}
+	if filter.To, err = parseDateParam(query, "to", true); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
+// parseDateParam reads a YYYY-MM-DD or RFC 3339 parameter. A bare date is
+// the start of the day, or its last second when endOfDay is set so that an
+// upper bound includes the whole day.
+func parseDateParam(query url.Values, name string, endOfDay bool) (*time.Time, error) {
+	value := query.Get(name)
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		if endOfDay {
+			t = t.Add(24*time.Hour - time.Second)
+		}
+		return &t, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s parameter: expected YYYY-MM-DD or RFC 3339", name)
+	}
+	return &t, nil
+}
@@ -0,0 +1,190 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/gvieiragoulart/draft-visualizer/internal/service"
+	"github.com/gvieiragoulart/draft-visualizer/internal/stream"
+)
+
+const streamKeepAlive = 15 * time.Second
+
+type StreamHandler struct {
+	broker   *stream.Broker
+	sessions *service.DraftSessionService
+	live     *service.LiveDraftService
+	upgrader websocket.Upgrader
+}
+
+func NewStreamHandler(broker *stream.Broker, sessions *service.DraftSessionService, live *service.LiveDraftService) *StreamHandler {
+	return &StreamHandler{
+		broker:   broker,
+		sessions: sessions,
+		live:     live,
+		upgrader: websocket.Upgrader{
+			// Boards are public and meant to be embedded in overlays
+			// served from other origins
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+	}
+}
+
+// SSEHandler streams the events of a draft session (?draft=) or a tracked
+// live game (?game=) as Server-Sent Events
+func (sh *StreamHandler) SSEHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	topic, lastEventID, ok := sh.subscription(w, r)
+	if !ok {
+		return
+	}
+
+	// Streams outlive the server's write timeout
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	sub := sh.broker.Subscribe(topic, lastEventID)
+	defer sub.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	rc.Flush()
+
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case event, ok := <-sub.Events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data); err != nil {
+				return
+			}
+		}
+		rc.Flush()
+	}
+}
+
+// WebSocketHandler streams the same events as SSEHandler over a WebSocket.
+// Browsers cannot set headers on WebSocket requests, so the resume point is
+// read from ?lastEventId= as well.
+func (sh *StreamHandler) WebSocketHandler(w http.ResponseWriter, r *http.Request) {
+	topic, lastEventID, ok := sh.subscription(w, r)
+	if !ok {
+		return
+	}
+
+	conn, err := sh.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade already wrote the error response
+		return
+	}
+	defer conn.Close()
+
+	sub := sh.broker.Subscribe(topic, lastEventID)
+	defer sub.Close()
+
+	// Reading is required to process pings and notice closed connections
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case <-keepAlive.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(5*time.Second)); err != nil {
+				return
+			}
+		case event, ok := <-sub.Events:
+			if !ok {
+				message := websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "subscriber fell behind")
+				if sub.Ended() {
+					message = websocket.FormatCloseMessage(websocket.CloseNormalClosure, "stream ended")
+				}
+				conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(5*time.Second))
+				return
+			}
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// subscription resolves the topic and resume point of a stream request,
+// writing an error response when it cannot
+func (sh *StreamHandler) subscription(w http.ResponseWriter, r *http.Request) (string, int64, bool) {
+	query := r.URL.Query()
+
+	var topic string
+	switch {
+	case query.Get("draft") != "":
+		id := query.Get("draft")
+		if _, err := sh.sessions.GetSession(r.Context(), id); err != nil {
+			writeDraftError(w, "Error streaming draft session", err)
+			return "", 0, false
+		}
+		topic = service.DraftTopic(id)
+	case query.Get("game") != "":
+		gameID := query.Get("game")
+		if _, err := sh.live.GetLiveGame(gameID); err != nil {
+			http.Error(w, fmt.Sprintf("Error streaming live game: %v", err), http.StatusNotFound)
+			return "", 0, false
+		}
+		topic = service.LiveGameTopic(gameID)
+	default:
+		http.Error(w, "draft or game parameter is required", http.StatusBadRequest)
+		return "", 0, false
+	}
+
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = query.Get("lastEventId")
+	}
+	if lastEventID == "" {
+		return topic, 0, true
+	}
+
+	id, err := strconv.ParseInt(lastEventID, 10, 64)
+	if err != nil || id < 0 {
+		http.Error(w, "invalid last event id", http.StatusBadRequest)
+		return "", 0, false
+	}
+	return topic, id, true
+}
@@ -0,0 +1,54 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/service"
+)
+
+type TeamHandler struct {
+	service *service.TeamProfileService
+}
+
+func NewTeamHandler(service *service.TeamProfileService) *TeamHandler {
+	return &TeamHandler{
+		service: service,
+	}
+}
+
+// ProfileHandler returns the draft tendencies of a team, keyed by its
+// lolesports slug or code. The games can be narrowed with the same filters
+// as /stats/champions.
+func (th *TeamHandler) ProfileHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	team := r.URL.Query().Get("team")
+	if team == "" {
+		http.Error(w, "team parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	filter, err := parseDraftGamesFilter(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	profile, err := th.service.GetTeamProfile(r.Context(), team, filter)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrTeamNotFound) {
+			status = http.StatusNotFound
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(profile)
+}
@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/service"
+)
+
+type WinProbHandler struct {
+	service *service.WinProbService
+}
+
+func NewWinProbHandler(service *service.WinProbService) *WinProbHandler {
+	return &WinProbHandler{
+		service: service,
+	}
+}
+
+// WinProbabilityHandler predicts each side's chance of winning for a board
+// posted in the body, or for a draft session given by ?session=
+func (wh *WinProbHandler) WinProbabilityHandler(w http.ResponseWriter, r *http.Request) {
+	var req service.BoardRequest
+	switch r.Method {
+	case http.MethodGet:
+		req.SessionID = r.URL.Query().Get("session")
+		if req.SessionID == "" {
+			http.Error(w, "session parameter is required", http.StatusBadRequest)
+			return
+		}
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+			return
+		}
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	prediction, err := wh.service.Predict(r.Context(), req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, service.ErrNoModel):
+			status = http.StatusServiceUnavailable
+		case errors.Is(err, service.ErrSessionNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, service.ErrInvalidPrediction):
+			status = http.StatusUnprocessableEntity
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(prediction)
+}
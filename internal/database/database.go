@@ -14,6 +14,7 @@ type DB interface {
 	QueryRow(query string, args ...interface{}) *sql.Row
 	Query(query string, args ...interface{}) (*sql.Rows, error)
 	Exec(query string, args ...interface{}) (sql.Result, error)
+	Begin() (*sql.Tx, error)
 	Close() error
 }
 
@@ -14,6 +14,7 @@ type MockDB struct {
 	QueryRowFunc func(query string, args ...interface{}) *sql.Row
 	QueryFunc    func(query string, args ...interface{}) (*sql.Rows, error)
 	ExecFunc     func(query string, args ...interface{}) (sql.Result, error)
+	BeginFunc    func() (*sql.Tx, error)
 	CloseFunc    func() error
 }
 
@@ -38,6 +39,13 @@ func (m *MockDB) Exec(query string, args ...interface{}) (sql.Result, error) {
 	return nil, nil
 }
 
+func (m *MockDB) Begin() (*sql.Tx, error) {
+	if m.BeginFunc != nil {
+		return m.BeginFunc()
+	}
+	return nil, nil
+}
+
 func (m *MockDB) Close() error {
 	if m.CloseFunc != nil {
 		return m.CloseFunc()
@@ -0,0 +1,236 @@
+package database
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// DraftSelections is one side's bans, picks and roles as stored in JSONB
+type DraftSelections struct {
+	Bans  []string `json:"bans"`
+	Picks []string `json:"picks"`
+	Roles []string `json:"roles"`
+}
+
+// DraftGame represents a pro draft in the database
+type DraftGame struct {
+	ID         int
+	GameID     string
+	MatchID    string
+	Tournament string
+	League     string
+	Patch      string
+	PlayedAt   *time.Time
+	BlueTeam   string
+	RedTeam    string
+	Winner     string
+	Blue       DraftSelections
+	Red        DraftSelections
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// DraftGameFilter narrows ListDraftGames. Empty fields are ignored.
+// PatchFrom and PatchTo bound the patch inclusively, comparing patches part
+// by part so 14.9 comes before 14.10.
+type DraftGameFilter struct {
+	Tournament string
+	League     string
+	Team       string
+	PatchFrom  string
+	PatchTo    string
+	From       *time.Time
+	To         *time.Time
+}
+
+// patchVersion turns a stored patch into an integer array Postgres compares
+// part by part. Patches that are not dotted numbers are NULL and so never
+// fall inside a patch bound.
+const patchVersion = `CASE WHEN patch ~ '^[0-9]+(\.[0-9]+)*$' THEN string_to_array(patch, '.')::int[] END`
+
+// SaveDraftGame saves a draft to the database, replacing any earlier copy
+// of the same game
+func (c *Client) SaveDraftGame(game *DraftGame) error {
+	blueJSON, err := json.Marshal(game.Blue)
+	if err != nil {
+		return fmt.Errorf("failed to marshal blue selections: %w", err)
+	}
+	redJSON, err := json.Marshal(game.Red)
+	if err != nil {
+		return fmt.Errorf("failed to marshal red selections: %w", err)
+	}
+
+	query := `
+		INSERT INTO draft_games (game_id, match_id, tournament, league, patch, played_at,
+			blue_team, red_team, winner, blue, red, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (game_id)
+		DO UPDATE SET
+			match_id = EXCLUDED.match_id,
+			tournament = EXCLUDED.tournament,
+			league = EXCLUDED.league,
+			patch = EXCLUDED.patch,
+			played_at = EXCLUDED.played_at,
+			blue_team = EXCLUDED.blue_team,
+			red_team = EXCLUDED.red_team,
+			winner = EXCLUDED.winner,
+			blue = EXCLUDED.blue,
+			red = EXCLUDED.red,
+			updated_at = EXCLUDED.updated_at
+		RETURNING id, created_at, updated_at
+	`
+
+	err = c.db.QueryRow(
+		query,
+		game.GameID,
+		game.MatchID,
+		game.Tournament,
+		game.League,
+		game.Patch,
+		game.PlayedAt,
+		game.BlueTeam,
+		game.RedTeam,
+		game.Winner,
+		blueJSON,
+		redJSON,
+		time.Now(),
+	).Scan(&game.ID, &game.CreatedAt, &game.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to save draft game: %w", err)
+	}
+
+	return nil
+}
+
+const draftGameColumns = `id, game_id, match_id, tournament, league, patch, played_at,
+	blue_team, red_team, winner, blue, red, created_at, updated_at`
+
+// GetDraftGame retrieves a stored draft by its game ID
+func (c *Client) GetDraftGame(gameID string) (*DraftGame, error) {
+	query := `SELECT ` + draftGameColumns + ` FROM draft_games WHERE game_id = $1`
+
+	game, err := scanDraftGame(c.db.QueryRow(query, gameID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return game, nil
+}
+
+// ListDraftGames retrieves the stored drafts matching filter, oldest first
+func (c *Client) ListDraftGames(filter DraftGameFilter) ([]DraftGame, error) {
+	conditions := []string{}
+	args := []interface{}{}
+	add := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Tournament != "" {
+		add("tournament = $%d", filter.Tournament)
+	}
+	if filter.League != "" {
+		add("league = $%d", filter.League)
+	}
+	if filter.Team != "" {
+		args = append(args, filter.Team)
+		conditions = append(conditions, fmt.Sprintf("(blue_team = $%d OR red_team = $%d)", len(args), len(args)))
+	}
+	if filter.PatchFrom != "" {
+		add(patchVersion+" >= $%d", pq.Array(patchParts(filter.PatchFrom)))
+	}
+	if filter.PatchTo != "" {
+		add(patchVersion+" <= $%d", pq.Array(patchParts(filter.PatchTo)))
+	}
+	if filter.From != nil {
+		add("played_at >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		add("played_at <= $%d", *filter.To)
+	}
+
+	query := `SELECT ` + draftGameColumns + ` FROM draft_games`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY played_at, game_id"
+
+	rows, err := c.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list draft games: %w", err)
+	}
+	defer rows.Close()
+
+	games := []DraftGame{}
+	for rows.Next() {
+		game, err := scanDraftGame(rows)
+		if err != nil {
+			return nil, err
+		}
+		games = append(games, *game)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list draft games: %w", err)
+	}
+
+	return games, nil
+}
+
+// patchParts splits a patch bound into its numeric parts. Parts that are not
+// numbers count as zero, as analytics.ComparePatches treats them.
+func patchParts(patch string) []int64 {
+	parts := []int64{}
+	for _, part := range strings.Split(patch, ".") {
+		n, _ := strconv.ParseInt(part, 10, 64)
+		parts = append(parts, n)
+	}
+	return parts
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanDraftGame(row scanner) (*DraftGame, error) {
+	game := &DraftGame{}
+	var blueJSON, redJSON []byte
+	if err := row.Scan(
+		&game.ID,
+		&game.GameID,
+		&game.MatchID,
+		&game.Tournament,
+		&game.League,
+		&game.Patch,
+		&game.PlayedAt,
+		&game.BlueTeam,
+		&game.RedTeam,
+		&game.Winner,
+		&blueJSON,
+		&redJSON,
+		&game.CreatedAt,
+		&game.UpdatedAt,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to scan draft game: %w", err)
+	}
+	if err := json.Unmarshal(blueJSON, &game.Blue); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal blue selections: %w", err)
+	}
+	if err := json.Unmarshal(redJSON, &game.Red); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal red selections: %w", err)
+	}
+	return game, nil
+}
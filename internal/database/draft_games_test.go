@@ -0,0 +1,156 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestSaveDraftGame_WithSqlMock(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	client := NewClientWithDB(db)
+	now := time.Now()
+
+	game := &DraftGame{
+		GameID:   "LCK/2024 Season/Summer Season_Week 1_1_1",
+		BlueTeam: "T1",
+		RedTeam:  "Gen.G",
+		Winner:   "blue",
+		Blue:     DraftSelections{Bans: []string{"Azir"}, Picks: []string{"Ahri"}},
+	}
+
+	mock.ExpectQuery(`INSERT INTO draft_games`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(7, now, now))
+
+	if err := client.SaveDraftGame(game); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if game.ID != 7 {
+		t.Errorf("expected ID to be 7, got %d", game.ID)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %s", err)
+	}
+}
+
+func TestListDraftGames_WithSqlMock(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	client := NewClientWithDB(db)
+	now := time.Now()
+
+	rows := sqlmock.NewRows([]string{"id", "game_id", "match_id", "tournament", "league", "patch",
+		"played_at", "blue_team", "red_team", "winner", "blue", "red", "created_at", "updated_at"}).
+		AddRow(1, "game-1", "match-1", "LCK/2024 Season/Summer Season", "LCK", "14.13",
+			now, "T1", "Gen.G", "red", []byte(`{"bans":["Azir"],"picks":["Ahri"],"roles":["Mid"]}`),
+			[]byte(`{"bans":[],"picks":["Corki"],"roles":["Mid"]}`), now, now)
+
+	mock.ExpectQuery(`SELECT id, game_id(.+) WHERE league = \$1 AND \(blue_team = \$2 OR red_team = \$2\)`).
+		WithArgs("LCK", "T1").
+		WillReturnRows(rows)
+
+	games, err := client.ListDraftGames(DraftGameFilter{League: "LCK", Team: "T1"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(games) != 1 {
+		t.Fatalf("expected 1 game, got %d", len(games))
+	}
+	if games[0].Blue.Picks[0] != "Ahri" || games[0].Red.Roles[0] != "Mid" {
+		t.Errorf("expected selections to be decoded, got %+v and %+v", games[0].Blue, games[0].Red)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %s", err)
+	}
+}
+
+func TestListDraftGames_PatchWindow(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	client := NewClientWithDB(db)
+
+	mock.ExpectQuery(`FROM draft_games WHERE tournament = \$1 AND CASE WHEN patch (.+) END >= \$2 AND CASE WHEN patch (.+) END <= \$3`).
+		WithArgs("LCK/2024 Season/Summer Season", "{14,9}", "{14,10}").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "game_id", "match_id", "tournament", "league", "patch",
+			"played_at", "blue_team", "red_team", "winner", "blue", "red", "created_at", "updated_at"}))
+
+	games, err := client.ListDraftGames(DraftGameFilter{
+		Tournament: "LCK/2024 Season/Summer Season",
+		PatchFrom:  "14.9",
+		PatchTo:    "14.10",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(games) != 0 {
+		t.Errorf("expected no games, got %d", len(games))
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetDraftGame_WithSqlMock(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	client := NewClientWithDB(db)
+	now := time.Now()
+
+	rows := sqlmock.NewRows([]string{"id", "game_id", "match_id", "tournament", "league", "patch",
+		"played_at", "blue_team", "red_team", "winner", "blue", "red", "created_at", "updated_at"}).
+		AddRow(1, "game-1", "match-1", "LCK/2024 Season/Summer Season", "LCK", "14.13",
+			now, "T1", "Gen.G", "red", []byte(`{"bans":["Azir"],"picks":["Ahri"],"roles":["Mid"]}`),
+			[]byte(`{"bans":[],"picks":["Corki"],"roles":["Mid"]}`), now, now)
+
+	mock.ExpectQuery(`SELECT id, game_id(.+) WHERE game_id = \$1`).
+		WithArgs("game-1").
+		WillReturnRows(rows)
+
+	game, err := client.GetDraftGame("game-1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if game == nil || game.BlueTeam != "T1" || game.Red.Picks[0] != "Corki" {
+		t.Errorf("expected the stored game, got %+v", game)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetDraftGame_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	client := NewClientWithDB(db)
+	mock.ExpectQuery(`SELECT id, game_id`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	game, err := client.GetDraftGame("missing")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if game != nil {
+		t.Error("expected game to be nil for not found case")
+	}
+}
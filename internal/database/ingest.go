@@ -0,0 +1,104 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// AnyQueue is the checkpoint queue of crawls not filtered by queue
+const AnyQueue = -1
+
+// IngestCheckpoint records which part of a player's match history has been
+// crawled in a region, for one queue and match type filter. Every match
+// played between CrawledFrom, or the start of the history when it is nil,
+// and CrawledUntil that passes the filter is stored, so the next crawl with
+// the same filter only has to list the matches outside that span. Matches
+// counts the matches the player's crawls stored.
+type IngestCheckpoint struct {
+	PUUID        string
+	Region       string
+	Queue        int
+	Type         string
+	CrawledFrom  *time.Time
+	CrawledUntil time.Time
+	Matches      int
+	UpdatedAt    time.Time
+}
+
+// MatchExists reports whether a match is already stored
+func (c *Client) MatchExists(matchID string) (bool, error) {
+	var exists bool
+	err := c.db.QueryRow(`SELECT EXISTS (SELECT 1 FROM matches WHERE match_id = $1)`, matchID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check match: %w", err)
+	}
+	return exists, nil
+}
+
+// SaveIngestCheckpoint saves how far a player's history has been crawled,
+// replacing the previous checkpoint of the same region, queue and type
+func (c *Client) SaveIngestCheckpoint(checkpoint *IngestCheckpoint) error {
+	query := `
+		INSERT INTO ingest_checkpoints (puuid, region, queue, match_type, crawled_from, crawled_until, matches, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (puuid, region, queue, match_type)
+		DO UPDATE SET
+			crawled_from = EXCLUDED.crawled_from,
+			crawled_until = EXCLUDED.crawled_until,
+			matches = EXCLUDED.matches,
+			updated_at = EXCLUDED.updated_at
+		RETURNING updated_at
+	`
+
+	err := c.db.QueryRow(
+		query,
+		checkpoint.PUUID,
+		checkpoint.Region,
+		checkpoint.Queue,
+		checkpoint.Type,
+		checkpoint.CrawledFrom,
+		checkpoint.CrawledUntil,
+		checkpoint.Matches,
+		time.Now(),
+	).Scan(&checkpoint.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to save ingest checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// GetIngestCheckpoint retrieves a player's checkpoint for a region, queue
+// and match type, or nil when the player was never crawled with them
+func (c *Client) GetIngestCheckpoint(puuid, region string, queue int, matchType string) (*IngestCheckpoint, error) {
+	query := `
+		SELECT puuid, region, queue, match_type, crawled_from, crawled_until, matches, updated_at
+		FROM ingest_checkpoints
+		WHERE puuid = $1 AND region = $2 AND queue = $3 AND match_type = $4
+	`
+
+	checkpoint := &IngestCheckpoint{}
+	err := c.db.QueryRow(query, puuid, region, queue, matchType).Scan(
+		&checkpoint.PUUID,
+		&checkpoint.Region,
+		&checkpoint.Queue,
+		&checkpoint.Type,
+		&checkpoint.CrawledFrom,
+		&checkpoint.CrawledUntil,
+		&checkpoint.Matches,
+		&checkpoint.UpdatedAt,
+	)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ingest checkpoint: %w", err)
+	}
+
+	return checkpoint, nil
+}
@@ -0,0 +1,59 @@
+package database
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestIngestCheckpoint_WithSqlMock(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	client := NewClientWithDB(db)
+	now := time.Now()
+
+	mock.ExpectQuery(`FROM ingest_checkpoints`).WithArgs("puuid-1", "asia", 420, "ranked").WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery(`INSERT INTO ingest_checkpoints`).
+		WithArgs("puuid-1", "asia", 420, "ranked", now.Add(-time.Hour), now, 12, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(now))
+
+	checkpoint, err := client.GetIngestCheckpoint("puuid-1", "asia", 420, "ranked")
+	if err != nil || checkpoint != nil {
+		t.Fatalf("expected no checkpoint for a new player, got %+v, %v", checkpoint, err)
+	}
+
+	from := now.Add(-time.Hour)
+	checkpoint = &IngestCheckpoint{PUUID: "puuid-1", Region: "asia", Queue: 420, Type: "ranked", CrawledFrom: &from, CrawledUntil: now, Matches: 12}
+	if err := client.SaveIngestCheckpoint(checkpoint); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !checkpoint.UpdatedAt.Equal(now) {
+		t.Errorf("expected UpdatedAt to be set, got %v", checkpoint.UpdatedAt)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %s", err)
+	}
+}
+
+func TestMatchExists_WithSqlMock(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	client := NewClientWithDB(db)
+	mock.ExpectQuery(`SELECT EXISTS`).WithArgs("KR_1").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	exists, err := client.MatchExists("KR_1")
+	if err != nil || !exists {
+		t.Errorf("expected KR_1 to exist, got %v, %v", exists, err)
+	}
+}
@@ -0,0 +1,219 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// LeagueSnapshot is a player's ranked standing in one queue at a moment
+type LeagueSnapshot struct {
+	ID           int
+	PUUID        string
+	Platform     string
+	QueueType    string
+	Tier         string
+	Rank         string
+	LeaguePoints int
+	Wins         int
+	Losses       int
+	TakenAt      time.Time
+}
+
+// MasterySnapshot is a player's mastery of one champion at a moment
+type MasterySnapshot struct {
+	ID             int
+	PUUID          string
+	Platform       string
+	ChampionID     int
+	ChampionLevel  int
+	ChampionPoints int
+	LastPlayTime   int64
+	TakenAt        time.Time
+}
+
+// SnapshotFilter narrows a player's snapshot history down. QueueType only
+// applies to league snapshots and ChampionID to mastery snapshots; zero
+// values leave a filter out.
+type SnapshotFilter struct {
+	QueueType  string
+	ChampionID int
+	From       *time.Time
+	To         *time.Time
+}
+
+// SavePlayerSnapshots saves a player's ranked standings and champion
+// masteries in one transaction, so a snapshot is never stored half written
+func (c *Client) SavePlayerSnapshots(leagues []LeagueSnapshot, masteries []MasterySnapshot) error {
+	tx, err := c.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin snapshot transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := saveLeagueSnapshots(tx, leagues); err != nil {
+		return err
+	}
+	if err := saveMasterySnapshots(tx, masteries); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit snapshots: %w", err)
+	}
+	return nil
+}
+
+func saveLeagueSnapshots(tx *sql.Tx, snapshots []LeagueSnapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO league_snapshots (puuid, platform, queue_type, tier, rank, league_points, wins, losses, taken_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare league snapshot: %w", err)
+	}
+	defer stmt.Close()
+
+	for i := range snapshots {
+		s := &snapshots[i]
+		err := stmt.QueryRow(
+			s.PUUID,
+			s.Platform,
+			s.QueueType,
+			s.Tier,
+			s.Rank,
+			s.LeaguePoints,
+			s.Wins,
+			s.Losses,
+			s.TakenAt,
+		).Scan(&s.ID)
+		if err != nil {
+			return fmt.Errorf("failed to save league snapshot: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func saveMasterySnapshots(tx *sql.Tx, snapshots []MasterySnapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO mastery_snapshots (puuid, platform, champion_id, champion_level, champion_points, last_play_time, taken_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare mastery snapshot: %w", err)
+	}
+	defer stmt.Close()
+
+	for i := range snapshots {
+		s := &snapshots[i]
+		err := stmt.QueryRow(
+			s.PUUID,
+			s.Platform,
+			s.ChampionID,
+			s.ChampionLevel,
+			s.ChampionPoints,
+			s.LastPlayTime,
+			s.TakenAt,
+		).Scan(&s.ID)
+		if err != nil {
+			return fmt.Errorf("failed to save mastery snapshot: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ListLeagueSnapshots retrieves a player's ranked history, oldest first
+func (c *Client) ListLeagueSnapshots(puuid string, filter SnapshotFilter) ([]LeagueSnapshot, error) {
+	conditions, args := snapshotConditions(puuid, filter)
+	if filter.QueueType != "" {
+		args = append(args, filter.QueueType)
+		conditions = append(conditions, fmt.Sprintf("queue_type = $%d", len(args)))
+	}
+
+	query := `SELECT id, puuid, platform, queue_type, tier, rank, league_points, wins, losses, taken_at
+		FROM league_snapshots WHERE ` + strings.Join(conditions, " AND ") + ` ORDER BY taken_at, queue_type`
+
+	rows, err := c.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list league snapshots: %w", err)
+	}
+	defer rows.Close()
+
+	snapshots := []LeagueSnapshot{}
+	for rows.Next() {
+		var s LeagueSnapshot
+		if err := rows.Scan(&s.ID, &s.PUUID, &s.Platform, &s.QueueType, &s.Tier, &s.Rank,
+			&s.LeaguePoints, &s.Wins, &s.Losses, &s.TakenAt); err != nil {
+			return nil, fmt.Errorf("failed to scan league snapshot: %w", err)
+		}
+		snapshots = append(snapshots, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list league snapshots: %w", err)
+	}
+
+	return snapshots, nil
+}
+
+// ListMasterySnapshots retrieves a player's champion mastery history,
+// oldest first
+func (c *Client) ListMasterySnapshots(puuid string, filter SnapshotFilter) ([]MasterySnapshot, error) {
+	conditions, args := snapshotConditions(puuid, filter)
+	if filter.ChampionID != 0 {
+		args = append(args, filter.ChampionID)
+		conditions = append(conditions, fmt.Sprintf("champion_id = $%d", len(args)))
+	}
+
+	query := `SELECT id, puuid, platform, champion_id, champion_level, champion_points, last_play_time, taken_at
+		FROM mastery_snapshots WHERE ` + strings.Join(conditions, " AND ") + ` ORDER BY taken_at, champion_id`
+
+	rows, err := c.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list mastery snapshots: %w", err)
+	}
+	defer rows.Close()
+
+	snapshots := []MasterySnapshot{}
+	for rows.Next() {
+		var s MasterySnapshot
+		if err := rows.Scan(&s.ID, &s.PUUID, &s.Platform, &s.ChampionID, &s.ChampionLevel,
+			&s.ChampionPoints, &s.LastPlayTime, &s.TakenAt); err != nil {
+			return nil, fmt.Errorf("failed to scan mastery snapshot: %w", err)
+		}
+		snapshots = append(snapshots, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list mastery snapshots: %w", err)
+	}
+
+	return snapshots, nil
+}
+
+// snapshotConditions builds the WHERE conditions shared by both snapshot
+// tables
+func snapshotConditions(puuid string, filter SnapshotFilter) ([]string, []interface{}) {
+	conditions := []string{"puuid = $1"}
+	args := []interface{}{puuid}
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		conditions = append(conditions, fmt.Sprintf("taken_at >= $%d", len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		conditions = append(conditions, fmt.Sprintf("taken_at <= $%d", len(args)))
+	}
+	return conditions, args
+}
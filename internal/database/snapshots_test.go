@@ -0,0 +1,113 @@
+package database
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestSaveSnapshots_WithSqlMock(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	client := NewClientWithDB(db)
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectPrepare(`INSERT INTO league_snapshots`).ExpectQuery().
+		WithArgs("puuid-1", "kr", "RANKED_SOLO_5x5", "DIAMOND", "II", 64, 120, 98, now).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	mock.ExpectPrepare(`INSERT INTO mastery_snapshots`).ExpectQuery().
+		WithArgs("puuid-1", "kr", 103, 12, 154321, int64(1716000000000), now).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(8))
+	mock.ExpectCommit()
+
+	league := []LeagueSnapshot{{PUUID: "puuid-1", Platform: "kr", QueueType: "RANKED_SOLO_5x5",
+		Tier: "DIAMOND", Rank: "II", LeaguePoints: 64, Wins: 120, Losses: 98, TakenAt: now}}
+	mastery := []MasterySnapshot{{PUUID: "puuid-1", Platform: "kr", ChampionID: 103, ChampionLevel: 12,
+		ChampionPoints: 154321, LastPlayTime: 1716000000000, TakenAt: now}}
+	if err := client.SavePlayerSnapshots(league, mastery); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if league[0].ID != 7 || mastery[0].ID != 8 {
+		t.Errorf("expected IDs to be set, got %d and %d", league[0].ID, mastery[0].ID)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSaveSnapshots_RollsBackOnError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	client := NewClientWithDB(db)
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectPrepare(`INSERT INTO league_snapshots`).ExpectQuery().
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	mock.ExpectPrepare(`INSERT INTO mastery_snapshots`).ExpectQuery().
+		WillReturnError(errors.New("connection reset"))
+	mock.ExpectRollback()
+
+	league := []LeagueSnapshot{{PUUID: "puuid-1", Platform: "kr", QueueType: "RANKED_SOLO_5x5", TakenAt: now}}
+	mastery := []MasterySnapshot{{PUUID: "puuid-1", Platform: "kr", ChampionID: 103, TakenAt: now}}
+	if err := client.SavePlayerSnapshots(league, mastery); err == nil {
+		t.Fatal("expected an error")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %s", err)
+	}
+}
+
+func TestListSnapshots_Filters(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	client := NewClientWithDB(db)
+	from := time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`FROM league_snapshots WHERE puuid = \$1 AND taken_at >= \$2 AND taken_at <= \$3 AND queue_type = \$4`).
+		WithArgs("puuid-1", from, to, "RANKED_SOLO_5x5").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "puuid", "platform", "queue_type", "tier", "rank",
+			"league_points", "wins", "losses", "taken_at"}).
+			AddRow(1, "puuid-1", "kr", "RANKED_SOLO_5x5", "DIAMOND", "II", 64, 120, 98, from))
+	mock.ExpectQuery(`FROM mastery_snapshots WHERE puuid = \$1 AND champion_id = \$2`).
+		WithArgs("puuid-1", 103).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "puuid", "platform", "champion_id", "champion_level",
+			"champion_points", "last_play_time", "taken_at"}).
+			AddRow(2, "puuid-1", "kr", 103, 12, 154321, int64(1716000000000), from))
+
+	league, err := client.ListLeagueSnapshots("puuid-1", SnapshotFilter{QueueType: "RANKED_SOLO_5x5", From: &from, To: &to})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(league) != 1 || league[0].Tier != "DIAMOND" || league[0].LeaguePoints != 64 {
+		t.Errorf("unexpected league snapshots %+v", league)
+	}
+
+	mastery, err := client.ListMasterySnapshots("puuid-1", SnapshotFilter{ChampionID: 103})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(mastery) != 1 || mastery[0].ChampionPoints != 154321 {
+		t.Errorf("unexpected mastery snapshots %+v", mastery)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,65 @@
+package draft
+
+// SideBoard is what one side has banned and picked so far
+type SideBoard struct {
+	Team   string   `json:"team"`
+	Bans   []string `json:"bans"`
+	Picks  []Action `json:"picks"`
+	Locked []string `json:"locked,omitempty"`
+}
+
+// Board is a read-only view of a draft, laid out the way it is shown on
+// screen: one column per side plus whose turn it is
+type Board struct {
+	Format   Format    `json:"format"`
+	Blue     SideBoard `json:"blue"`
+	Red      SideBoard `json:"red"`
+	Phase    Phase     `json:"phase,omitempty"`
+	NextTurn *Turn     `json:"nextTurn,omitempty"`
+	Complete bool      `json:"complete"`
+	Actions  []Action  `json:"actions"`
+	Swaps    []Swap    `json:"swaps,omitempty"`
+	Disabled []string  `json:"disabled,omitempty"`
+}
+
+// Board returns a snapshot of the draft that is safe to hand out while the
+// draft keeps changing
+func (d *Draft) Board() Board {
+	format := d.Format
+	if format == "" {
+		format = FormatTournament
+	}
+
+	board := Board{
+		Format:   format,
+		Blue:     d.sideBoard(Blue),
+		Red:      d.sideBoard(Red),
+		Actions:  append([]Action{}, d.Actions...),
+		Swaps:    append([]Swap{}, d.Swaps...),
+		Disabled: d.Disabled,
+	}
+
+	if turn, ok := d.NextTurn(); ok {
+		board.Phase = turn.Phase
+		board.NextTurn = &turn
+	} else {
+		board.Complete = true
+	}
+
+	return board
+}
+
+func (d *Draft) sideBoard(side Side) SideBoard {
+	board := SideBoard{
+		Team:   d.Team(side),
+		Bans:   d.Bans(side),
+		Picks:  []Action{},
+		Locked: d.Locked[side],
+	}
+	for _, action := range d.Actions {
+		if action.Side == side && action.Type == Pick {
+			board.Picks = append(board.Picks, action)
+		}
+	}
+	return board
+}
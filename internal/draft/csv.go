@@ -0,0 +1,180 @@
+package draft
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidCSV = errors.New("invalid draft csv")
+
+// CSVHeader is the header row of the CSV draft export. Every action is one
+// row, followed by a "swap" row per swap; draft and series columns repeat on
+// every row of a game so the file can be filtered in a spreadsheet.
+var CSVHeader = []string{
+	"game", "blue_team", "red_team", "format", "best_of", "series_game", "ruleset",
+	"turn", "side", "type", "champion", "role", "swap_with",
+}
+
+// WriteCSV writes records as CSV, numbering them from 1 in the game column.
+// Roles are the ones assigned at pick time, as in draft notation.
+func WriteCSV(w io.Writer, records []Record) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(CSVHeader); err != nil {
+		return fmt.Errorf("error writing csv: %w", err)
+	}
+
+	for i, record := range records {
+		d := record.Draft
+		series := SeriesContext{}
+		if record.Series != nil {
+			series = *record.Series
+		}
+		row := func(turn, side, actionType, champion, role, swapWith string) []string {
+			return []string{
+				strconv.Itoa(i + 1), d.BlueTeam, d.RedTeam, string(d.Format),
+				optionalInt(series.BestOf), optionalInt(series.Game), string(series.Ruleset),
+				turn, side, actionType, champion, role, swapWith,
+			}
+		}
+
+		rows := [][]string{}
+		for j, action := range pickTimeActions(d) {
+			rows = append(rows, row(strconv.Itoa(j+1), string(action.Side), string(action.Type), action.Champion, action.Role, ""))
+		}
+		for _, swap := range d.Swaps {
+			rows = append(rows, row("", string(swap.Side), "swap", swap.Champions[0], "", swap.Champions[1]))
+		}
+		if len(rows) == 0 {
+			// Keep drafts with no actions yet so their teams survive
+			rows = append(rows, row("", "", "", "", "", ""))
+		}
+
+		if err := writer.WriteAll(rows); err != nil {
+			return fmt.Errorf("error writing csv: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("error writing csv: %w", err)
+	}
+	return nil
+}
+
+// ReadCSV reads records written by WriteCSV. Columns are matched by header
+// name, so they may come in any order; rows of a game must be in draft order.
+func ReadCSV(r io.Reader) ([]Record, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCSV, err)
+	}
+	if len(rows) == 0 {
+		return []Record{}, nil
+	}
+
+	columns := make(map[string]int)
+	for i, name := range rows[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"game", "blue_team", "red_team", "side", "type", "champion"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("%w: missing column %q", ErrInvalidCSV, required)
+		}
+	}
+	field := func(row []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	records := []Record{}
+	byGame := make(map[string]int)
+	for n, row := range rows[1:] {
+		line := n + 2
+		game := field(row, "game")
+
+		i, ok := byGame[game]
+		if !ok {
+			format, err := ParseFormat(field(row, "format"))
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidCSV, line, err)
+			}
+			d := New(field(row, "blue_team"), field(row, "red_team"))
+			d.Format = format
+
+			record := Record{Draft: d}
+			series, err := csvSeries(row, field)
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidCSV, line, err)
+			}
+			record.Series = series
+
+			i = len(records)
+			byGame[game] = i
+			records = append(records, record)
+		}
+		d := records[i].Draft
+
+		side, actionType := Side(strings.ToLower(field(row, "side"))), strings.ToLower(field(row, "type"))
+		switch actionType {
+		case "":
+			continue
+		case "swap":
+			if err := d.Swap(side, field(row, "champion"), field(row, "swap_with")); err != nil {
+				return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidCSV, line, err)
+			}
+		default:
+			action := Action{
+				Side:     side,
+				Type:     ActionType(actionType),
+				Champion: field(row, "champion"),
+				Role:     field(row, "role"),
+			}
+			if err := d.Apply(action); err != nil {
+				return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidCSV, line, err)
+			}
+		}
+	}
+	return records, nil
+}
+
+func csvSeries(row []string, field func([]string, string) string) (*SeriesContext, error) {
+	bestOf, game, ruleset := field(row, "best_of"), field(row, "series_game"), field(row, "ruleset")
+	if bestOf == "" && game == "" && ruleset == "" {
+		return nil, nil
+	}
+
+	series := &SeriesContext{}
+	var err error
+	if bestOf != "" {
+		if series.BestOf, err = strconv.Atoi(bestOf); err != nil {
+			return nil, fmt.Errorf("invalid best_of %q", bestOf)
+		}
+	}
+	if game != "" {
+		if series.Game, err = strconv.Atoi(game); err != nil {
+			return nil, fmt.Errorf("invalid series_game %q", game)
+		}
+	}
+	if ruleset != "" {
+		if series.Ruleset, err = ParseRuleset(ruleset); err != nil {
+			return nil, err
+		}
+	}
+	return series, nil
+}
+
+func optionalInt(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
@@ -0,0 +1,249 @@
+package draft
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// Weights of the similarity score. Picks matter most, then bans, then
+// whether shared picks were played in the same roles.
+const (
+	pickSimilarityWeight = 0.5
+	banSimilarityWeight  = 0.3
+	roleSimilarityWeight = 0.2
+)
+
+// Slot is where a champion was taken in one draft. Order is the position
+// among that side's bans or picks, starting at 1.
+type Slot struct {
+	Team  string     `json:"team"`
+	Side  Side       `json:"side"`
+	Type  ActionType `json:"type"`
+	Phase Phase      `json:"phase"`
+	Turn  int        `json:"turn"`
+	Order int        `json:"order"`
+	Role  string     `json:"role,omitempty"`
+}
+
+// ChampionChange is a champion taken in both drafts but not in the same way.
+// Changes lists what differs: side, type, phase, order or role.
+type ChampionChange struct {
+	Champion string   `json:"champion"`
+	A        Slot     `json:"a"`
+	B        Slot     `json:"b"`
+	Changes  []string `json:"changes"`
+}
+
+// DiffSelections are the bans and picks found in only one of the drafts
+type DiffSelections struct {
+	Bans  []string `json:"bans"`
+	Picks []string `json:"picks"`
+}
+
+// Diff compares two drafts. Sides are matched blue to blue unless a team
+// played both drafts on different sides, in which case SidesSwapped is set
+// and the team's sides are matched instead.
+type Diff struct {
+	Similarity   float64          `json:"similarity"`
+	Team         string           `json:"team,omitempty"`
+	SidesSwapped bool             `json:"sidesSwapped"`
+	SharedBans   []string         `json:"sharedBans"`
+	SharedPicks  []string         `json:"sharedPicks"`
+	OnlyInA      DiffSelections   `json:"onlyInA"`
+	OnlyInB      DiffSelections   `json:"onlyInB"`
+	Changes      []ChampionChange `json:"changes"`
+}
+
+// Compare diffs draft a against draft b. team may name a team that played
+// both drafts so its sides are compared with each other; it is ignored when
+// empty or missing from either draft.
+func Compare(a, b *Draft, team string) Diff {
+	diff := Diff{
+		SharedBans:  []string{},
+		SharedPicks: []string{},
+		OnlyInA:     DiffSelections{Bans: []string{}, Picks: []string{}},
+		OnlyInB:     DiffSelections{Bans: []string{}, Picks: []string{}},
+		Changes:     []ChampionChange{},
+	}
+
+	if sideA, sideB, ok := teamSides(a, b, team); ok {
+		diff.Team = team
+		diff.SidesSwapped = sideA != sideB
+	}
+	// align maps a side of b onto the side of a it is compared with
+	align := func(side Side) Side {
+		if diff.SidesSwapped {
+			return side.Opponent()
+		}
+		return side
+	}
+
+	slotsA, slotsB := slots(a), slots(b)
+
+	var sharedPicks, sharedBans, unionPicks, unionBans int
+	var sameRoles, rolesCompared int
+	for key, slotA := range slotsA {
+		slotB, ok := slotsB[key]
+		if !ok {
+			continue
+		}
+		champion := slotA.champion
+
+		changes := []string{}
+		sameSide := slotA.Side == align(slotB.Side)
+		if !sameSide {
+			changes = append(changes, "side")
+		}
+		if slotA.Type != slotB.Type {
+			changes = append(changes, "type")
+		}
+		if slotA.Phase != slotB.Phase {
+			changes = append(changes, "phase")
+		}
+		if slotA.Type == slotB.Type && slotA.Order != slotB.Order {
+			changes = append(changes, "order")
+		}
+		if slotA.Type == Pick && slotB.Type == Pick && slotA.Role != "" && slotB.Role != "" {
+			rolesCompared++
+			if strings.EqualFold(slotA.Role, slotB.Role) {
+				sameRoles++
+			} else {
+				changes = append(changes, "role")
+			}
+		}
+
+		if sameSide && slotA.Type == slotB.Type {
+			if slotA.Type == Pick {
+				diff.SharedPicks = append(diff.SharedPicks, champion)
+				sharedPicks++
+			} else {
+				diff.SharedBans = append(diff.SharedBans, champion)
+				sharedBans++
+			}
+		}
+		if len(changes) > 0 {
+			diff.Changes = append(diff.Changes, ChampionChange{
+				Champion: champion,
+				A:        slotA.Slot,
+				B:        slotB.Slot,
+				Changes:  changes,
+			})
+		}
+	}
+
+	for key, slot := range slotsA {
+		if slot.Type == Pick {
+			unionPicks++
+		} else {
+			unionBans++
+		}
+		if _, ok := slotsB[key]; !ok {
+			diff.OnlyInA.add(slot)
+		}
+	}
+	for key, slot := range slotsB {
+		match, ok := slotsA[key]
+		counted := ok && match.Type == slot.Type && match.Side == align(slot.Side)
+		if !counted {
+			if slot.Type == Pick {
+				unionPicks++
+			} else {
+				unionBans++
+			}
+		}
+		if !ok {
+			diff.OnlyInB.add(slot)
+		}
+	}
+
+	roleScore := 1.0
+	if rolesCompared > 0 {
+		roleScore = float64(sameRoles) / float64(rolesCompared)
+	}
+	similarity := pickSimilarityWeight*ratio(sharedPicks, unionPicks) +
+		banSimilarityWeight*ratio(sharedBans, unionBans) +
+		roleSimilarityWeight*roleScore
+	diff.Similarity = math.Round(similarity*1000) / 1000
+
+	sort.Strings(diff.SharedBans)
+	sort.Strings(diff.SharedPicks)
+	for _, selections := range []*DiffSelections{&diff.OnlyInA, &diff.OnlyInB} {
+		sort.Strings(selections.Bans)
+		sort.Strings(selections.Picks)
+	}
+	sort.Slice(diff.Changes, func(i, j int) bool {
+		return diff.Changes[i].A.Turn < diff.Changes[j].A.Turn
+	})
+	return diff
+}
+
+func (s *DiffSelections) add(slot championSlot) {
+	if slot.Type == Pick {
+		s.Picks = append(s.Picks, slot.champion)
+	} else {
+		s.Bans = append(s.Bans, slot.champion)
+	}
+}
+
+// ratio is shared over union, with two empty sets counting as identical
+func ratio(shared, union int) float64 {
+	if union == 0 {
+		return 1
+	}
+	return float64(shared) / float64(union)
+}
+
+// teamSides finds the side team played in each draft
+func teamSides(a, b *Draft, team string) (Side, Side, bool) {
+	if team == "" {
+		return "", "", false
+	}
+	sideA, okA := a.SideOf(team)
+	sideB, okB := b.SideOf(team)
+	return sideA, sideB, okA && okB
+}
+
+// SideOf returns the side team plays on, ignoring case
+func (d *Draft) SideOf(team string) (Side, bool) {
+	switch {
+	case strings.EqualFold(d.BlueTeam, team):
+		return Blue, true
+	case strings.EqualFold(d.RedTeam, team):
+		return Red, true
+	}
+	return "", false
+}
+
+type championSlot struct {
+	Slot
+	champion string
+}
+
+// slots indexes every champion banned or picked in d by normalized name
+func slots(d *Draft) map[string]championSlot {
+	order := d.Order()
+	counts := map[Side]map[ActionType]int{Blue: {}, Red: {}}
+
+	indexed := make(map[string]championSlot)
+	for i, action := range d.Actions {
+		counts[action.Side][action.Type]++
+		if action.Champion == NoBan || action.Champion == "" {
+			continue
+		}
+
+		slot := Slot{
+			Team:  d.Team(action.Side),
+			Side:  action.Side,
+			Type:  action.Type,
+			Turn:  i + 1,
+			Order: counts[action.Side][action.Type],
+			Role:  action.Role,
+		}
+		if i < len(order) {
+			slot.Phase = order[i].Phase
+		}
+		indexed[normalize(action.Champion)] = championSlot{Slot: slot, champion: action.Champion}
+	}
+	return indexed
+}
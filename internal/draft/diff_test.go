@@ -0,0 +1,112 @@
+package draft
+
+import (
+	"testing"
+)
+
+func playedDraft(t *testing.T, blueTeam, redTeam string, actions []Action) *Draft {
+	t.Helper()
+	d := New(blueTeam, redTeam)
+	for i, action := range actions {
+		if err := d.Apply(action); err != nil {
+			t.Fatalf("action %d: expected no error, got %v", i+1, err)
+		}
+	}
+	return d
+}
+
+func TestCompare_Identical(t *testing.T) {
+	d := playedDraft(t, "T1", "Gen.G", fullDraft())
+
+	diff := Compare(d, d, "")
+	if diff.Similarity != 1 {
+		t.Errorf("expected similarity 1, got %f", diff.Similarity)
+	}
+	if len(diff.SharedPicks) != 10 || len(diff.SharedBans) != 10 {
+		t.Errorf("expected every pick and ban to be shared, got %d picks and %d bans", len(diff.SharedPicks), len(diff.SharedBans))
+	}
+	if len(diff.Changes) != 0 {
+		t.Errorf("expected no changes, got %+v", diff.Changes)
+	}
+}
+
+func TestCompare_Changes(t *testing.T) {
+	a := playedDraft(t, "T1", "Gen.G", fullDraft())
+
+	actions := fullDraft()
+	actions[0].Champion = "Corki"   // Corki banned first instead of picked by red
+	actions[11].Champion = "Azir"   // Azir picked by red instead of banned
+	actions[4].Champion = "Vi"      // Vi banned in the first phase instead of the second
+	actions[13].Champion = "Zeri"   // Zeri replaces Vi in the second phase
+	actions[6].Champion = "Sejuani" // Blue first picks Sejuani instead of Ashe
+	actions[6].Role = "Jungle"
+	actions[9].Champion = "Ashe"
+	actions[9].Role = "Support"
+	b := playedDraft(t, "T1", "Gen.G", actions)
+
+	diff := Compare(a, b, "")
+
+	changes := map[string][]string{}
+	for _, change := range diff.Changes {
+		changes[change.Champion] = change.Changes
+	}
+	expected := map[string][]string{
+		"Corki":   {"side", "type", "phase"},
+		"Azir":    {"side", "type", "phase"},
+		"Vi":      {"phase", "order"},
+		"Sejuani": {"order"},
+		"Ashe":    {"order", "role"},
+	}
+	for champion, want := range expected {
+		got := changes[champion]
+		if len(got) != len(want) {
+			t.Errorf("%s: expected changes %v, got %v", champion, want, got)
+			continue
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%s: expected changes %v, got %v", champion, want, got)
+				break
+			}
+		}
+	}
+	if len(diff.Changes) != len(expected) {
+		t.Errorf("expected %d changes, got %+v", len(expected), diff.Changes)
+	}
+
+	if len(diff.OnlyInA.Bans) != 1 || diff.OnlyInA.Bans[0] != "Rell" {
+		t.Errorf("expected Rell to be only banned in A, got %v", diff.OnlyInA.Bans)
+	}
+	if len(diff.OnlyInB.Bans) != 1 || diff.OnlyInB.Bans[0] != "Zeri" {
+		t.Errorf("expected Zeri to be only banned in B, got %v", diff.OnlyInB.Bans)
+	}
+	if diff.Similarity <= 0 || diff.Similarity >= 1 {
+		t.Errorf("expected a partial similarity, got %f", diff.Similarity)
+	}
+}
+
+func TestCompare_AlignsTeamSides(t *testing.T) {
+	a := playedDraft(t, "T1", "Gen.G", fullDraft())
+
+	// Same champions with T1 on red: every action moves to the other side
+	// of the draft order, so compare by team instead
+	mirrored := []Action{}
+	for _, action := range fullDraft() {
+		action.Side = action.Side.Opponent()
+		mirrored = append(mirrored, action)
+	}
+	b := &Draft{BlueTeam: "Gen.G", RedTeam: "T1", Actions: mirrored}
+
+	bySide := Compare(a, b, "")
+	if len(bySide.SharedPicks) != 0 {
+		t.Errorf("expected no shared picks when comparing by side, got %v", bySide.SharedPicks)
+	}
+
+	byTeam := Compare(a, b, "t1")
+	if !byTeam.SidesSwapped {
+		t.Error("expected the sides to be swapped")
+	}
+	if len(byTeam.SharedPicks) != 10 {
+		t.Errorf("expected every pick to be shared by team, got %v", byTeam.SharedPicks)
+	}
+}
@@ -0,0 +1,403 @@
+package draft
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Side is one of the two sides of the map
+type Side string
+
+const (
+	Blue Side = "blue"
+	Red  Side = "red"
+)
+
+// Opponent returns the other side
+func (s Side) Opponent() Side {
+	if s == Blue {
+		return Red
+	}
+	return Blue
+}
+
+// Valid reports whether s is a known side
+func (s Side) Valid() bool {
+	return s == Blue || s == Red
+}
+
+// ActionType is either a ban or a pick
+type ActionType string
+
+const (
+	Ban  ActionType = "ban"
+	Pick ActionType = "pick"
+)
+
+// Phase is one of the four tournament draft phases
+type Phase string
+
+const (
+	BanPhase1  Phase = "ban_phase_1"
+	PickPhase1 Phase = "pick_phase_1"
+	BanPhase2  Phase = "ban_phase_2"
+	PickPhase2 Phase = "pick_phase_2"
+)
+
+// NoBan is the champion name used for a skipped ban
+const NoBan = "None"
+
+// Format selects the turn order a draft follows
+type Format string
+
+const (
+	FormatTournament Format = "tournament"
+	FormatRanked     Format = "ranked"
+)
+
+var formatOrders = map[Format][]Turn{
+	FormatTournament: TournamentOrder,
+	FormatRanked:     RankedOrder,
+}
+
+// ParseFormat validates a format name, defaulting to the tournament draft
+func ParseFormat(name string) (Format, error) {
+	if name == "" {
+		return FormatTournament, nil
+	}
+	format := Format(strings.ToLower(name))
+	if _, ok := formatOrders[format]; !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownFormat, name)
+	}
+	return format, nil
+}
+
+// Turn is a single slot of the draft order
+type Turn struct {
+	Phase Phase      `json:"phase"`
+	Side  Side       `json:"side"`
+	Type  ActionType `json:"type"`
+}
+
+// TournamentOrder is the 20 step draft used in professional play
+var TournamentOrder = []Turn{
+	{BanPhase1, Blue, Ban}, {BanPhase1, Red, Ban},
+	{BanPhase1, Blue, Ban}, {BanPhase1, Red, Ban},
+	{BanPhase1, Blue, Ban}, {BanPhase1, Red, Ban},
+
+	{PickPhase1, Blue, Pick}, {PickPhase1, Red, Pick},
+	{PickPhase1, Red, Pick}, {PickPhase1, Blue, Pick},
+	{PickPhase1, Blue, Pick}, {PickPhase1, Red, Pick},
+
+	{BanPhase2, Red, Ban}, {BanPhase2, Blue, Ban},
+	{BanPhase2, Red, Ban}, {BanPhase2, Blue, Ban},
+
+	{PickPhase2, Red, Pick}, {PickPhase2, Blue, Pick},
+	{PickPhase2, Blue, Pick}, {PickPhase2, Red, Pick},
+}
+
+// RankedOrder is the draft used in ranked and normal draft queues. Both
+// teams ban at the same time, recorded here as alternating bans, and then
+// pick in a single snake phase.
+var RankedOrder = []Turn{
+	{BanPhase1, Blue, Ban}, {BanPhase1, Red, Ban},
+	{BanPhase1, Blue, Ban}, {BanPhase1, Red, Ban},
+	{BanPhase1, Blue, Ban}, {BanPhase1, Red, Ban},
+	{BanPhase1, Blue, Ban}, {BanPhase1, Red, Ban},
+	{BanPhase1, Blue, Ban}, {BanPhase1, Red, Ban},
+
+	{PickPhase1, Blue, Pick}, {PickPhase1, Red, Pick},
+	{PickPhase1, Red, Pick}, {PickPhase1, Blue, Pick},
+	{PickPhase1, Blue, Pick}, {PickPhase1, Red, Pick},
+	{PickPhase1, Red, Pick}, {PickPhase1, Blue, Pick},
+	{PickPhase1, Blue, Pick}, {PickPhase1, Red, Pick},
+}
+
+var (
+	ErrDraftComplete     = errors.New("draft is already complete")
+	ErrWrongSide         = errors.New("it is not this side's turn")
+	ErrWrongActionType   = errors.New("wrong action type for this turn")
+	ErrDuplicateChampion = errors.New("champion was already banned or picked")
+	ErrMissingChampion   = errors.New("champion is required")
+	ErrInvalidSide       = errors.New("invalid side")
+	ErrUnknownFormat     = errors.New("unknown draft format")
+	ErrNotPicked         = errors.New("champion was not picked by this side")
+	ErrChampionDisabled  = errors.New("champion is not available on this patch")
+	ErrTooManyHovers     = errors.New("too many hovers on this turn")
+)
+
+// Action is a ban or pick made by one side
+type Action struct {
+	Side     Side       `json:"side"`
+	Type     ActionType `json:"type"`
+	Champion string     `json:"champion"`
+	Role     string     `json:"role,omitempty"`
+}
+
+// Swap is a trade of two champions between teammates after they were
+// picked, which exchanges the roles they end up played in
+type Swap struct {
+	Side      Side      `json:"side"`
+	Champions [2]string `json:"champions"`
+}
+
+// Draft is the single draft model every data source converts into. Actions
+// are stored in the order they were made and always follow the draft order.
+type Draft struct {
+	Format   Format   `json:"format,omitempty"`
+	BlueTeam string   `json:"blueTeam"`
+	RedTeam  string   `json:"redTeam"`
+	Actions  []Action `json:"actions"`
+	Swaps    []Swap   `json:"swaps,omitempty"`
+
+	// Locked holds champions a side may not pick, e.g. because they were
+	// already played earlier in a fearless series
+	Locked map[Side][]string `json:"locked,omitempty"`
+
+	// Disabled holds champions neither side may ban or pick, such as
+	// champions disabled for the patch or not yet released
+	Disabled []string `json:"disabled,omitempty"`
+
+	// Timing is only known for drafts captured as they were played
+	Timing *Timing `json:"timing,omitempty"`
+}
+
+// New creates an empty draft between two teams
+func New(blueTeam, redTeam string) *Draft {
+	return &Draft{
+		BlueTeam: blueTeam,
+		RedTeam:  redTeam,
+		Actions:  []Action{},
+	}
+}
+
+// Order returns the turn order this draft follows
+func (d *Draft) Order() []Turn {
+	if order, ok := formatOrders[d.Format]; ok {
+		return order
+	}
+	return TournamentOrder
+}
+
+// NextTurn returns the turn that has to be played next, or false when the
+// draft is complete
+func (d *Draft) NextTurn() (Turn, bool) {
+	order := d.Order()
+	if len(d.Actions) >= len(order) {
+		return Turn{}, false
+	}
+	return order[len(d.Actions)], true
+}
+
+// Complete reports whether every turn has been played
+func (d *Draft) Complete() bool {
+	_, ok := d.NextTurn()
+	return !ok
+}
+
+// Team returns the name of the team playing on side
+func (d *Draft) Team(side Side) string {
+	if side == Blue {
+		return d.BlueTeam
+	}
+	return d.RedTeam
+}
+
+// Apply validates an action against the draft order and appends it
+func (d *Draft) Apply(action Action) error {
+	turn, ok := d.NextTurn()
+	if !ok {
+		return ErrDraftComplete
+	}
+
+	if !action.Side.Valid() {
+		return fmt.Errorf("%w: %q", ErrInvalidSide, action.Side)
+	}
+	if action.Side != turn.Side {
+		return fmt.Errorf("turn %d: %w (expected %s)", len(d.Actions)+1, ErrWrongSide, turn.Side)
+	}
+	if action.Type != turn.Type {
+		return fmt.Errorf("turn %d: %w (expected %s)", len(d.Actions)+1, ErrWrongActionType, turn.Type)
+	}
+
+	champion := strings.TrimSpace(action.Champion)
+	if champion == "" {
+		if action.Type != Ban {
+			return fmt.Errorf("turn %d: %w", len(d.Actions)+1, ErrMissingChampion)
+		}
+		champion = NoBan
+	}
+	action.Champion = champion
+
+	if champion != NoBan && containsChampion(d.Disabled, champion) {
+		return fmt.Errorf("turn %d: %w: %s", len(d.Actions)+1, ErrChampionDisabled, champion)
+	}
+	if champion != NoBan && d.Contains(champion) {
+		return fmt.Errorf("turn %d: %w: %s", len(d.Actions)+1, ErrDuplicateChampion, champion)
+	}
+	if action.Type == Pick && containsChampion(d.Locked[action.Side], champion) {
+		return fmt.Errorf("turn %d: %w: %s", len(d.Actions)+1, ErrChampionLocked, champion)
+	}
+
+	d.Actions = append(d.Actions, action)
+	return nil
+}
+
+// Swap trades two champions picked by the same side, exchanging their roles
+func (d *Draft) Swap(side Side, first, second string) error {
+	if !side.Valid() {
+		return fmt.Errorf("%w: %q", ErrInvalidSide, side)
+	}
+
+	i, j := d.pickIndex(side, first), d.pickIndex(side, second)
+	if i < 0 {
+		return fmt.Errorf("%w: %s", ErrNotPicked, first)
+	}
+	if j < 0 {
+		return fmt.Errorf("%w: %s", ErrNotPicked, second)
+	}
+
+	d.Actions[i].Role, d.Actions[j].Role = d.Actions[j].Role, d.Actions[i].Role
+	d.Swaps = append(d.Swaps, Swap{
+		Side:      side,
+		Champions: [2]string{d.Actions[i].Champion, d.Actions[j].Champion},
+	})
+	return nil
+}
+
+func (d *Draft) pickIndex(side Side, champion string) int {
+	key := normalize(champion)
+	for i, action := range d.Actions {
+		if action.Side == side && action.Type == Pick && normalize(action.Champion) == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// Validate replays the draft's actions from scratch and returns the first
+// illegal action
+func (d *Draft) Validate() error {
+	replay := New(d.BlueTeam, d.RedTeam)
+	replay.Format = d.Format
+	replay.Locked = d.Locked
+	replay.Disabled = d.Disabled
+	for _, action := range d.Actions {
+		if err := replay.Apply(action); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DisabledViolations returns every action that bans or picks a disabled
+// champion. Unlike Validate it does not stop at the first one, so imported
+// games can be flagged rather than rejected.
+func (d *Draft) DisabledViolations() error {
+	var violations []error
+	for i, action := range d.Actions {
+		if action.Champion != NoBan && containsChampion(d.Disabled, action.Champion) {
+			violations = append(violations, fmt.Errorf("turn %d: %w: %s %s %s",
+				i+1, ErrChampionDisabled, d.Team(action.Side), action.Type, action.Champion))
+		}
+	}
+	return errors.Join(violations...)
+}
+
+// Contains reports whether a champion was already banned or picked
+func (d *Draft) Contains(champion string) bool {
+	key := normalize(champion)
+	for _, action := range d.Actions {
+		if normalize(action.Champion) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Bans returns the bans of a side in the order they were made
+func (d *Draft) Bans(side Side) []string {
+	return d.champions(side, Ban)
+}
+
+// Picks returns the picks of a side in the order they were made
+func (d *Draft) Picks(side Side) []string {
+	return d.champions(side, Pick)
+}
+
+func (d *Draft) champions(side Side, actionType ActionType) []string {
+	champions := []string{}
+	for _, action := range d.Actions {
+		if action.Side == side && action.Type == actionType {
+			champions = append(champions, action.Champion)
+		}
+	}
+	return champions
+}
+
+// Selections holds one side's bans and picks, each in the order that side
+// made them. Roles, when known, line up with Picks.
+type Selections struct {
+	Bans  []string
+	Picks []string
+	Roles []string
+}
+
+// Assemble builds a draft from per-side selections by walking the draft
+// order, which is how sources that store bans and picks per team (such as
+// Leaguepedia) convert into a Draft. It stops at the first turn the
+// selections cannot fill, so partially recorded drafts are kept.
+func Assemble(blueTeam, redTeam string, blue, red Selections) (*Draft, error) {
+	return AssembleFormat("", blueTeam, redTeam, blue, red)
+}
+
+// AssembleFormat is Assemble for drafts that follow the order of format
+func AssembleFormat(format Format, blueTeam, redTeam string, blue, red Selections) (*Draft, error) {
+	d := New(blueTeam, redTeam)
+	d.Format = format
+	used := map[Side]map[ActionType]int{
+		Blue: {},
+		Red:  {},
+	}
+
+	for _, turn := range d.Order() {
+		selections := blue
+		if turn.Side == Red {
+			selections = red
+		}
+
+		n := used[turn.Side][turn.Type]
+		list := selections.Bans
+		if turn.Type == Pick {
+			list = selections.Picks
+		}
+		if n >= len(list) {
+			break
+		}
+
+		action := Action{Side: turn.Side, Type: turn.Type, Champion: list[n]}
+		if turn.Type == Pick && n < len(selections.Roles) {
+			action.Role = selections.Roles[n]
+		}
+		if err := d.Apply(action); err != nil {
+			return nil, err
+		}
+		used[turn.Side][turn.Type] = n + 1
+	}
+
+	return d, nil
+}
+
+// normalize makes champion names comparable regardless of case, spacing and
+// punctuation ("Kai'Sa" and "kaisa" are the same champion)
+func normalize(champion string) string {
+	var b strings.Builder
+	for _, r := range champion {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,267 @@
+package draft
+
+import (
+	"errors"
+	"testing"
+)
+
+// fullDraft plays a complete tournament draft, one action per turn
+func fullDraft() []Action {
+	return []Action{
+		{Blue, Ban, "Azir", ""}, {Red, Ban, "Kalista", ""},
+		{Blue, Ban, "Ahri", ""}, {Red, Ban, "Varus", ""},
+		{Blue, Ban, "Rell", ""}, {Red, Ban, "Orianna", ""},
+		{Blue, Pick, "Ashe", "Bot"}, {Red, Pick, "Xayah", "Bot"},
+		{Red, Pick, "Maokai", "Jungle"}, {Blue, Pick, "Sejuani", "Jungle"},
+		{Blue, Pick, "Taliyah", "Mid"}, {Red, Pick, "Corki", "Mid"},
+		{Red, Ban, "Jax", ""}, {Blue, Ban, "Vi", ""},
+		{Red, Ban, "Rakan", ""}, {Blue, Ban, "Nautilus", ""},
+		{Red, Pick, "Jayce", "Top"}, {Blue, Pick, "K'Sante", "Top"},
+		{Blue, Pick, "Lulu", "Support"}, {Red, Pick, "Braum", "Support"},
+	}
+}
+
+func TestApply_FullDraft(t *testing.T) {
+	d := New("T1", "Gen.G")
+	for i, action := range fullDraft() {
+		if err := d.Apply(action); err != nil {
+			t.Fatalf("action %d: expected no error, got %v", i+1, err)
+		}
+	}
+
+	if !d.Complete() {
+		t.Error("expected draft to be complete")
+	}
+	if picks := d.Picks(Blue); len(picks) != 5 || picks[0] != "Ashe" || picks[4] != "Lulu" {
+		t.Errorf("expected blue picks in pick order, got %v", picks)
+	}
+	if bans := d.Bans(Red); len(bans) != 5 || bans[3] != "Jax" {
+		t.Errorf("expected red bans in ban order, got %v", bans)
+	}
+	if err := d.Apply(Action{Blue, Ban, "Zed", ""}); !errors.Is(err, ErrDraftComplete) {
+		t.Errorf("expected ErrDraftComplete, got %v", err)
+	}
+	if err := d.Validate(); err != nil {
+		t.Errorf("expected full draft to validate, got %v", err)
+	}
+}
+
+func TestApply_IllegalActions(t *testing.T) {
+	tests := []struct {
+		name     string
+		played   int
+		action   Action
+		expected error
+	}{
+		{
+			name:     "red bans first",
+			played:   0,
+			action:   Action{Red, Ban, "Azir", ""},
+			expected: ErrWrongSide,
+		},
+		{
+			name:     "pick during ban phase",
+			played:   0,
+			action:   Action{Blue, Pick, "Azir", ""},
+			expected: ErrWrongActionType,
+		},
+		{
+			name:     "picking a banned champion",
+			played:   6,
+			action:   Action{Blue, Pick, "Azir", ""},
+			expected: ErrDuplicateChampion,
+		},
+		{
+			name:     "duplicate with different spelling",
+			played:   7,
+			action:   Action{Red, Pick, "ashe", ""},
+			expected: ErrDuplicateChampion,
+		},
+		{
+			name:     "pick without champion",
+			played:   6,
+			action:   Action{Blue, Pick, " ", ""},
+			expected: ErrMissingChampion,
+		},
+		{
+			name:     "unknown side",
+			played:   0,
+			action:   Action{"purple", Ban, "Azir", ""},
+			expected: ErrInvalidSide,
+		},
+		{
+			name:     "blue bans twice in a row in phase 2",
+			played:   12,
+			action:   Action{Blue, Ban, "Zed", ""},
+			expected: ErrWrongSide,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := New("T1", "Gen.G")
+			for _, action := range fullDraft()[:tt.played] {
+				if err := d.Apply(action); err != nil {
+					t.Fatalf("setup: unexpected error %v", err)
+				}
+			}
+
+			err := d.Apply(tt.action)
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+			if len(d.Actions) != tt.played {
+				t.Errorf("expected illegal action not to be recorded, got %d actions", len(d.Actions))
+			}
+		})
+	}
+}
+
+func TestApply_SkippedBans(t *testing.T) {
+	d := New("T1", "Gen.G")
+	if err := d.Apply(Action{Blue, Ban, "", ""}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := d.Apply(Action{Red, Ban, NoBan, ""}); err != nil {
+		t.Fatalf("expected a second skipped ban to be allowed, got %v", err)
+	}
+	if d.Actions[0].Champion != NoBan {
+		t.Errorf("expected empty ban to be stored as %q, got %q", NoBan, d.Actions[0].Champion)
+	}
+}
+
+func TestAssemble(t *testing.T) {
+	d, err := Assemble("T1", "Gen.G",
+		Selections{
+			Bans:  []string{"Azir", "Ahri", "Rell", "Vi", "Nautilus"},
+			Picks: []string{"Ashe", "Sejuani", "Taliyah", "K'Sante", "Lulu"},
+			Roles: []string{"Bot", "Jungle", "Mid", "Top", "Support"},
+		},
+		Selections{
+			Bans:  []string{"Kalista", "Varus", "Orianna", "Jax", "Rakan"},
+			Picks: []string{"Xayah", "Maokai", "Corki", "Jayce", "Braum"},
+		},
+	)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := fullDraft()
+	if len(d.Actions) != len(expected) {
+		t.Fatalf("expected %d actions, got %d", len(expected), len(d.Actions))
+	}
+	for i, action := range d.Actions {
+		if action.Side != expected[i].Side || action.Type != expected[i].Type || action.Champion != expected[i].Champion {
+			t.Errorf("action %d: expected %+v, got %+v", i+1, expected[i], action)
+		}
+		if action.Side == Blue && action.Role != expected[i].Role {
+			t.Errorf("action %d: expected role %q, got %q", i+1, expected[i].Role, action.Role)
+		}
+	}
+}
+
+func TestAssembleFormat_Ranked(t *testing.T) {
+	d, err := AssembleFormat(FormatRanked, "Blue", "Red",
+		Selections{
+			Bans:  []string{"Azir", "Ahri", "Rell", "Vi", "Nautilus"},
+			Picks: []string{"Ashe", "Sejuani", "Taliyah", "K'Sante", "Lulu"},
+		},
+		Selections{
+			Bans:  []string{"Kalista", "Varus", "Orianna", "Jax", "Rakan"},
+			Picks: []string{"Xayah", "Maokai", "Corki", "Jayce", "Braum"},
+		},
+	)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !d.Complete() {
+		t.Fatalf("expected a complete draft, got %d actions", len(d.Actions))
+	}
+
+	picks := []string{"Ashe", "Xayah", "Maokai", "Sejuani", "Taliyah", "Corki", "Jayce", "K'Sante", "Lulu", "Braum"}
+	for i, champion := range picks {
+		if action := d.Actions[10+i]; action.Type != Pick || action.Champion != champion {
+			t.Errorf("pick %d: expected %s, got %+v", i+1, champion, action)
+		}
+	}
+}
+
+func TestAssemble_PartialDraft(t *testing.T) {
+	d, err := Assemble("T1", "Gen.G",
+		Selections{Bans: []string{"Azir", "Ahri", "Rell"}, Picks: []string{"Ashe"}},
+		Selections{Bans: []string{"Kalista", "Varus", "Orianna"}},
+	)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(d.Actions) != 7 {
+		t.Errorf("expected draft to stop after 7 actions, got %d", len(d.Actions))
+	}
+	if turn, _ := d.NextTurn(); turn.Side != Red || turn.Type != Pick {
+		t.Errorf("expected red pick next, got %+v", turn)
+	}
+}
+
+func TestAssemble_Duplicate(t *testing.T) {
+	_, err := Assemble("T1", "Gen.G",
+		Selections{Bans: []string{"Azir"}},
+		Selections{Bans: []string{"Azir"}},
+	)
+	if !errors.Is(err, ErrDuplicateChampion) {
+		t.Errorf("expected ErrDuplicateChampion, got %v", err)
+	}
+}
+
+func TestSwap(t *testing.T) {
+	d := New("T1", "Gen.G")
+	for _, action := range fullDraft() {
+		d.Apply(action)
+	}
+
+	if err := d.Swap(Blue, "Ashe", "Lulu"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if d.Actions[6].Role != "Support" || d.Actions[18].Role != "Bot" {
+		t.Errorf("expected Ashe and Lulu to trade roles, got %s and %s", d.Actions[6].Role, d.Actions[18].Role)
+	}
+	if len(d.Swaps) != 1 || d.Swaps[0].Champions != [2]string{"Ashe", "Lulu"} {
+		t.Errorf("expected swap to be recorded, got %+v", d.Swaps)
+	}
+
+	if err := d.Swap(Blue, "Ashe", "Xayah"); !errors.Is(err, ErrNotPicked) {
+		t.Errorf("expected ErrNotPicked for the enemy's champion, got %v", err)
+	}
+}
+
+func TestApply_Disabled(t *testing.T) {
+	d := New("T1", "Gen.G")
+	d.Disabled = []string{"Smolder"}
+
+	if err := d.Apply(Action{Side: Blue, Type: Ban, Champion: "smolder"}); !errors.Is(err, ErrChampionDisabled) {
+		t.Errorf("expected ErrChampionDisabled, got %v", err)
+	}
+	if err := d.Apply(Action{Side: Blue, Type: Ban}); err != nil {
+		t.Errorf("expected a skipped ban to be allowed, got %v", err)
+	}
+}
+
+func TestDisabledViolations(t *testing.T) {
+	d := New("T1", "Gen.G")
+	for _, action := range fullDraft() {
+		if err := d.Apply(action); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+	d.Disabled = []string{"Azir", "Lulu"}
+
+	err := d.DisabledViolations()
+	if !errors.Is(err, ErrChampionDisabled) {
+		t.Fatalf("expected ErrChampionDisabled, got %v", err)
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); !ok || len(joined.Unwrap()) != 2 {
+		t.Errorf("expected both disabled champions to be reported, got %v", err)
+	}
+	if err := d.Validate(); !errors.Is(err, ErrChampionDisabled) {
+		t.Errorf("expected Validate to reject the draft, got %v", err)
+	}
+}
@@ -0,0 +1,331 @@
+package draft
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Draft notation is a one line text form of a draft that survives being
+// pasted into chat:
+//
+//	T1 vs Gen.G (bo5 g2 fearless): BB Azir, RB Kai'Sa, BB -, ..., BP Jax@top; B Jax<>Vi
+//
+// The header names the blue and red teams, quoted like Go strings when they
+// hold characters the header uses ("Team: Liquid" vs T1), optionally
+// followed by the series context in parentheses: best of, game number, ruleset and, for other
+// formats than the tournament draft, the format. Actions follow in draft
+// order as a side letter (B or R), a type letter (B for ban, P for pick), the
+// champion and an optional @role; "-" is a skipped ban. Roles are the ones
+// assigned at pick time, and the swaps after the semicolon trade them the
+// same way Draft.Swap does. Text may hold several drafts, one per line.
+
+var ErrInvalidNotation = errors.New("invalid draft notation")
+
+// SeriesContext places a draft within a series
+type SeriesContext struct {
+	BestOf  int     `json:"bestOf,omitempty"`
+	Game    int     `json:"game,omitempty"`
+	Ruleset Ruleset `json:"ruleset,omitempty"`
+}
+
+// Record is a draft together with its series context, the unit drafts are
+// exported and converted in
+type Record struct {
+	Draft  *Draft         `json:"draft"`
+	Series *SeriesContext `json:"series,omitempty"`
+}
+
+// Notation returns the record in draft notation
+func (r Record) Notation() string {
+	d := r.Draft
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s vs %s", teamNotation(d.BlueTeam), teamNotation(d.RedTeam))
+	if context := r.seriesNotation(); context != "" {
+		fmt.Fprintf(&b, " (%s)", context)
+	}
+	b.WriteString(":")
+
+	for i, action := range pickTimeActions(d) {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		champion := action.Champion
+		if champion == NoBan || champion == "" {
+			champion = "-"
+		}
+		fmt.Fprintf(&b, " %s%s %s", sideCode(action.Side), typeCode(action.Type), champion)
+		if action.Role != "" {
+			fmt.Fprintf(&b, "@%s", action.Role)
+		}
+	}
+
+	for i, swap := range d.Swaps {
+		separator := ","
+		if i == 0 {
+			separator = ";"
+		}
+		fmt.Fprintf(&b, "%s %s %s<>%s", separator, sideCode(swap.Side), swap.Champions[0], swap.Champions[1])
+	}
+
+	return b.String()
+}
+
+// teamNotation quotes a team name that would otherwise not parse back: one
+// holding a header separator, a quote, or starting with the # of a comment
+func teamNotation(team string) string {
+	if team == "" || team != strings.TrimSpace(team) || strings.HasPrefix(team, "#") ||
+		strings.ContainsAny(team, ":()\"\\\n") || strings.Contains(" "+team+" ", " vs ") {
+		return strconv.Quote(team)
+	}
+	return team
+}
+
+func (r Record) seriesNotation() string {
+	parts := []string{}
+	if r.Series != nil {
+		if r.Series.BestOf > 0 {
+			parts = append(parts, fmt.Sprintf("bo%d", r.Series.BestOf))
+		}
+		if r.Series.Game > 0 {
+			parts = append(parts, fmt.Sprintf("g%d", r.Series.Game))
+		}
+		if r.Series.Ruleset != "" && r.Series.Ruleset != RulesetStandard {
+			parts = append(parts, string(r.Series.Ruleset))
+		}
+	}
+	if r.Draft.Format != "" && r.Draft.Format != FormatTournament {
+		parts = append(parts, string(r.Draft.Format))
+	}
+	return strings.Join(parts, " ")
+}
+
+// pickTimeActions returns the draft's actions with the roles they had before
+// any swap, by undoing the swaps from last to first
+func pickTimeActions(d *Draft) []Action {
+	undone := &Draft{Actions: append([]Action{}, d.Actions...)}
+	for i := len(d.Swaps) - 1; i >= 0; i-- {
+		swap := d.Swaps[i]
+		a, b := undone.pickIndex(swap.Side, swap.Champions[0]), undone.pickIndex(swap.Side, swap.Champions[1])
+		if a >= 0 && b >= 0 {
+			undone.Actions[a].Role, undone.Actions[b].Role = undone.Actions[b].Role, undone.Actions[a].Role
+		}
+	}
+	return undone.Actions
+}
+
+// FormatNotation writes records in draft notation, one per line
+func FormatNotation(records []Record) string {
+	var b strings.Builder
+	for _, record := range records {
+		b.WriteString(record.Notation())
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// ParseNotation reads one draft per non-empty line. Lines starting with # are
+// comments. Every action is checked against the draft order.
+func ParseNotation(text string) ([]Record, error) {
+	records := []Record{}
+	for n, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		record, err := parseNotationLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n+1, err)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func parseNotationLine(line string) (Record, error) {
+	blueTeam, rest, err := parseTeamNotation(line, " vs ")
+	if err != nil {
+		return Record{}, err
+	}
+	rest = strings.TrimSpace(rest)
+	if !strings.HasPrefix(rest, "vs ") {
+		return Record{}, fmt.Errorf("%w: teams must be written as \"Blue vs Red\"", ErrInvalidNotation)
+	}
+	redTeam, rest, err := parseTeamNotation(strings.TrimSpace(rest[len("vs "):]), "(", ":")
+	if err != nil {
+		return Record{}, err
+	}
+
+	record := Record{}
+	format := FormatTournament
+	rest = strings.TrimSpace(rest)
+	if strings.HasPrefix(rest, "(") {
+		closing := strings.Index(rest, ")")
+		if closing < 0 {
+			return Record{}, fmt.Errorf("%w: missing ')' after the series context", ErrInvalidNotation)
+		}
+		series, f, err := parseSeriesNotation(rest[1:closing])
+		if err != nil {
+			return Record{}, err
+		}
+		record.Series, format = series, f
+		rest = strings.TrimSpace(rest[closing+1:])
+	}
+
+	body, ok := strings.CutPrefix(rest, ":")
+	if !ok {
+		return Record{}, fmt.Errorf("%w: missing ':' after the teams", ErrInvalidNotation)
+	}
+	d := New(blueTeam, redTeam)
+	d.Format = format
+
+	actions, swaps, _ := strings.Cut(body, ";")
+	for i, token := range splitList(actions) {
+		action, err := parseActionNotation(token)
+		if err != nil {
+			return Record{}, fmt.Errorf("action %d: %w", i+1, err)
+		}
+		if err := d.Apply(action); err != nil {
+			return Record{}, fmt.Errorf("%w: %w", ErrInvalidNotation, err)
+		}
+	}
+
+	for i, token := range splitList(swaps) {
+		code, champions, ok := strings.Cut(token, " ")
+		first, second, paired := strings.Cut(champions, "<>")
+		side, sideOK := parseSideCode(code)
+		if !ok || !paired || !sideOK {
+			return Record{}, fmt.Errorf("%w: swap %d: expected \"B Champion<>Champion\", got %q", ErrInvalidNotation, i+1, token)
+		}
+		if err := d.Swap(side, strings.TrimSpace(first), strings.TrimSpace(second)); err != nil {
+			return Record{}, fmt.Errorf("%w: swap %d: %w", ErrInvalidNotation, i+1, err)
+		}
+	}
+
+	record.Draft = d
+	return record, nil
+}
+
+// parseTeamNotation reads a team name at the start of text, either quoted or
+// running up to the first of the separators, and returns the text after it.
+// Callers check the separator they expect is there.
+func parseTeamNotation(text string, separators ...string) (string, string, error) {
+	if strings.HasPrefix(text, `"`) {
+		quoted, err := strconv.QuotedPrefix(text)
+		if err != nil {
+			return "", "", fmt.Errorf("%w: unterminated quoted team name", ErrInvalidNotation)
+		}
+		team, _ := strconv.Unquote(quoted)
+		return team, text[len(quoted):], nil
+	}
+
+	end := len(text)
+	for _, separator := range separators {
+		if i := strings.Index(text, separator); i >= 0 && i < end {
+			end = i
+		}
+	}
+	return strings.TrimSpace(text[:end]), text[end:], nil
+}
+
+func parseSeriesNotation(text string) (*SeriesContext, Format, error) {
+	series := &SeriesContext{}
+	format := FormatTournament
+	for _, token := range strings.Fields(strings.ToLower(text)) {
+		if n, ok := numberAfter(token, "bo"); ok {
+			series.BestOf = n
+			continue
+		}
+		if n, ok := numberAfter(token, "g"); ok {
+			series.Game = n
+			continue
+		}
+		if ruleset, err := ParseRuleset(token); err == nil {
+			series.Ruleset = ruleset
+			continue
+		}
+		if f, err := ParseFormat(token); err == nil {
+			format = f
+			continue
+		}
+		return nil, "", fmt.Errorf("%w: unknown series context %q", ErrInvalidNotation, token)
+	}
+	return series, format, nil
+}
+
+func parseActionNotation(token string) (Action, error) {
+	code, champion, _ := strings.Cut(token, " ")
+	if len(code) != 2 {
+		return Action{}, fmt.Errorf("%w: expected a side and type code such as BP, got %q", ErrInvalidNotation, token)
+	}
+	side, ok := parseSideCode(code[:1])
+	if !ok {
+		return Action{}, fmt.Errorf("%w: unknown side %q", ErrInvalidNotation, code[:1])
+	}
+
+	action := Action{Side: side}
+	switch strings.ToUpper(code[1:]) {
+	case "B":
+		action.Type = Ban
+	case "P":
+		action.Type = Pick
+	default:
+		return Action{}, fmt.Errorf("%w: unknown action type %q", ErrInvalidNotation, code[1:])
+	}
+
+	champion = strings.TrimSpace(champion)
+	if at := strings.LastIndex(champion, "@"); at >= 0 {
+		action.Role = strings.TrimSpace(champion[at+1:])
+		champion = strings.TrimSpace(champion[:at])
+	}
+	if champion == "-" {
+		champion = NoBan
+	}
+	action.Champion = champion
+	return action, nil
+}
+
+// splitList splits a comma separated list, dropping empty entries
+func splitList(text string) []string {
+	items := []string{}
+	for _, item := range strings.Split(text, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func numberAfter(token, prefix string) (int, bool) {
+	if !strings.HasPrefix(token, prefix) {
+		return 0, false
+	}
+	n, err := strconv.Atoi(token[len(prefix):])
+	return n, err == nil && n > 0
+}
+
+func sideCode(side Side) string {
+	if side == Red {
+		return "R"
+	}
+	return "B"
+}
+
+func parseSideCode(code string) (Side, bool) {
+	switch strings.ToUpper(code) {
+	case "B":
+		return Blue, true
+	case "R":
+		return Red, true
+	}
+	return "", false
+}
+
+func typeCode(actionType ActionType) string {
+	if actionType == Pick {
+		return "P"
+	}
+	return "B"
+}
@@ -0,0 +1,210 @@
+package draft
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// swappedDraft is a complete draft where blue traded the roles of two picks
+func swappedDraft(t *testing.T) *Draft {
+	t.Helper()
+	d := New("T1", "Gen.G")
+	for i, action := range fullDraft() {
+		if i == 2 {
+			action.Champion = ""
+		}
+		if err := d.Apply(action); err != nil {
+			t.Fatalf("action %d: expected no error, got %v", i+1, err)
+		}
+	}
+	if err := d.Swap(Blue, "Sejuani", "K'Sante"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	return d
+}
+
+func TestNotation_Format(t *testing.T) {
+	record := Record{
+		Draft:  swappedDraft(t),
+		Series: &SeriesContext{BestOf: 5, Game: 2, Ruleset: RulesetFearless},
+	}
+
+	notation := record.Notation()
+	for _, want := range []string{
+		"T1 vs Gen.G (bo5 g2 fearless): BB Azir, RB Kalista, BB -,",
+		"BP Sejuani@Jungle",
+		"BP K'Sante@Top",
+		"; B Sejuani<>K'Sante",
+	} {
+		if !strings.Contains(notation, want) {
+			t.Errorf("expected %q in %q", want, notation)
+		}
+	}
+}
+
+func TestNotation_RoundTrip(t *testing.T) {
+	record := Record{
+		Draft:  swappedDraft(t),
+		Series: &SeriesContext{BestOf: 3, Game: 1},
+	}
+
+	records, err := ParseNotation(record.Notation())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(records))
+	}
+
+	parsed := records[0]
+	if parsed.Notation() != record.Notation() {
+		t.Errorf("expected %q, got %q", record.Notation(), parsed.Notation())
+	}
+	if parsed.Series == nil || parsed.Series.BestOf != 3 || parsed.Series.Game != 1 {
+		t.Errorf("expected bo3 game 1, got %+v", parsed.Series)
+	}
+	if bans := parsed.Draft.Bans(Blue); bans[1] != NoBan {
+		t.Errorf("expected a skipped ban, got %v", bans)
+	}
+	for _, action := range parsed.Draft.Actions {
+		if action.Champion == "Sejuani" && action.Role != "Top" {
+			t.Errorf("expected the swap to move Sejuani to top, got %s", action.Role)
+		}
+	}
+}
+
+func TestNotation_RoundTripQuotedTeams(t *testing.T) {
+	teams := [][2]string{
+		{"Team: Liquid", "T1"},
+		{"Red vs Blue", "Gen.G (Academy)"},
+		{"#1 Seed", `The "Quote" Team`},
+		{`Back\slash`, "vs"},
+	}
+	for _, pair := range teams {
+		d := New(pair[0], pair[1])
+		if err := d.Apply(Action{Side: Blue, Type: Ban, Champion: "Azir"}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		notation := Record{Draft: d, Series: &SeriesContext{BestOf: 3}}.Notation()
+
+		records, err := ParseNotation(notation)
+		if err != nil {
+			t.Fatalf("%q: expected no error, got %v", notation, err)
+		}
+		if len(records) != 1 {
+			t.Fatalf("%q: expected 1 record, got %d", notation, len(records))
+		}
+		got := records[0]
+		if got.Draft.BlueTeam != pair[0] || got.Draft.RedTeam != pair[1] {
+			t.Errorf("%q: expected %q vs %q, got %q vs %q", notation, pair[0], pair[1], got.Draft.BlueTeam, got.Draft.RedTeam)
+		}
+		if got.Series == nil || got.Series.BestOf != 3 || len(got.Draft.Actions) != 1 {
+			t.Errorf("%q: expected the series and ban to survive, got %+v", notation, got)
+		}
+	}
+
+	if notation := (Record{Draft: New("T1", "Gen.G")}).Notation(); notation != "T1 vs Gen.G:" {
+		t.Errorf("expected plain team names to stay unquoted, got %q", notation)
+	}
+}
+
+func TestParseNotation(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		records int
+		wantErr bool
+	}{
+		{
+			name:    "partial draft",
+			text:    "T1 vs Gen.G: BB Azir, RB Kai'Sa, bb Lee Sin",
+			records: 1,
+		},
+		{
+			name:    "several lines and comments",
+			text:    "# week 1\nT1 vs Gen.G:\n\nHLE vs DK (bo3): BB Azir\n",
+			records: 2,
+		},
+		{
+			name:    "wrong side for the turn",
+			text:    "T1 vs Gen.G: RB Azir",
+			wantErr: true,
+		},
+		{
+			name:    "missing teams separator",
+			text:    "T1 Gen.G: BB Azir",
+			wantErr: true,
+		},
+		{
+			name:    "unterminated quoted team",
+			text:    `"T1 vs Gen.G: BB Azir`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown series context",
+			text:    "T1 vs Gen.G (bo5 blind): BB Azir",
+			wantErr: true,
+		},
+		{
+			name:    "swap of a champion that was not picked",
+			text:    "T1 vs Gen.G: BB Azir; B Azir<>Vi",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := ParseNotation(tt.text)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidNotation) {
+					t.Errorf("expected ErrInvalidNotation, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if len(records) != tt.records {
+				t.Errorf("expected %d records, got %d", tt.records, len(records))
+			}
+		})
+	}
+}
+
+func TestCSV_RoundTrip(t *testing.T) {
+	records := []Record{
+		{Draft: swappedDraft(t), Series: &SeriesContext{BestOf: 5, Game: 3, Ruleset: RulesetFearless}},
+		{Draft: New("HLE", "DK")},
+	}
+
+	var b bytes.Buffer
+	if err := WriteCSV(&b, records); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	parsed, err := ReadCSV(&b)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(parsed) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(parsed))
+	}
+	for i := range records {
+		if got, want := parsed[i].Notation(), records[i].Notation(); got != want {
+			t.Errorf("record %d: expected %q, got %q", i+1, want, got)
+		}
+	}
+}
+
+func TestReadCSV_Invalid(t *testing.T) {
+	if _, err := ReadCSV(strings.NewReader("game,side\n1,blue\n")); !errors.Is(err, ErrInvalidCSV) {
+		t.Errorf("expected ErrInvalidCSV for missing columns, got %v", err)
+	}
+
+	text := strings.Join(CSVHeader, ",") + "\n1,T1,Gen.G,,,,,1,red,ban,Azir,,\n"
+	if _, err := ReadCSV(strings.NewReader(text)); !errors.Is(err, ErrInvalidCSV) {
+		t.Errorf("expected ErrInvalidCSV for an action out of order, got %v", err)
+	}
+}
@@ -0,0 +1,227 @@
+package draft
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Ruleset decides which champions stay available across games of a series
+type Ruleset string
+
+const (
+	// RulesetStandard puts no restriction between games
+	RulesetStandard Ruleset = "standard"
+	// RulesetFearless locks every champion picked in an earlier game for
+	// both teams
+	RulesetFearless Ruleset = "fearless"
+	// RulesetFearlessTeam only stops a team from picking a champion it
+	// already picked itself
+	RulesetFearlessTeam Ruleset = "fearless_team"
+)
+
+var (
+	ErrChampionLocked = errors.New("champion is locked for this series")
+	ErrSeriesOver     = errors.New("series is already decided")
+	ErrGameInProgress = errors.New("current game draft is not complete")
+	ErrUnknownTeam    = errors.New("team is not part of this series")
+	ErrUnknownRuleset = errors.New("unknown series ruleset")
+	ErrInvalidBestOf  = errors.New("best of must be a positive odd number")
+	ErrNoGameToRecord = errors.New("no game without a winner to record")
+)
+
+// ParseRuleset validates a ruleset name, defaulting to the standard rules
+func ParseRuleset(name string) (Ruleset, error) {
+	switch Ruleset(strings.ToLower(name)) {
+	case "", RulesetStandard:
+		return RulesetStandard, nil
+	case RulesetFearless, "hard_fearless":
+		return RulesetFearless, nil
+	case RulesetFearlessTeam:
+		return RulesetFearlessTeam, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownRuleset, name)
+}
+
+// Series is a best-of set of drafts between the same two teams. Teams are
+// tracked by name because they can switch sides between games.
+type Series struct {
+	Team1   string   `json:"team1"`
+	Team2   string   `json:"team2"`
+	BestOf  int      `json:"bestOf"`
+	Ruleset Ruleset  `json:"ruleset"`
+	Games   []*Draft `json:"games"`
+	Winners []string `json:"winners"`
+
+	// Disabled is carried over to every game started in the series
+	Disabled []string `json:"disabled,omitempty"`
+}
+
+// NewSeries creates a series with no games played
+func NewSeries(team1, team2 string, bestOf int, ruleset Ruleset) (*Series, error) {
+	if bestOf < 1 || bestOf%2 == 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidBestOf, bestOf)
+	}
+	if ruleset == "" {
+		ruleset = RulesetStandard
+	}
+	return &Series{
+		Team1:   team1,
+		Team2:   team2,
+		BestOf:  bestOf,
+		Ruleset: ruleset,
+		Games:   []*Draft{},
+		Winners: []string{},
+	}, nil
+}
+
+// Current returns the game being played, or nil before the first game
+func (s *Series) Current() *Draft {
+	if len(s.Games) == 0 {
+		return nil
+	}
+	return s.Games[len(s.Games)-1]
+}
+
+// Wins returns how many games a team has won so far
+func (s *Series) Wins(team string) int {
+	wins := 0
+	for _, winner := range s.Winners {
+		if winner == team {
+			wins++
+		}
+	}
+	return wins
+}
+
+// Over reports whether a team has won the series or every game was played
+func (s *Series) Over() bool {
+	needed := s.BestOf/2 + 1
+	return s.Wins(s.Team1) >= needed || s.Wins(s.Team2) >= needed || len(s.Games) >= s.BestOf
+}
+
+// StartGame opens the next game's draft with blueTeam on the blue side. The
+// previous draft has to be complete and the series still undecided.
+func (s *Series) StartGame(blueTeam string) (*Draft, error) {
+	if current := s.Current(); current != nil && !current.Complete() {
+		return nil, ErrGameInProgress
+	}
+	if s.Over() {
+		return nil, ErrSeriesOver
+	}
+
+	redTeam, err := s.opponent(blueTeam)
+	if err != nil {
+		return nil, err
+	}
+
+	d := New(blueTeam, redTeam)
+	d.Locked = s.lockedBySide(blueTeam, redTeam)
+	d.Disabled = s.Disabled
+	s.Games = append(s.Games, d)
+	return d, nil
+}
+
+// RecordWinner stores the winner of the current game
+func (s *Series) RecordWinner(team string) error {
+	if _, err := s.opponent(team); err != nil {
+		return err
+	}
+	if len(s.Winners) >= len(s.Games) {
+		return ErrNoGameToRecord
+	}
+	s.Winners = append(s.Winners, team)
+	return nil
+}
+
+// AddGame appends an already played draft, such as an imported pro game, and
+// returns every pick that breaks the ruleset. The game is recorded even when
+// it breaks the rules so later games are checked against what was actually
+// played.
+func (s *Series) AddGame(d *Draft, winner string) error {
+	if _, err := s.opponent(d.BlueTeam); err != nil {
+		return err
+	}
+
+	locked := s.lockedBySide(d.BlueTeam, d.RedTeam)
+	var violations []error
+	for i, action := range d.Actions {
+		if action.Type == Pick && containsChampion(locked[action.Side], action.Champion) {
+			violations = append(violations, fmt.Errorf("game %d turn %d: %w: %s picked %s",
+				len(s.Games)+1, i+1, ErrChampionLocked, d.Team(action.Side), action.Champion))
+		}
+	}
+
+	s.Games = append(s.Games, d)
+	if winner != "" {
+		s.Winners = append(s.Winners, winner)
+	}
+	return errors.Join(violations...)
+}
+
+// LockedChampions returns, per team, the champions that team can no longer
+// pick in the next game
+func (s *Series) LockedChampions() map[string][]string {
+	return map[string][]string{
+		s.Team1: s.lockedFor(s.Team1),
+		s.Team2: s.lockedFor(s.Team2),
+	}
+}
+
+func (s *Series) lockedBySide(blueTeam, redTeam string) map[Side][]string {
+	if s.Ruleset == RulesetStandard {
+		return nil
+	}
+	return map[Side][]string{
+		Blue: s.lockedFor(blueTeam),
+		Red:  s.lockedFor(redTeam),
+	}
+}
+
+func (s *Series) lockedFor(team string) []string {
+	locked := []string{}
+	if s.Ruleset == RulesetStandard {
+		return locked
+	}
+
+	seen := make(map[string]bool)
+	for _, game := range s.Games {
+		for _, action := range game.Actions {
+			if action.Type != Pick {
+				continue
+			}
+			if s.Ruleset == RulesetFearlessTeam && game.Team(action.Side) != team {
+				continue
+			}
+			key := normalize(action.Champion)
+			if !seen[key] {
+				seen[key] = true
+				locked = append(locked, action.Champion)
+			}
+		}
+	}
+
+	sort.Strings(locked)
+	return locked
+}
+
+func (s *Series) opponent(team string) (string, error) {
+	switch team {
+	case s.Team1:
+		return s.Team2, nil
+	case s.Team2:
+		return s.Team1, nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnknownTeam, team)
+}
+
+func containsChampion(champions []string, champion string) bool {
+	key := normalize(champion)
+	for _, c := range champions {
+		if normalize(c) == key {
+			return true
+		}
+	}
+	return false
+}
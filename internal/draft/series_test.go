@@ -0,0 +1,162 @@
+package draft
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// playGame fills the series' next game with the standard test draft, with
+// blueTeam on the blue side
+func playGame(t *testing.T, s *Series, blueTeam string) *Draft {
+	t.Helper()
+	d, err := s.StartGame(blueTeam)
+	if err != nil {
+		t.Fatalf("expected no error starting game, got %v", err)
+	}
+	for _, action := range fullDraft() {
+		if err := d.Apply(action); err != nil {
+			return d
+		}
+	}
+	return d
+}
+
+func TestParseRuleset(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected Ruleset
+		err      error
+	}{
+		{name: "", expected: RulesetStandard},
+		{name: "Fearless", expected: RulesetFearless},
+		{name: "hard_fearless", expected: RulesetFearless},
+		{name: "fearless_team", expected: RulesetFearlessTeam},
+		{name: "chaos", err: ErrUnknownRuleset},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ruleset, err := ParseRuleset(tt.name)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected error %v, got %v", tt.err, err)
+			}
+			if ruleset != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, ruleset)
+			}
+		})
+	}
+}
+
+func TestNewSeries_InvalidBestOf(t *testing.T) {
+	for _, bestOf := range []int{0, 2, -3} {
+		if _, err := NewSeries("T1", "Gen.G", bestOf, RulesetStandard); !errors.Is(err, ErrInvalidBestOf) {
+			t.Errorf("bestOf %d: expected ErrInvalidBestOf, got %v", bestOf, err)
+		}
+	}
+}
+
+func TestSeries_HardFearless(t *testing.T) {
+	s, _ := NewSeries("T1", "Gen.G", 3, RulesetFearless)
+	playGame(t, s, "T1")
+	if err := s.RecordWinner("T1"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := s.RecordWinner("T1"); !errors.Is(err, ErrNoGameToRecord) {
+		t.Errorf("expected ErrNoGameToRecord, got %v", err)
+	}
+
+	// Game 2: Gen.G on blue tries to first pick Ashe, which T1 played
+	d, err := s.StartGame("Gen.G")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, action := range fullDraft()[:6] {
+		if err := d.Apply(action); err != nil {
+			t.Fatalf("setup: unexpected error %v", err)
+		}
+	}
+	if err := d.Apply(Action{Side: Blue, Type: Pick, Champion: "Ashe"}); !errors.Is(err, ErrChampionLocked) {
+		t.Errorf("expected ErrChampionLocked, got %v", err)
+	}
+
+	locked := s.LockedChampions()
+	if len(locked["T1"]) != 10 || !reflect.DeepEqual(locked["T1"], locked["Gen.G"]) {
+		t.Errorf("expected both teams to have the same 10 locked champions, got %v", locked)
+	}
+}
+
+func TestSeries_TeamFearless(t *testing.T) {
+	s, _ := NewSeries("T1", "Gen.G", 3, RulesetFearlessTeam)
+	playGame(t, s, "T1")
+
+	// Game 2 swaps sides: Gen.G may pick T1's Ashe but not its own Xayah
+	d, err := s.StartGame("Gen.G")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, action := range fullDraft()[:6] {
+		if err := d.Apply(action); err != nil {
+			t.Fatalf("setup: unexpected error %v", err)
+		}
+	}
+	if err := d.Apply(Action{Side: Blue, Type: Pick, Champion: "Xayah"}); !errors.Is(err, ErrChampionLocked) {
+		t.Errorf("expected ErrChampionLocked for Gen.G's own champion, got %v", err)
+	}
+	if err := d.Apply(Action{Side: Blue, Type: Pick, Champion: "Ashe"}); err != nil {
+		t.Errorf("expected Gen.G to be allowed T1's champion, got %v", err)
+	}
+}
+
+func TestSeries_Lifecycle(t *testing.T) {
+	s, _ := NewSeries("T1", "Gen.G", 3, RulesetStandard)
+
+	d, _ := s.StartGame("T1")
+	if _, err := s.StartGame("Gen.G"); !errors.Is(err, ErrGameInProgress) {
+		t.Errorf("expected ErrGameInProgress, got %v", err)
+	}
+	for _, action := range fullDraft() {
+		d.Apply(action)
+	}
+	s.RecordWinner("T1")
+
+	playGame(t, s, "Gen.G")
+	s.RecordWinner("T1")
+
+	if !s.Over() {
+		t.Error("expected series to be over after a 2-0")
+	}
+	if _, err := s.StartGame("T1"); !errors.Is(err, ErrSeriesOver) {
+		t.Errorf("expected ErrSeriesOver, got %v", err)
+	}
+	if _, err := s.StartGame("G2"); !errors.Is(err, ErrSeriesOver) {
+		t.Errorf("expected ErrSeriesOver before team check, got %v", err)
+	}
+}
+
+func TestSeries_AddGameReportsViolations(t *testing.T) {
+	s, _ := NewSeries("T1", "Gen.G", 5, RulesetFearless)
+
+	game1 := New("T1", "Gen.G")
+	for _, action := range fullDraft() {
+		game1.Apply(action)
+	}
+	if err := s.AddGame(game1, "T1"); err != nil {
+		t.Fatalf("expected first game to be legal, got %v", err)
+	}
+
+	game2 := New("Gen.G", "T1")
+	for _, action := range fullDraft() {
+		game2.Apply(action)
+	}
+	err := s.AddGame(game2, "Gen.G")
+	if !errors.Is(err, ErrChampionLocked) {
+		t.Fatalf("expected ErrChampionLocked, got %v", err)
+	}
+	if n := len(err.(interface{ Unwrap() []error }).Unwrap()); n != 10 {
+		t.Errorf("expected 10 violations, got %d", n)
+	}
+	if len(s.Games) != 2 {
+		t.Errorf("expected the illegal game to be recorded, got %d games", len(s.Games))
+	}
+}
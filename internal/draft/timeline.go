@@ -0,0 +1,257 @@
+package draft
+
+import (
+	"fmt"
+	"time"
+)
+
+// Kinds of timeline entries
+const (
+	TimelineStart = "start"
+	TimelineHover = "hover"
+	TimelineBan   = "ban"
+	TimelinePick  = "pick"
+	TimelineSwap  = "swap"
+)
+
+// Timing records when the events of a captured draft happened. Actions and
+// Swaps line up with the draft's actions and swaps; a zero time means the
+// moment is not known.
+type Timing struct {
+	StartedAt time.Time   `json:"startedAt"`
+	Actions   []time.Time `json:"actions"`
+	Swaps     []time.Time `json:"swaps"`
+	Hovers    []Hover     `json:"hovers"`
+}
+
+// MaxHoversPerTurn caps the hovers a draft keeps for one turn
+const MaxHoversPerTurn = 50
+
+// Hover is a champion a side showed on its turn before locking in. An empty
+// champion clears the hover.
+type Hover struct {
+	Turn     int       `json:"turn"`
+	Side     Side      `json:"side"`
+	Champion string    `json:"champion"`
+	At       time.Time `json:"at"`
+}
+
+// Start marks the moment the draft opened, from which its timeline is timed
+func (d *Draft) Start(at time.Time) {
+	d.Timing = &Timing{
+		StartedAt: at,
+		Actions:   []time.Time{},
+		Swaps:     []time.Time{},
+		Hovers:    []Hover{},
+	}
+}
+
+// ApplyAt applies an action and records when it was locked in
+func (d *Draft) ApplyAt(action Action, at time.Time) error {
+	if err := d.Apply(action); err != nil {
+		return err
+	}
+	timing := d.timing()
+	timing.Actions = stampAt(timing.Actions, len(d.Actions)-1, at)
+	return nil
+}
+
+// SwapAt trades two champions and records when the swap was made
+func (d *Draft) SwapAt(side Side, first, second string, at time.Time) error {
+	if err := d.Swap(side, first, second); err != nil {
+		return err
+	}
+	timing := d.timing()
+	timing.Swaps = stampAt(timing.Swaps, len(d.Swaps)-1, at)
+	return nil
+}
+
+// HoverChampion records the champion the side to play is showing, up to
+// MaxHoversPerTurn times per turn
+func (d *Draft) HoverChampion(side Side, champion string, at time.Time) error {
+	turn, ok := d.NextTurn()
+	if !ok {
+		return ErrDraftComplete
+	}
+	if !side.Valid() {
+		return fmt.Errorf("%w: %q", ErrInvalidSide, side)
+	}
+	if side != turn.Side {
+		return fmt.Errorf("turn %d: %w (expected %s)", len(d.Actions)+1, ErrWrongSide, turn.Side)
+	}
+
+	timing := d.timing()
+	hovers := 0
+	for _, hover := range timing.Hovers {
+		if hover.Turn == len(d.Actions)+1 {
+			hovers++
+		}
+	}
+	if hovers >= MaxHoversPerTurn {
+		return fmt.Errorf("turn %d: %w (at most %d)", len(d.Actions)+1, ErrTooManyHovers, MaxHoversPerTurn)
+	}
+
+	timing.Hovers = append(timing.Hovers, Hover{
+		Turn:     len(d.Actions) + 1,
+		Side:     side,
+		Champion: champion,
+		At:       at,
+	})
+	return nil
+}
+
+// timing returns the draft's timing, starting an untimed one when the draft
+// has none
+func (d *Draft) timing() *Timing {
+	if d.Timing == nil {
+		d.Start(time.Time{})
+	}
+	return d.Timing
+}
+
+// stampAt sets times[i], padding earlier unknown moments with zero times
+func stampAt(times []time.Time, i int, at time.Time) []time.Time {
+	for len(times) <= i {
+		times = append(times, time.Time{})
+	}
+	times[i] = at
+	return times
+}
+
+// TimelineEntry is one step of a draft replay together with the board as it
+// stood right after it. Times are only set when the draft recorded them;
+// Elapsed counts from the start of the draft and TurnTime from the start of
+// the turn a ban or pick was locked in, both in milliseconds.
+type TimelineEntry struct {
+	Step     int        `json:"step"`
+	Kind     string     `json:"kind"`
+	Turn     int        `json:"turn,omitempty"`
+	Phase    Phase      `json:"phase,omitempty"`
+	Side     Side       `json:"side,omitempty"`
+	Champion string     `json:"champion,omitempty"`
+	Role     string     `json:"role,omitempty"`
+	SwapWith string     `json:"swapWith,omitempty"`
+	At       *time.Time `json:"at,omitempty"`
+	Elapsed  *int64     `json:"elapsedMs,omitempty"`
+	TurnTime *int64     `json:"turnMs,omitempty"`
+	Board    Board      `json:"board"`
+}
+
+// Timeline replays the draft step by step: its start, then every turn's
+// hovers followed by the ban or pick. Swaps are placed by when they were
+// made, or at the end when that is not known. Roles are the ones assigned at
+// pick time until a swap trades them.
+func (d *Draft) Timeline() []TimelineEntry {
+	timing := d.Timing
+	if timing == nil {
+		timing = &Timing{}
+	}
+
+	replay := New(d.BlueTeam, d.RedTeam)
+	replay.Format = d.Format
+	replay.Locked = d.Locked
+	replay.Disabled = d.Disabled
+	order := d.Order()
+
+	startedAt := knownTime(timing.StartedAt)
+	entries := []TimelineEntry{{Kind: TimelineStart, At: startedAt, Board: replay.Board()}}
+	turnStart := startedAt
+
+	next := 0
+	// addSwaps replays the swaps made before a moment, or all that are left
+	// when final
+	addSwaps := func(before *time.Time, final bool) {
+		for ; next < len(d.Swaps); next++ {
+			swap := d.Swaps[next]
+			at := knownTime(timeAt(timing.Swaps, next))
+			if !final && (before == nil || at == nil || !at.Before(*before)) {
+				return
+			}
+			if err := replay.Swap(swap.Side, swap.Champions[0], swap.Champions[1]); err != nil {
+				if !final {
+					return
+				}
+				continue
+			}
+			entries = append(entries, TimelineEntry{
+				Kind:     TimelineSwap,
+				Side:     swap.Side,
+				Champion: swap.Champions[0],
+				SwapWith: swap.Champions[1],
+				At:       at,
+				Board:    replay.Board(),
+			})
+		}
+	}
+
+	for i, action := range pickTimeActions(d) {
+		turn := i + 1
+		lockedAt := knownTime(timeAt(timing.Actions, i))
+		addSwaps(lockedAt, false)
+
+		for _, hover := range timing.Hovers {
+			if hover.Turn != turn {
+				continue
+			}
+			entries = append(entries, TimelineEntry{
+				Kind:     TimelineHover,
+				Turn:     turn,
+				Phase:    order[i].Phase,
+				Side:     hover.Side,
+				Champion: hover.Champion,
+				At:       knownTime(hover.At),
+				Board:    replay.Board(),
+			})
+		}
+
+		if err := replay.Apply(action); err != nil {
+			break
+		}
+		entry := TimelineEntry{
+			Kind:     TimelineBan,
+			Turn:     turn,
+			Phase:    order[i].Phase,
+			Side:     action.Side,
+			Champion: action.Champion,
+			Role:     action.Role,
+			At:       lockedAt,
+			TurnTime: millisBetween(turnStart, lockedAt),
+			Board:    replay.Board(),
+		}
+		if action.Type == Pick {
+			entry.Kind = TimelinePick
+		}
+		entries = append(entries, entry)
+		turnStart = lockedAt
+	}
+	addSwaps(nil, true)
+
+	for i := range entries {
+		entries[i].Step = i
+		entries[i].Elapsed = millisBetween(startedAt, entries[i].At)
+	}
+	return entries
+}
+
+func timeAt(times []time.Time, i int) time.Time {
+	if i < len(times) {
+		return times[i]
+	}
+	return time.Time{}
+}
+
+// knownTime returns nil for the zero time
+func knownTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func millisBetween(from, to *time.Time) *int64 {
+	if from == nil || to == nil {
+		return nil
+	}
+	ms := to.Sub(*from).Milliseconds()
+	return &ms
+}
@@ -0,0 +1,107 @@
+package draft
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTimeline_Timed(t *testing.T) {
+	start := time.Date(2024, 5, 19, 9, 0, 0, 0, time.UTC)
+	d := New("T1", "Gen.G")
+	d.Start(start)
+
+	at := start
+	for i, action := range fullDraft() {
+		at = at.Add(20 * time.Second)
+		if i == 0 {
+			if err := d.HoverChampion(Blue, "Orianna", at.Add(-10*time.Second)); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		}
+		if err := d.ApplyAt(action, at); err != nil {
+			t.Fatalf("action %d: expected no error, got %v", i+1, err)
+		}
+		if i == 10 {
+			if err := d.SwapAt(Blue, "Ashe", "Sejuani", at.Add(5*time.Second)); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		}
+	}
+
+	timeline := d.Timeline()
+	if len(timeline) != 23 {
+		t.Fatalf("expected start, hover, 20 actions and a swap, got %d entries", len(timeline))
+	}
+
+	if timeline[0].Kind != TimelineStart || len(timeline[0].Board.Actions) != 0 {
+		t.Errorf("expected an empty board at the start, got %+v", timeline[0])
+	}
+	hover := timeline[1]
+	if hover.Kind != TimelineHover || hover.Champion != "Orianna" || *hover.Elapsed != 10000 {
+		t.Errorf("expected Orianna hovered after 10s, got %+v", hover)
+	}
+	ban := timeline[2]
+	if ban.Kind != TimelineBan || ban.Champion != "Azir" || *ban.TurnTime != 20000 || len(ban.Board.Actions) != 1 {
+		t.Errorf("expected Azir banned after a 20s turn, got %+v", ban)
+	}
+
+	swap := timeline[13]
+	if swap.Kind != TimelineSwap || swap.Champion != "Ashe" || swap.SwapWith != "Sejuani" {
+		t.Fatalf("expected the swap right after turn 11, got %+v", swap)
+	}
+	if swap.Board.Blue.Picks[0].Role != "Jungle" {
+		t.Errorf("expected the swap to trade roles on the board, got %+v", swap.Board.Blue.Picks)
+	}
+	if timeline[12].Board.Blue.Picks[0].Role != "Bot" {
+		t.Errorf("expected pick-time roles before the swap, got %+v", timeline[12].Board.Blue.Picks)
+	}
+
+	last := timeline[len(timeline)-1]
+	if last.Step != 22 || !last.Board.Complete || *last.Elapsed != 400000 {
+		t.Errorf("expected the last step to complete the draft after 400s, got %+v", last)
+	}
+}
+
+func TestTimeline_Untimed(t *testing.T) {
+	d := swappedDraft(t)
+
+	timeline := d.Timeline()
+	if len(timeline) != 22 {
+		t.Fatalf("expected start, 20 actions and a swap, got %d entries", len(timeline))
+	}
+	for _, entry := range timeline {
+		if entry.At != nil || entry.Elapsed != nil || entry.TurnTime != nil {
+			t.Errorf("expected no times for an untimed draft, got %+v", entry)
+		}
+	}
+	if timeline[21].Kind != TimelineSwap {
+		t.Errorf("expected an untimed swap at the end, got %+v", timeline[21])
+	}
+}
+
+func TestHoverChampion_WrongSide(t *testing.T) {
+	d := New("T1", "Gen.G")
+	if err := d.HoverChampion(Red, "Azir", time.Now()); err == nil {
+		t.Error("expected an error hovering out of turn")
+	}
+}
+
+func TestHoverChampion_Capped(t *testing.T) {
+	d := New("T1", "Gen.G")
+	for i := 0; i < MaxHoversPerTurn; i++ {
+		if err := d.HoverChampion(Blue, "Azir", time.Now()); err != nil {
+			t.Fatalf("hover %d: expected no error, got %v", i+1, err)
+		}
+	}
+	if err := d.HoverChampion(Blue, "Azir", time.Now()); !errors.Is(err, ErrTooManyHovers) {
+		t.Errorf("expected ErrTooManyHovers, got %v", err)
+	}
+
+	if err := d.Apply(Action{Side: Blue, Type: Ban, Champion: "Azir"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := d.HoverChampion(Red, "Ahri", time.Now()); err != nil {
+		t.Errorf("expected the next turn to accept hovers, got %v", err)
+	}
+}
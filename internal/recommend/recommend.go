@@ -0,0 +1,350 @@
+package recommend
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/analytics"
+	"github.com/gvieiragoulart/draft-visualizer/internal/draft"
+)
+
+// DefaultLimit is how many candidates Recommend returns when no limit is set
+const DefaultLimit = 10
+
+// winRatePrior smooths win rates toward 50% as if every champion had this
+// many extra games split evenly
+const winRatePrior = 10
+
+var ErrDraftComplete = errors.New("draft is complete")
+
+// Weights sets how much each component contributes to a candidate's score
+type Weights struct {
+	Meta     float64 `json:"meta"`
+	Synergy  float64 `json:"synergy"`
+	Counter  float64 `json:"counter"`
+	RoleNeed float64 `json:"roleNeed"`
+	Comfort  float64 `json:"comfort"`
+}
+
+// DefaultWeights favours meta strength and filling an open role, with
+// synergy, counter value and comfort as tie breakers
+var DefaultWeights = Weights{
+	Meta:     0.35,
+	Synergy:  0.2,
+	Counter:  0.2,
+	RoleNeed: 0.15,
+	Comfort:  0.1,
+}
+
+// Breakdown is each component of a score before weighting. Meta, RoleNeed
+// and Comfort range from 0 to 1; Synergy and Counter are win-rate lifts
+// between -1 and 1.
+type Breakdown struct {
+	Meta     float64 `json:"meta"`
+	Synergy  float64 `json:"synergy"`
+	Counter  float64 `json:"counter"`
+	RoleNeed float64 `json:"roleNeed"`
+	Comfort  float64 `json:"comfort"`
+}
+
+// Recommendation is one ranked candidate for the next action
+type Recommendation struct {
+	Champion  string    `json:"champion"`
+	Role      string    `json:"role,omitempty"`
+	Score     float64   `json:"score"`
+	Breakdown Breakdown `json:"breakdown"`
+	Reasons   []string  `json:"reasons"`
+}
+
+// Result ranks candidates for the next turn of a draft. For a ban, the
+// candidates are scored as picks for the opponent, so the top entries are
+// the champions most worth denying.
+type Result struct {
+	Turn            draft.Turn       `json:"turn"`
+	ScoredFor       draft.Side       `json:"scoredFor"`
+	Games           int              `json:"games"`
+	Recommendations []Recommendation `json:"recommendations"`
+}
+
+// Options tune a single recommendation
+type Options struct {
+	// Pools lists, per side and role, the champions that side's player is
+	// comfortable on
+	Pools map[draft.Side]map[string][]string
+	Limit int
+}
+
+// Model holds the statistics candidates are scored with
+type Model struct {
+	weights    Weights
+	games      int
+	stats      map[string]analytics.ChampionStats
+	synergy    map[[2]string]analytics.PairStats
+	counters   map[[3]string]analytics.PairStats
+	roles      map[string]map[string]int
+	candidates []string
+}
+
+// NewModel builds a model from historical games. Pairs seen in fewer than
+// minGames games do not contribute synergy or counter value.
+func NewModel(games []analytics.Game, minGames int) *Model {
+	m := &Model{
+		weights:  DefaultWeights,
+		games:    len(games),
+		stats:    map[string]analytics.ChampionStats{},
+		synergy:  map[[2]string]analytics.PairStats{},
+		counters: map[[3]string]analytics.PairStats{},
+		roles:    map[string]map[string]int{},
+	}
+
+	for _, stats := range analytics.ChampionStatistics(games).Champions {
+		m.stats[key(stats.Champion)] = stats
+		m.candidates = append(m.candidates, stats.Champion)
+	}
+	for _, pair := range analytics.SynergyMatrix(games, minGames) {
+		m.synergy[[2]string{key(pair.Champion), key(pair.Other)}] = pair
+	}
+	for _, pair := range analytics.CounterMatrix(games, minGames) {
+		m.counters[[3]string{key(pair.Champion), key(pair.Other), pair.Role}] = pair
+	}
+
+	for _, game := range games {
+		for _, selections := range []draft.Selections{game.Blue, game.Red} {
+			for i, champion := range selections.Picks {
+				if i >= len(selections.Roles) {
+					break
+				}
+				role := analytics.NormalizeRole(selections.Roles[i])
+				if role == "" {
+					continue
+				}
+				if m.roles[key(champion)] == nil {
+					m.roles[key(champion)] = map[string]int{}
+				}
+				m.roles[key(champion)][role]++
+			}
+		}
+	}
+
+	return m
+}
+
+// SetWeights replaces the default component weights
+func (m *Model) SetWeights(weights Weights) {
+	m.weights = weights
+}
+
+// SetCandidates replaces the champions considered, such as the full
+// champion catalogue instead of only the champions seen in the games
+func (m *Model) SetCandidates(champions []string) {
+	m.candidates = append([]string{}, champions...)
+}
+
+// Recommend ranks the candidates for the next turn of d
+func (m *Model) Recommend(d *draft.Draft, opts Options) (*Result, error) {
+	turn, ok := d.NextTurn()
+	if !ok {
+		return nil, ErrDraftComplete
+	}
+
+	scoredFor := turn.Side
+	if turn.Type == draft.Ban {
+		scoredFor = turn.Side.Opponent()
+	}
+
+	own := m.picksByRole(d, scoredFor)
+	enemy := m.picksByRole(d, scoredFor.Opponent())
+	open := []string{}
+	for _, role := range analytics.Roles {
+		if _, filled := own[role]; !filled {
+			open = append(open, role)
+		}
+	}
+
+	recommendations := []Recommendation{}
+	for _, champion := range m.candidates {
+		if d.Contains(champion) || containsKey(d.Locked[scoredFor], champion) || containsKey(d.Disabled, champion) {
+			continue
+		}
+		recommendations = append(recommendations, m.score(champion, own, enemy, open, opts.Pools[scoredFor]))
+	}
+
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		if recommendations[i].Score != recommendations[j].Score {
+			return recommendations[i].Score > recommendations[j].Score
+		}
+		return recommendations[i].Champion < recommendations[j].Champion
+	})
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if len(recommendations) > limit {
+		recommendations = recommendations[:limit]
+	}
+
+	return &Result{
+		Turn:            turn,
+		ScoredFor:       scoredFor,
+		Games:           m.games,
+		Recommendations: recommendations,
+	}, nil
+}
+
+func (m *Model) score(champion string, own, enemy map[string]string, open []string, pools map[string][]string) Recommendation {
+	rec := Recommendation{Champion: champion, Reasons: []string{}}
+
+	// Meta: presence and smoothed win rate
+	if stats, ok := m.stats[key(champion)]; ok {
+		winRate := (float64(stats.Wins) + winRatePrior/2) / (float64(stats.Decided) + winRatePrior)
+		rec.Breakdown.Meta = (stats.PresenceRate + winRate) / 2
+		rec.Reasons = append(rec.Reasons, fmt.Sprintf("%.0f%% presence and %.0f%% win rate over %d picks",
+			stats.PresenceRate*100, stats.WinRate*100, stats.Picks))
+	}
+
+	// Role need: the open role this champion plays most
+	var roleShare float64
+	total := 0
+	for _, count := range m.roles[key(champion)] {
+		total += count
+	}
+	for _, role := range open {
+		if total == 0 {
+			break
+		}
+		share := float64(m.roles[key(champion)][role]) / float64(total)
+		if share > roleShare {
+			roleShare = share
+			rec.Role = role
+		}
+	}
+	rec.Breakdown.RoleNeed = roleShare
+	if rec.Role != "" {
+		rec.Reasons = append(rec.Reasons, fmt.Sprintf("fills %s (%.0f%% of its games)", rec.Role, roleShare*100))
+	}
+
+	// Synergy: average lift with the picks already on the team
+	var lifts []float64
+	var best *analytics.PairStats
+	for _, ally := range own {
+		pair, ok := m.synergy[[2]string{key(champion), key(ally)}]
+		if !ok {
+			continue
+		}
+		lifts = append(lifts, pair.Lift)
+		if best == nil || pair.Lift > best.Lift {
+			p := pair
+			best = &p
+		}
+	}
+	rec.Breakdown.Synergy = mean(lifts)
+	if best != nil && best.Lift > 0 {
+		rec.Reasons = append(rec.Reasons, fmt.Sprintf("pairs with %s (%+.0f%% win rate over %d games)",
+			best.Other, best.Lift*100, best.Games))
+	}
+
+	// Counter: lift against the enemy pick in the same role
+	if opponent, ok := enemy[rec.Role]; ok && rec.Role != "" {
+		if pair, ok := m.counters[[3]string{key(champion), key(opponent), rec.Role}]; ok {
+			rec.Breakdown.Counter = pair.Lift
+			rec.Reasons = append(rec.Reasons, fmt.Sprintf("into %s %s: %.0f%% win rate over %d games",
+				opponent, rec.Role, pair.WinRate*100, pair.Games))
+		}
+	}
+
+	// Comfort: in the pool of the player who would play it
+	if rec.Role != "" && containsKey(pools[rec.Role], champion) {
+		rec.Breakdown.Comfort = 1
+		rec.Reasons = append(rec.Reasons, fmt.Sprintf("in the %s player's pool", rec.Role))
+	} else {
+		for role, pool := range pools {
+			if containsKey(pool, champion) {
+				rec.Breakdown.Comfort = 0.5
+				rec.Reasons = append(rec.Reasons, fmt.Sprintf("in the %s player's pool", role))
+				break
+			}
+		}
+	}
+
+	w := m.weights
+	rec.Score = w.Meta*rec.Breakdown.Meta +
+		w.Synergy*clamp(rec.Breakdown.Synergy) +
+		w.Counter*clamp(rec.Breakdown.Counter) +
+		w.RoleNeed*rec.Breakdown.RoleNeed +
+		w.Comfort*rec.Breakdown.Comfort
+	return rec
+}
+
+// picksByRole maps each role a side has filled to its champion. Picks
+// without an explicit role are placed in the champion's most played open
+// role.
+func (m *Model) picksByRole(d *draft.Draft, side draft.Side) map[string]string {
+	byRole := map[string]string{}
+	unassigned := []string{}
+	for _, action := range d.Actions {
+		if action.Side != side || action.Type != draft.Pick {
+			continue
+		}
+		if role := analytics.NormalizeRole(action.Role); role != "" {
+			byRole[role] = action.Champion
+			continue
+		}
+		unassigned = append(unassigned, action.Champion)
+	}
+
+	for _, champion := range unassigned {
+		bestRole, bestCount := "", 0
+		for role, count := range m.roles[key(champion)] {
+			if _, taken := byRole[role]; taken {
+				continue
+			}
+			if count > bestCount || (count == bestCount && role < bestRole) {
+				bestRole, bestCount = role, count
+			}
+		}
+		if bestRole != "" {
+			byRole[bestRole] = champion
+		}
+	}
+	return byRole
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+func clamp(v float64) float64 {
+	return math.Max(-1, math.Min(1, v))
+}
+
+func containsKey(champions []string, champion string) bool {
+	for _, c := range champions {
+		if key(c) == key(champion) {
+			return true
+		}
+	}
+	return false
+}
+
+// key makes champion names comparable regardless of case and punctuation
+func key(champion string) string {
+	var b strings.Builder
+	for _, r := range champion {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return b.String()
+}
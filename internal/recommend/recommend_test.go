@@ -0,0 +1,146 @@
+package recommend
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/analytics"
+	"github.com/gvieiragoulart/draft-visualizer/internal/draft"
+)
+
+var roles = []string{"Top", "Jungle", "Mid", "Bot", "Support"}
+
+func game(winner draft.Side, blue, red []string) analytics.Game {
+	return analytics.Game{
+		Winner: winner,
+		Blue:   draft.Selections{Picks: blue, Roles: roles},
+		Red:    draft.Selections{Picks: red, Roles: roles},
+	}
+}
+
+func testModel() *Model {
+	games := []analytics.Game{
+		game(draft.Blue, []string{"Jax", "Vi", "Azir", "Kalista", "Rell"}, []string{"Aatrox", "Sejuani", "Ahri", "Varus", "Nautilus"}),
+		game(draft.Blue, []string{"Jax", "Vi", "Ahri", "Varus", "Rell"}, []string{"Aatrox", "Maokai", "Azir", "Kalista", "Nautilus"}),
+		game(draft.Red, []string{"Aatrox", "Sejuani", "Azir", "Varus", "Nautilus"}, []string{"Jax", "Vi", "Ahri", "Kalista", "Rell"}),
+		game(draft.Blue, []string{"Jax", "Maokai", "Azir", "Kalista", "Rell"}, []string{"Aatrox", "Vi", "Ahri", "Varus", "Nautilus"}),
+	}
+	return NewModel(games, 1)
+}
+
+func TestRecommend_FirstPick(t *testing.T) {
+	d := draft.New("T1", "Gen.G")
+	for i := 0; i < 6; i++ {
+		turn, _ := d.NextTurn()
+		if err := d.Apply(draft.Action{Side: turn.Side, Type: draft.Ban}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	result, err := testModel().Recommend(d, Options{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if result.ScoredFor != draft.Blue || result.Turn.Type != draft.Pick {
+		t.Errorf("expected a blue pick, got %+v scored for %s", result.Turn, result.ScoredFor)
+	}
+	if result.Recommendations[0].Champion != "Jax" {
+		t.Errorf("expected Jax to be recommended first, got %s", result.Recommendations[0].Champion)
+	}
+	if result.Recommendations[0].Role != "top" {
+		t.Errorf("expected Jax to fill top, got %s", result.Recommendations[0].Role)
+	}
+	if len(result.Recommendations[0].Reasons) == 0 {
+		t.Error("expected the recommendation to be explained")
+	}
+}
+
+func TestRecommend_Ban(t *testing.T) {
+	result, err := testModel().Recommend(draft.New("T1", "Gen.G"), Options{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if result.Turn.Type != draft.Ban || result.ScoredFor != draft.Red {
+		t.Errorf("expected blue ban scored as red picks, got %+v scored for %s", result.Turn, result.ScoredFor)
+	}
+}
+
+func TestRecommend_SkipsUnavailable(t *testing.T) {
+	d := draft.New("T1", "Gen.G")
+	d.Locked = map[draft.Side][]string{draft.Blue: {"Vi"}}
+	bans := []string{"Jax", "Azir", "Rell", "", "", ""}
+	for _, champion := range bans {
+		turn, _ := d.NextTurn()
+		if err := d.Apply(draft.Action{Side: turn.Side, Type: draft.Ban, Champion: champion}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	result, err := testModel().Recommend(d, Options{Limit: 50})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, rec := range result.Recommendations {
+		switch rec.Champion {
+		case "Jax", "Azir", "Rell", "Vi":
+			t.Errorf("expected %s to be unavailable", rec.Champion)
+		}
+	}
+}
+
+func TestRecommend_RoleNeedAndComfort(t *testing.T) {
+	d := draft.New("T1", "Gen.G")
+	actions := []draft.Action{
+		{Side: draft.Blue, Type: draft.Ban}, {Side: draft.Red, Type: draft.Ban},
+		{Side: draft.Blue, Type: draft.Ban}, {Side: draft.Red, Type: draft.Ban},
+		{Side: draft.Blue, Type: draft.Ban}, {Side: draft.Red, Type: draft.Ban},
+		{Side: draft.Blue, Type: draft.Pick, Champion: "Jax", Role: "Top"},
+		{Side: draft.Red, Type: draft.Pick, Champion: "Aatrox"},
+		{Side: draft.Red, Type: draft.Pick, Champion: "Sejuani"},
+	}
+	for _, action := range actions {
+		if err := d.Apply(action); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	pools := map[draft.Side]map[string][]string{
+		draft.Blue: {"mid": {"Ahri"}},
+	}
+	result, err := testModel().Recommend(d, Options{Pools: pools, Limit: 50})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for _, rec := range result.Recommendations {
+		if rec.Role == "top" {
+			t.Errorf("expected top to be filled, got %s recommended for top", rec.Champion)
+		}
+		if rec.Champion == "Ahri" && rec.Breakdown.Comfort != 1 {
+			t.Errorf("expected Ahri to be a comfort pick, got %+v", rec.Breakdown)
+		}
+		if rec.Champion == "Vi" && rec.Breakdown.Synergy <= 0 {
+			t.Errorf("expected Vi to have positive synergy with Jax, got %+v", rec.Breakdown)
+		}
+	}
+}
+
+func TestRecommend_Complete(t *testing.T) {
+	d := draft.New("T1", "Gen.G")
+	champions := []string{
+		"A1", "A2", "A3", "A4", "A5", "A6", "A7", "A8", "A9", "A10",
+		"A11", "A12", "A13", "A14", "A15", "A16", "A17", "A18", "A19", "A20",
+	}
+	for _, champion := range champions {
+		turn, _ := d.NextTurn()
+		if err := d.Apply(draft.Action{Side: turn.Side, Type: turn.Type, Champion: champion}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	if _, err := testModel().Recommend(d, Options{}); !errors.Is(err, ErrDraftComplete) {
+		t.Errorf("expected ErrDraftComplete, got %v", err)
+	}
+}
@@ -0,0 +1,395 @@
+// Package render draws a draft board as a shareable image. SVG output links
+// team logos and champion icons by URL, PNG output is rasterized in pure Go
+// with whatever artwork was fetched and falls back to plain text.
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"sync"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/draft"
+)
+
+const (
+	Width  = 1280
+	Height = 720
+
+	// slots is how many bans and picks are laid out per side, the tournament
+	// draft's count; shorter formats leave slots empty
+	slots = 5
+
+	margin     = 40
+	headerH    = 120
+	logoSize   = 72
+	banSize    = 48
+	banGap     = 12
+	pickH      = 72
+	pickIcon   = 60
+	columnW    = 520
+	columnTop  = headerH + 20
+	banTop     = columnTop + logoSize + 24
+	pickTop    = banTop + banSize + 24
+	fontFamily = "Go, Helvetica, Arial, sans-serif"
+)
+
+var (
+	background = color.RGBA{0x0f, 0x19, 0x23, 0xff}
+	panel      = color.RGBA{0x1b, 0x26, 0x33, 0xff}
+	blueColor  = color.RGBA{0x2f, 0x80, 0xed, 0xff}
+	redColor   = color.RGBA{0xe8, 0x4a, 0x5f, 0xff}
+	textColor  = color.RGBA{0xf0, 0xf0, 0xf0, 0xff}
+	mutedColor = color.RGBA{0x8a, 0x96, 0xa3, 0xff}
+	emptyColor = color.RGBA{0x2a, 0x36, 0x44, 0xff}
+)
+
+// Team is one side's name and logo. Logo is only used for PNG output and
+// LogoURL only for SVG output.
+type Team struct {
+	Name    string
+	LogoURL string
+	Logo    image.Image
+}
+
+// Art is a champion's square icon
+type Art struct {
+	URL   string
+	Image image.Image
+}
+
+// Card is everything drawn on a draft image
+type Card struct {
+	Board      draft.Board
+	Title      string
+	Patch      string
+	GameNumber int
+	Blue       Team
+	Red        Team
+	Icons      map[string]Art
+}
+
+// subtitle is the line under the title, such as "Game 2 · Patch 14.24"
+func (c Card) subtitle() string {
+	parts := []string{}
+	if c.GameNumber > 0 {
+		parts = append(parts, fmt.Sprintf("Game %d", c.GameNumber))
+	}
+	if c.Patch != "" {
+		parts = append(parts, "Patch "+c.Patch)
+	}
+	if !c.Board.Complete {
+		parts = append(parts, "In progress")
+	}
+	return strings.Join(parts, " · ")
+}
+
+func (c Card) title() string {
+	if c.Title != "" {
+		return c.Title
+	}
+	return fmt.Sprintf("%s vs %s", teamName(c.Blue, c.Board.Blue), teamName(c.Red, c.Board.Red))
+}
+
+func teamName(team Team, board draft.SideBoard) string {
+	if team.Name != "" {
+		return team.Name
+	}
+	if board.Team != "" {
+		return board.Team
+	}
+	return "TBD"
+}
+
+// column is where one side is drawn; the red column is mirrored so picks
+// read from the outside in
+type column struct {
+	side   draft.Side
+	x      int
+	accent color.RGBA
+	team   Team
+	board  draft.SideBoard
+}
+
+func (c Card) columns() [2]column {
+	return [2]column{
+		{side: draft.Blue, x: margin, accent: blueColor, team: c.Blue, board: c.Board.Blue},
+		{side: draft.Red, x: Width - margin - columnW, accent: redColor, team: c.Red, board: c.Board.Red},
+	}
+}
+
+// banX is the left edge of ban slot i
+func (col column) banX(i int) int {
+	if col.side == draft.Red {
+		return col.x + columnW - 16 - (i+1)*banSize - i*banGap
+	}
+	return col.x + 16 + i*(banSize+banGap)
+}
+
+// SVG renders the card as a standalone SVG document
+func SVG(c Card) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="%s">`+"\n",
+		Width, Height, Width, Height, fontFamily)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="%s"/>`+"\n", Width, Height, hex(background))
+	fmt.Fprintf(&b, `<text x="%d" y="56" text-anchor="middle" font-size="34" font-weight="bold" fill="%s">%s</text>`+"\n",
+		Width/2, hex(textColor), html.EscapeString(c.title()))
+	if subtitle := c.subtitle(); subtitle != "" {
+		fmt.Fprintf(&b, `<text x="%d" y="92" text-anchor="middle" font-size="20" fill="%s">%s</text>`+"\n",
+			Width/2, hex(mutedColor), html.EscapeString(subtitle))
+	}
+
+	for _, col := range c.columns() {
+		fmt.Fprintf(&b, `<g id="%s">`+"\n", col.side)
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" rx="12" fill="%s"/>`+"\n",
+			col.x, columnTop, columnW, Height-columnTop-margin, hex(panel))
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="6" rx="3" fill="%s"/>`+"\n",
+			col.x, columnTop, columnW, hex(col.accent))
+
+		logoX, nameX, anchor := col.x+16, col.x+16+logoSize+16, "start"
+		if col.side == draft.Red {
+			logoX, nameX, anchor = col.x+columnW-16-logoSize, col.x+columnW-16-logoSize-16, "end"
+		}
+		if col.team.LogoURL != "" {
+			fmt.Fprintf(&b, `<image x="%d" y="%d" width="%d" height="%d" href="%s"/>`+"\n",
+				logoX, columnTop+16, logoSize, logoSize, html.EscapeString(col.team.LogoURL))
+		}
+		fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="%s" font-size="30" font-weight="bold" fill="%s">%s</text>`+"\n",
+			nameX, columnTop+16+logoSize/2+10, anchor, hex(textColor), html.EscapeString(teamName(col.team, col.board)))
+
+		for i := 0; i < slots; i++ {
+			x := col.banX(i)
+			fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" rx="6" fill="%s"/>`+"\n",
+				x, banTop, banSize, banSize, hex(emptyColor))
+			if i >= len(col.board.Bans) {
+				continue
+			}
+			champion := col.board.Bans[i]
+			if art, ok := c.Icons[champion]; ok && art.URL != "" {
+				fmt.Fprintf(&b, `<image x="%d" y="%d" width="%d" height="%d" href="%s" opacity="0.55"><title>%s</title></image>`+"\n",
+					x, banTop, banSize, banSize, html.EscapeString(art.URL), html.EscapeString(champion))
+			} else if champion != draft.NoBan {
+				fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="middle" font-size="11" fill="%s">%s</text>`+"\n",
+					x+banSize/2, banTop+banSize/2+4, hex(mutedColor), html.EscapeString(champion))
+			}
+			fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s" stroke-width="3"/>`+"\n",
+				x+6, banTop+banSize-6, x+banSize-6, banTop+6, hex(col.accent))
+		}
+
+		for i := 0; i < slots; i++ {
+			y := pickTop + i*pickH
+			iconX, labelX := col.x+16, col.x+16+pickIcon+20
+			if col.side == draft.Red {
+				iconX, labelX = col.x+columnW-16-pickIcon, col.x+columnW-16-pickIcon-20
+			}
+			fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" rx="8" fill="%s"/>`+"\n",
+				iconX, y, pickIcon, pickIcon, hex(emptyColor))
+			if i >= len(col.board.Picks) {
+				continue
+			}
+			pick := col.board.Picks[i]
+			if art, ok := c.Icons[pick.Champion]; ok && art.URL != "" {
+				fmt.Fprintf(&b, `<image x="%d" y="%d" width="%d" height="%d" href="%s"/>`+"\n",
+					iconX, y, pickIcon, pickIcon, html.EscapeString(art.URL))
+			}
+			fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="%s" font-size="26" font-weight="bold" fill="%s">%s</text>`+"\n",
+				labelX, y+28, anchor, hex(textColor), html.EscapeString(pick.Champion))
+			if pick.Role != "" {
+				fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="%s" font-size="16" fill="%s">%s</text>`+"\n",
+					labelX, y+52, anchor, hex(mutedColor), html.EscapeString(pick.Role))
+			}
+		}
+		b.WriteString("</g>\n")
+	}
+
+	b.WriteString("</svg>\n")
+	return b.Bytes()
+}
+
+// PNG rasterizes the card. Missing logos and icons are drawn as empty slots
+// with the name as text.
+func PNG(c Card) ([]byte, error) {
+	faces, err := loadFaces()
+	if err != nil {
+		return nil, err
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, Width, Height))
+	fill(img, img.Bounds(), background)
+
+	drawText(img, faces.title, c.title(), Width/2, 56, textColor, alignCenter)
+	if subtitle := c.subtitle(); subtitle != "" {
+		drawText(img, faces.body, subtitle, Width/2, 92, mutedColor, alignCenter)
+	}
+
+	for _, col := range c.columns() {
+		fill(img, image.Rect(col.x, columnTop, col.x+columnW, Height-margin), panel)
+		fill(img, image.Rect(col.x, columnTop, col.x+columnW, columnTop+6), col.accent)
+
+		logoX, nameX, align := col.x+16, col.x+16+logoSize+16, alignLeft
+		if col.side == draft.Red {
+			logoX, nameX, align = col.x+columnW-16-logoSize, col.x+columnW-16-logoSize-16, alignRight
+		}
+		if col.team.Logo != nil {
+			scale(img, image.Rect(logoX, columnTop+16, logoX+logoSize, columnTop+16+logoSize), col.team.Logo)
+		}
+		drawText(img, faces.heading, teamName(col.team, col.board), nameX, columnTop+16+logoSize/2+10, textColor, align)
+
+		for i := 0; i < slots; i++ {
+			x := col.banX(i)
+			slot := image.Rect(x, banTop, x+banSize, banTop+banSize)
+			fill(img, slot, emptyColor)
+			if i >= len(col.board.Bans) {
+				continue
+			}
+			champion := col.board.Bans[i]
+			if art, ok := c.Icons[champion]; ok && art.Image != nil {
+				scale(img, slot, art.Image)
+				dim(img, slot)
+			} else if champion != draft.NoBan {
+				drawText(img, faces.small, champion, x+banSize/2, banTop+banSize/2+4, mutedColor, alignCenter)
+			}
+			strike(img, slot, col.accent)
+		}
+
+		for i := 0; i < slots; i++ {
+			y := pickTop + i*pickH
+			iconX, labelX := col.x+16, col.x+16+pickIcon+20
+			if col.side == draft.Red {
+				iconX, labelX = col.x+columnW-16-pickIcon, col.x+columnW-16-pickIcon-20
+			}
+			slot := image.Rect(iconX, y, iconX+pickIcon, y+pickIcon)
+			fill(img, slot, emptyColor)
+			if i >= len(col.board.Picks) {
+				continue
+			}
+			pick := col.board.Picks[i]
+			if art, ok := c.Icons[pick.Champion]; ok && art.Image != nil {
+				scale(img, slot, art.Image)
+			}
+			drawText(img, faces.pick, pick.Champion, labelX, y+28, textColor, align)
+			if pick.Role != "" {
+				drawText(img, faces.body, pick.Role, labelX, y+52, mutedColor, align)
+			}
+		}
+	}
+
+	var b bytes.Buffer
+	if err := png.Encode(&b, img); err != nil {
+		return nil, fmt.Errorf("error encoding png: %w", err)
+	}
+	return b.Bytes(), nil
+}
+
+type faceSet struct {
+	title, heading, pick, body, small font.Face
+}
+
+var (
+	facesOnce sync.Once
+	faces     faceSet
+	facesErr  error
+)
+
+// loadFaces parses the Go fonts once. Faces cache glyphs and are not safe
+// for concurrent use, so drawing goes through facesMu.
+func loadFaces() (faceSet, error) {
+	facesOnce.Do(func() {
+		regular, err := opentype.Parse(goregular.TTF)
+		if err != nil {
+			facesErr = fmt.Errorf("error parsing font: %w", err)
+			return
+		}
+		bold, err := opentype.Parse(gobold.TTF)
+		if err != nil {
+			facesErr = fmt.Errorf("error parsing font: %w", err)
+			return
+		}
+
+		sizes := []struct {
+			face *font.Face
+			font *opentype.Font
+			size float64
+		}{
+			{&faces.title, bold, 34},
+			{&faces.heading, bold, 30},
+			{&faces.pick, bold, 26},
+			{&faces.body, regular, 18},
+			{&faces.small, regular, 11},
+		}
+		for _, s := range sizes {
+			face, err := opentype.NewFace(s.font, &opentype.FaceOptions{Size: s.size, DPI: 72, Hinting: font.HintingFull})
+			if err != nil {
+				facesErr = fmt.Errorf("error creating font face: %w", err)
+				return
+			}
+			*s.face = face
+		}
+	})
+	return faces, facesErr
+}
+
+var facesMu sync.Mutex
+
+type alignment int
+
+const (
+	alignLeft alignment = iota
+	alignCenter
+	alignRight
+)
+
+// drawText draws s with its baseline at y, anchored at x
+func drawText(img draw.Image, face font.Face, s string, x, y int, c color.Color, align alignment) {
+	facesMu.Lock()
+	defer facesMu.Unlock()
+
+	d := &font.Drawer{Dst: img, Src: image.NewUniform(c), Face: face}
+	width := d.MeasureString(s).Round()
+	switch align {
+	case alignCenter:
+		x -= width / 2
+	case alignRight:
+		x -= width
+	}
+	d.Dot = fixed.P(x, y)
+	d.DrawString(s)
+}
+
+func fill(img draw.Image, r image.Rectangle, c color.Color) {
+	draw.Draw(img, r, image.NewUniform(c), image.Point{}, draw.Src)
+}
+
+func scale(img draw.Image, r image.Rectangle, src image.Image) {
+	draw.CatmullRom.Scale(img, r, src, src.Bounds(), draw.Over, nil)
+}
+
+// dim darkens a banned champion's icon
+func dim(img draw.Image, r image.Rectangle) {
+	draw.Draw(img, r, image.NewUniform(color.RGBA{0, 0, 0, 0x90}), image.Point{}, draw.Over)
+}
+
+// strike draws the diagonal line across a ban slot
+func strike(img *image.RGBA, r image.Rectangle, c color.Color) {
+	size := r.Dx() - 12
+	for i := 0; i <= size; i++ {
+		for w := -1; w <= 1; w++ {
+			img.Set(r.Min.X+6+i+w, r.Max.Y-6-i, c)
+		}
+	}
+}
+
+func hex(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
@@ -0,0 +1,104 @@
+package render
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/draft"
+)
+
+func testCard() Card {
+	d := draft.New("T1", "Gen.G")
+	for _, action := range []draft.Action{
+		{Side: draft.Blue, Type: draft.Ban, Champion: "Azir"},
+		{Side: draft.Red, Type: draft.Ban, Champion: "Kai'Sa"},
+		{Side: draft.Blue, Type: draft.Ban, Champion: draft.NoBan},
+		{Side: draft.Red, Type: draft.Ban, Champion: "Vi"},
+		{Side: draft.Blue, Type: draft.Ban, Champion: "Rell"},
+		{Side: draft.Red, Type: draft.Ban, Champion: "Ahri"},
+		{Side: draft.Blue, Type: draft.Pick, Champion: "Jax", Role: "Top"},
+	} {
+		d.Apply(action)
+	}
+
+	icon := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	for i := range icon.Pix {
+		icon.Pix[i] = 0xff
+	}
+
+	return Card{
+		Board:      d.Board(),
+		Patch:      "14.24",
+		GameNumber: 2,
+		Blue:       Team{Name: "T1", LogoURL: "https://static.lolesports.com/t1.png", Logo: icon},
+		Red:        Team{Name: "Gen.G"},
+		Icons: map[string]Art{
+			"Jax":  {URL: "https://ddragon.leagueoflegends.com/cdn/14.24.1/img/champion/Jax.png", Image: icon},
+			"Azir": {URL: "https://ddragon.leagueoflegends.com/cdn/14.24.1/img/champion/Azir.png"},
+		},
+	}
+}
+
+func TestSVG(t *testing.T) {
+	svg := string(SVG(testCard()))
+
+	for _, want := range []string{
+		"<svg ",
+		"T1 vs Gen.G",
+		"Game 2 · Patch 14.24 · In progress",
+		"https://static.lolesports.com/t1.png",
+		"img/champion/Jax.png",
+		"Kai&#39;Sa",
+		">Jax<",
+		">Top<",
+		"</svg>",
+	} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("expected SVG to contain %q", want)
+		}
+	}
+	if strings.Contains(svg, "Gen.G.png") {
+		t.Error("expected no logo for a team without one")
+	}
+}
+
+func TestSVG_Title(t *testing.T) {
+	card := testCard()
+	card.Title = "LCK Finals <2024>"
+
+	svg := string(SVG(card))
+	if !strings.Contains(svg, "LCK Finals &lt;2024&gt;") {
+		t.Error("expected the escaped title in the SVG")
+	}
+}
+
+func TestPNG(t *testing.T) {
+	data, err := PNG(testCard())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("expected a valid PNG, got %v", err)
+	}
+	if img.Bounds().Dx() != Width || img.Bounds().Dy() != Height {
+		t.Errorf("expected %dx%d, got %v", Width, Height, img.Bounds())
+	}
+
+	// The white test icon is scaled into the first blue pick slot
+	x, y := margin+16+pickIcon/2, pickTop+pickIcon/2
+	if got := color.RGBAModel.Convert(img.At(x, y)).(color.RGBA); got.R < 0xf0 || got.G < 0xf0 || got.B < 0xf0 {
+		t.Errorf("expected the pick icon at %d,%d, got %v", x, y, got)
+	}
+
+	// The red side has no picks yet, so its first slot stays empty
+	x = Width - margin - 16 - pickIcon/2
+	if got := color.RGBAModel.Convert(img.At(x, y)).(color.RGBA); got != emptyColor {
+		t.Errorf("expected an empty slot at %d,%d, got %v", x, y, got)
+	}
+}
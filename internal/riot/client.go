@@ -1,10 +1,14 @@
 package riot
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 )
 
@@ -13,22 +17,25 @@ type HTTPClient interface {
 	Do(req *http.Request) (*http.Response, error)
 }
 
-// Client is the Riot Games API client
+// Client is the Riot Games API client. Requests are routed to the host of
+// the platform or region each endpoint is served from; baseURL is the host
+// of the default region, used when a request names none.
 type Client struct {
 	apiKey     string
 	httpClient HTTPClient
 	baseURL    string
+	region     Region
+	limiter    *RateLimiter
+
+	// routed is false once SetBaseURL pins every request to one host
+	routed bool
 }
 
 // NewClient creates a new Riot Games API client
 func NewClient(apiKey string) *Client {
-	return &Client{
-		apiKey: apiKey,
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
-		baseURL: "https://americas.api.riotgames.com",
-	}
+	return NewClientWithHTTPClient(apiKey, &http.Client{
+		Timeout: 10 * time.Second,
+	})
 }
 
 // NewClientWithHTTPClient creates a new client with a custom HTTP client
@@ -36,16 +43,122 @@ func NewClientWithHTTPClient(apiKey string, httpClient HTTPClient) *Client {
 	return &Client{
 		apiKey:     apiKey,
 		httpClient: httpClient,
-		baseURL:    "https://americas.api.riotgames.com",
+		baseURL:    regionHost(Americas),
+		region:     Americas,
+		limiter:    NewRateLimiter(),
+		routed:     true,
 	}
 }
 
-// SetBaseURL sets the base URL for the client (useful for testing)
+// SetRateLimiter replaces the client's rate limiter, so that clients using
+// the same API key can share one
+func (c *Client) SetRateLimiter(limiter *RateLimiter) {
+	c.limiter = limiter
+}
+
+// SetBaseURL sends every request to url instead of the platform and region
+// hosts (useful for testing)
 func (c *Client) SetBaseURL(url string) {
 	c.baseURL = url
+	c.routed = false
+}
+
+// SetRegion sets the region used by requests that cannot tell which region
+// they belong to, such as match lists by PUUID
+func (c *Client) SetRegion(region Region) {
+	c.region = region
+	if c.routed {
+		c.baseURL = regionHost(region)
+	}
+}
+
+// Region returns the region used by requests that cannot tell which region
+// they belong to
+func (c *Client) Region() Region {
+	return c.region
+}
+
+func regionHost(region Region) string {
+	return fmt.Sprintf("https://%s.api.riotgames.com", region)
+}
+
+// regionURL returns the host serving a routing region
+func (c *Client) regionURL(region Region) string {
+	if !c.routed {
+		return c.baseURL
+	}
+	return regionHost(region)
+}
+
+// platformURL returns the host serving a platform
+func (c *Client) platformURL(platform Platform) string {
+	if !c.routed {
+		return c.baseURL
+	}
+	return fmt.Sprintf("https://%s.api.riotgames.com", platform)
+}
+
+// ErrInvalidRiotID is returned for Riot IDs not written as gameName#tagLine
+var ErrInvalidRiotID = errors.New("riot id must be gameName#tagLine")
+
+// APIError is a non-200 response from the Riot API
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error: status %d, body: %s", e.StatusCode, e.Body)
+}
+
+// IsNotFound reports whether err is a 404 from the Riot API
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// IsRateLimited reports whether err is a 429 from the Riot API that was
+// still rate limited after every retry
+func IsRateLimited(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusTooManyRequests
+}
+
+// Account is a Riot account from account-v1. GameName and TagLine make up
+// the player's Riot ID.
+type Account struct {
+	PUUID    string `json:"puuid"`
+	GameName string `json:"gameName"`
+	TagLine  string `json:"tagLine"`
+}
+
+// RiotID returns the account's Riot ID as gameName#tagLine
+func (a *Account) RiotID() string {
+	return a.GameName + "#" + a.TagLine
+}
+
+// Player is a Riot account together with its League of Legends summoner
+type Player struct {
+	Account  *Account  `json:"account,omitempty"`
+	Summoner *Summoner `json:"summoner"`
+}
+
+// ParseRiotID splits a Riot ID such as "Faker#KR1" into its game name and
+// tag line. Game names may contain '#', so the last one separates the tag.
+func ParseRiotID(riotID string) (string, string, error) {
+	i := strings.LastIndex(riotID, "#")
+	if i < 0 {
+		return "", "", fmt.Errorf("%w: %q", ErrInvalidRiotID, riotID)
+	}
+	gameName, tagLine := strings.TrimSpace(riotID[:i]), strings.TrimSpace(riotID[i+1:])
+	if gameName == "" || tagLine == "" {
+		return "", "", fmt.Errorf("%w: %q", ErrInvalidRiotID, riotID)
+	}
+	return gameName, tagLine, nil
 }
 
-// Summoner represents a summoner from the Riot API
+// Summoner represents a summoner from the Riot API. Summoners looked up by
+// PUUID no longer carry a name; use the account's Riot ID instead.
 type Summoner struct {
 	PUUID         string `json:"puuid"`
 	SummonerName  string `json:"name"`
@@ -53,111 +166,148 @@ type Summoner struct {
 	ProfileIconID int    `json:"profileIconId"`
 }
 
-// Match represents a match from the Riot API
-type Match struct {
-	Metadata MatchMetadata `json:"metadata"`
-	Info     MatchInfo     `json:"info"`
+// GetAccountByRiotID looks up an account by Riot ID through account-v1 in
+// the client's region
+func (c *Client) GetAccountByRiotID(ctx context.Context, gameName, tagLine string) (*Account, error) {
+	return c.getAccount(ctx, c.region, gameName, tagLine)
 }
 
-// MatchMetadata contains match metadata
-type MatchMetadata struct {
-	MatchID      string   `json:"matchId"`
-	Participants []string `json:"participants"`
+func (c *Client) getAccount(ctx context.Context, region Region, gameName, tagLine string) (*Account, error) {
+	endpoint := fmt.Sprintf("%s/riot/account/v1/accounts/by-riot-id/%s/%s", c.regionURL(region), url.PathEscape(gameName), url.PathEscape(tagLine))
+
+	var account Account
+	if err := c.getJSON(ctx, "account-v1.getByRiotId", endpoint, &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
 }
 
-// MatchInfo contains match information
-type MatchInfo struct {
-	GameMode     string `json:"gameMode"`
-	GameDuration int    `json:"gameDuration"`
-	GameCreation int64  `json:"gameCreation"`
+// GetSummonerByPUUID retrieves the summoner of an account on a platform
+func (c *Client) GetSummonerByPUUID(ctx context.Context, platform Platform, puuid string) (*Summoner, error) {
+	endpoint := fmt.Sprintf("%s/lol/summoner/v4/summoners/by-puuid/%s", c.platformURL(platform), url.PathEscape(puuid))
+
+	var summoner Summoner
+	if err := c.getJSON(ctx, "summoner-v4.getByPUUID", endpoint, &summoner); err != nil {
+		return nil, err
+	}
+	return &summoner, nil
 }
 
-// GetSummonerByName retrieves a summoner by name
-func (c *Client) GetSummonerByName(region, summonerName string) (*Summoner, error) {
-	url := fmt.Sprintf("https://%s.api.riotgames.com/lol/summoner/v4/summoners/by-name/%s", region, summonerName)
-	
-	req, err := http.NewRequest("GET", url, nil)
+// GetPlayerByRiotID resolves a Riot ID such as "Faker#KR1" to its account,
+// looked up in the platform's region, and the summoner it plays on platform
+func (c *Client) GetPlayerByRiotID(ctx context.Context, platform Platform, riotID string) (*Player, error) {
+	gameName, tagLine, err := ParseRiotID(riotID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
-	
-	req.Header.Set("X-Riot-Token", c.apiKey)
-	
-	resp, err := c.httpClient.Do(req)
+
+	account, err := c.getAccount(ctx, platform.AccountRegion(), gameName, tagLine)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+		return nil, fmt.Errorf("failed to get account: %w", err)
 	}
-	defer resp.Body.Close()
-	
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error: status %d, body: %s", resp.StatusCode, string(body))
+
+	summoner, err := c.GetSummonerByPUUID(ctx, platform, account.PUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get summoner: %w", err)
 	}
-	
+	return &Player{Account: account, Summoner: summoner}, nil
+}
+
+// GetSummonerByName retrieves a summoner by name.
+//
+// Deprecated: Riot has retired summoner-v4 by-name; use GetPlayerByRiotID.
+func (c *Client) GetSummonerByName(ctx context.Context, platform Platform, summonerName string) (*Summoner, error) {
+	url := fmt.Sprintf("%s/lol/summoner/v4/summoners/by-name/%s", c.platformURL(platform), summonerName)
+
 	var summoner Summoner
-	if err := json.NewDecoder(resp.Body).Decode(&summoner); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := c.getJSON(ctx, "summoner-v4.getBySummonerName", url, &summoner); err != nil {
+		return nil, err
 	}
-	
 	return &summoner, nil
 }
 
-// GetMatchesByPUUID retrieves match IDs for a player by PUUID
-func (c *Client) GetMatchesByPUUID(puuid string, count int) ([]string, error) {
-	url := fmt.Sprintf("%s/lol/match/v5/matches/by-puuid/%s/ids?count=%d", c.baseURL, puuid, count)
-	
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+// GetMatchesByPUUID retrieves up to count match IDs for a player by PUUID
+// from the client's region, most recent first
+func (c *Client) GetMatchesByPUUID(ctx context.Context, puuid string, count int) ([]string, error) {
+	if count <= 0 {
+		count = DefaultMatchCount
 	}
-	
-	req.Header.Set("X-Riot-Token", c.apiKey)
-	
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+	return c.GetMatchesByPUUIDInRegion(ctx, c.region, puuid, MatchFilter{Count: count})
+}
+
+// GetMatchByID retrieves a match by ID from the region its platform prefix
+// belongs to, or from the client's region when the prefix is not known
+func (c *Client) GetMatchByID(ctx context.Context, matchID string) (*Match, error) {
+	var match Match
+	if err := c.getJSON(ctx, "match-v5.getMatch", c.matchURL(matchID), &match); err != nil {
+		return nil, err
 	}
-	defer resp.Body.Close()
-	
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error: status %d, body: %s", resp.StatusCode, string(body))
+	return &match, nil
+}
+
+// GetMatchTimeline retrieves the minute by minute timeline of a match,
+// routed like GetMatchByID
+func (c *Client) GetMatchTimeline(ctx context.Context, matchID string) (*MatchTimeline, error) {
+	var timeline MatchTimeline
+	if err := c.getJSON(ctx, "match-v5.getTimeline", c.matchURL(matchID)+"/timeline", &timeline); err != nil {
+		return nil, err
 	}
-	
-	var matchIDs []string
-	if err := json.NewDecoder(resp.Body).Decode(&matchIDs); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	return &timeline, nil
+}
+
+// matchURL returns the match-v5 URL of a match in the region of its ID
+func (c *Client) matchURL(matchID string) string {
+	region, err := MatchRegion(matchID)
+	if err != nil {
+		region = c.region
 	}
-	
-	return matchIDs, nil
+	return fmt.Sprintf("%s/lol/match/v5/matches/%s", c.regionURL(region), url.PathEscape(matchID))
 }
 
-// GetMatchByID retrieves a match by ID
-func (c *Client) GetMatchByID(matchID string) (*Match, error) {
-	url := fmt.Sprintf("%s/lol/match/v5/matches/%s", c.baseURL, matchID)
-	
-	req, err := http.NewRequest("GET", url, nil)
+// getJSON sends an authenticated GET request and decodes the response into
+// v. method names the endpoint for rate limiting, e.g. "match-v5.getMatch".
+// Requests wait for the rate limiter and are retried when answered with 429;
+// canceling ctx stops the wait and the request.
+func (c *Client) getJSON(ctx context.Context, method, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return fmt.Errorf("failed to create request: %w", err)
 	}
-	
+
 	req.Header.Set("X-Riot-Token", c.apiKey)
-	
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+
+	for attempt := 0; ; attempt++ {
+		if err := c.limiter.Wait(ctx, req.URL.Host, method); err != nil {
+			return err
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("failed to execute request: %w", err)
+		}
+		c.limiter.Update(req.URL.Host, method, resp.Header)
+
+		if resp.StatusCode == http.StatusTooManyRequests && c.limiter.Backoff(req.URL.Host, method, resp.Header, attempt) {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			continue
+		}
+		return decodeResponse(resp, v)
 	}
+}
+
+// decodeResponse decodes a 200 response into v and turns any other status
+// into an APIError
+func decodeResponse(resp *http.Response, v interface{}) error {
 	defer resp.Body.Close()
-	
+
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error: status %d, body: %s", resp.StatusCode, string(body))
+		return &APIError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
-	
-	var match Match
-	if err := json.NewDecoder(resp.Body).Decode(&match); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
 	}
-	
-	return &match, nil
+	return nil
 }
@@ -1,9 +1,12 @@
 package riot
 
 import (
+	"context"
 	"bytes"
+	"errors"
 	"io"
 	"net/http"
+	"strings"
 	"testing"
 )
 
@@ -74,7 +77,7 @@ func TestGetSummonerByName_Success(t *testing.T) {
 	}
 
 	client := NewClientWithHTTPClient("test-api-key", mockClient)
-	summoner, err := client.GetSummonerByName("na1", "TestSummoner")
+	summoner, err := client.GetSummonerByName(context.Background(), "na1", "TestSummoner")
 	
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
@@ -105,7 +108,7 @@ func TestGetSummonerByName_APIError(t *testing.T) {
 	}
 
 	client := NewClientWithHTTPClient("test-api-key", mockClient)
-	_, err := client.GetSummonerByName("na1", "NonExistent")
+	_, err := client.GetSummonerByName(context.Background(), "na1", "NonExistent")
 	
 	if err == nil {
 		t.Fatal("expected error, got nil")
@@ -130,7 +133,7 @@ func TestGetMatchesByPUUID_Success(t *testing.T) {
 	}
 
 	client := NewClientWithHTTPClient("test-api-key", mockClient)
-	matches, err := client.GetMatchesByPUUID("test-puuid", 3)
+	matches, err := client.GetMatchesByPUUID(context.Background(), "test-puuid", 3)
 	
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
@@ -156,7 +159,7 @@ func TestGetMatchesByPUUID_APIError(t *testing.T) {
 	}
 
 	client := NewClientWithHTTPClient("test-api-key", mockClient)
-	_, err := client.GetMatchesByPUUID("invalid-puuid", 3)
+	_, err := client.GetMatchesByPUUID(context.Background(), "invalid-puuid", 3)
 	
 	if err == nil {
 		t.Fatal("expected error, got nil")
@@ -191,7 +194,7 @@ func TestGetMatchByID_Success(t *testing.T) {
 	}
 
 	client := NewClientWithHTTPClient("test-api-key", mockClient)
-	match, err := client.GetMatchByID("NA1_match1")
+	match, err := client.GetMatchByID(context.Background(), "NA1_match1")
 	
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
@@ -219,9 +222,98 @@ func TestGetMatchByID_APIError(t *testing.T) {
 	}
 
 	client := NewClientWithHTTPClient("test-api-key", mockClient)
-	_, err := client.GetMatchByID("invalid-match-id")
+	_, err := client.GetMatchByID(context.Background(), "invalid-match-id")
 	
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
 }
+
+func TestParseRiotID(t *testing.T) {
+	tests := []struct {
+		riotID   string
+		gameName string
+		tagLine  string
+		wantErr  bool
+	}{
+		{riotID: "Faker#KR1", gameName: "Faker", tagLine: "KR1"},
+		{riotID: "Hide on bush#KR1", gameName: "Hide on bush", tagLine: "KR1"},
+		{riotID: "a#b#EUW", gameName: "a#b", tagLine: "EUW"},
+		{riotID: "Faker", wantErr: true},
+		{riotID: "Faker#", wantErr: true},
+		{riotID: "#KR1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.riotID, func(t *testing.T) {
+			gameName, tagLine, err := ParseRiotID(tt.riotID)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidRiotID) {
+					t.Errorf("expected ErrInvalidRiotID, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if gameName != tt.gameName || tagLine != tt.tagLine {
+				t.Errorf("expected %q and %q, got %q and %q", tt.gameName, tt.tagLine, gameName, tagLine)
+			}
+		})
+	}
+}
+
+func TestGetPlayerByRiotID_Success(t *testing.T) {
+	requested := []string{}
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			requested = append(requested, req.URL.String())
+
+			responseBody := `{"puuid": "test-puuid", "gameName": "Hide on bush", "tagLine": "KR1"}`
+			if strings.Contains(req.URL.Path, "/lol/summoner/v4/") {
+				responseBody = `{"puuid": "test-puuid", "summonerLevel": 700, "profileIconId": 6}`
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(responseBody)),
+			}, nil
+		},
+	}
+
+	client := NewClientWithHTTPClient("test-api-key", mockClient)
+	player, err := client.GetPlayerByRiotID(context.Background(), "kr", "Hide on bush#KR1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := []string{
+		"https://asia.api.riotgames.com/riot/account/v1/accounts/by-riot-id/Hide%20on%20bush/KR1",
+		"https://kr.api.riotgames.com/lol/summoner/v4/summoners/by-puuid/test-puuid",
+	}
+	if len(requested) != len(expected) || requested[0] != expected[0] || requested[1] != expected[1] {
+		t.Errorf("expected requests %v, got %v", expected, requested)
+	}
+	if player.Account.RiotID() != "Hide on bush#KR1" {
+		t.Errorf("expected Riot ID 'Hide on bush#KR1', got %s", player.Account.RiotID())
+	}
+	if player.Summoner.SummonerLevel != 700 {
+		t.Errorf("expected SummonerLevel to be 700, got %d", player.Summoner.SummonerLevel)
+	}
+}
+
+func TestGetPlayerByRiotID_NotFound(t *testing.T) {
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Body:       io.NopCloser(bytes.NewBufferString(`{"status":{"message":"Data not found"}}`)),
+			}, nil
+		},
+	}
+
+	client := NewClientWithHTTPClient("test-api-key", mockClient)
+	_, err := client.GetPlayerByRiotID(context.Background(), "na1", "Nobody#NA1")
+	if !IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+package riot
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Ranked queue types used by league-v4
+const (
+	QueueTypeRankedSolo = "RANKED_SOLO_5x5"
+	QueueTypeRankedFlex = "RANKED_FLEX_SR"
+)
+
+// LeagueEntry is a player's standing in one ranked queue
+type LeagueEntry struct {
+	LeagueID     string      `json:"leagueId"`
+	PUUID        string      `json:"puuid"`
+	QueueType    string      `json:"queueType"`
+	Tier         string      `json:"tier"`
+	Rank         string      `json:"rank"`
+	LeaguePoints int         `json:"leaguePoints"`
+	Wins         int         `json:"wins"`
+	Losses       int         `json:"losses"`
+	HotStreak    bool        `json:"hotStreak"`
+	Veteran      bool        `json:"veteran"`
+	FreshBlood   bool        `json:"freshBlood"`
+	Inactive     bool        `json:"inactive"`
+	MiniSeries   *MiniSeries `json:"miniSeries,omitempty"`
+}
+
+// MiniSeries is a promotion series in progress
+type MiniSeries struct {
+	Losses   int    `json:"losses"`
+	Progress string `json:"progress"`
+	Target   int    `json:"target"`
+	Wins     int    `json:"wins"`
+}
+
+// ChampionMastery is a player's mastery of one champion. LastPlayTime is in
+// milliseconds since the epoch.
+type ChampionMastery struct {
+	PUUID                        string `json:"puuid"`
+	ChampionID                   int    `json:"championId"`
+	ChampionLevel                int    `json:"championLevel"`
+	ChampionPoints               int    `json:"championPoints"`
+	ChampionPointsSinceLastLevel int    `json:"championPointsSinceLastLevel"`
+	ChampionPointsUntilNextLevel int    `json:"championPointsUntilNextLevel"`
+	LastPlayTime                 int64  `json:"lastPlayTime"`
+}
+
+// GetLeagueEntries retrieves a player's ranked standing in every queue they
+// are placed in on a platform
+func (c *Client) GetLeagueEntries(ctx context.Context, platform Platform, puuid string) ([]LeagueEntry, error) {
+	endpoint := fmt.Sprintf("%s/lol/league/v4/entries/by-puuid/%s", c.platformURL(platform), url.PathEscape(puuid))
+
+	entries := []LeagueEntry{}
+	if err := c.getJSON(ctx, "league-v4.getLeagueEntriesByPUUID", endpoint, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// GetChampionMasteries retrieves a player's mastery of every champion they
+// have played on a platform, highest points first
+func (c *Client) GetChampionMasteries(ctx context.Context, platform Platform, puuid string) ([]ChampionMastery, error) {
+	endpoint := fmt.Sprintf("%s/lol/champion-mastery/v4/champion-masteries/by-puuid/%s", c.platformURL(platform), url.PathEscape(puuid))
+
+	masteries := []ChampionMastery{}
+	if err := c.getJSON(ctx, "champion-mastery-v4.getAllChampionMasteriesByPUUID", endpoint, &masteries); err != nil {
+		return nil, err
+	}
+	return masteries, nil
+}
+
+// GetTopChampionMasteries retrieves a player's count highest champion
+// masteries on a platform
+func (c *Client) GetTopChampionMasteries(ctx context.Context, platform Platform, puuid string, count int) ([]ChampionMastery, error) {
+	endpoint := fmt.Sprintf("%s/lol/champion-mastery/v4/champion-masteries/by-puuid/%s/top?count=%d", c.platformURL(platform), url.PathEscape(puuid), count)
+
+	masteries := []ChampionMastery{}
+	if err := c.getJSON(ctx, "champion-mastery-v4.getTopChampionMasteriesByPUUID", endpoint, &masteries); err != nil {
+		return nil, err
+	}
+	return masteries, nil
+}
@@ -0,0 +1,95 @@
+package riot
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestGetLeagueEntries(t *testing.T) {
+	var requested string
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			requested = req.URL.String()
+			body := `[{"leagueId": "league-1", "puuid": "test-puuid", "queueType": "RANKED_SOLO_5x5",
+				"tier": "DIAMOND", "rank": "II", "leaguePoints": 64, "wins": 120, "losses": 98, "hotStreak": true}]`
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+
+	client := NewClientWithHTTPClient("test-api-key", mockClient)
+	entries, err := client.GetLeagueEntries(context.Background(), KR, "test-puuid")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := "https://kr.api.riotgames.com/lol/league/v4/entries/by-puuid/test-puuid"
+	if requested != expected {
+		t.Errorf("expected request to %s, got %s", expected, requested)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(entries))
+	}
+	entry := entries[0]
+	if entry.QueueType != QueueTypeRankedSolo || entry.Tier != "DIAMOND" || entry.Rank != "II" ||
+		entry.LeaguePoints != 64 || entry.Wins != 120 || entry.Losses != 98 || !entry.HotStreak {
+		t.Errorf("unexpected entry %+v", entry)
+	}
+}
+
+func TestGetChampionMasteries(t *testing.T) {
+	tests := []struct {
+		name     string
+		get      func(*Client) ([]ChampionMastery, error)
+		expected string
+	}{
+		{
+			name: "all",
+			get: func(c *Client) ([]ChampionMastery, error) {
+				return c.GetChampionMasteries(context.Background(), EUW1, "test-puuid")
+			},
+			expected: "https://euw1.api.riotgames.com/lol/champion-mastery/v4/champion-masteries/by-puuid/test-puuid",
+		},
+		{
+			name: "top",
+			get: func(c *Client) ([]ChampionMastery, error) {
+				return c.GetTopChampionMasteries(context.Background(), EUW1, "test-puuid", 3)
+			},
+			expected: "https://euw1.api.riotgames.com/lol/champion-mastery/v4/champion-masteries/by-puuid/test-puuid/top?count=3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requested string
+			mockClient := &MockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					requested = req.URL.String()
+					body := `[{"puuid": "test-puuid", "championId": 103, "championLevel": 12,
+						"championPoints": 154321, "lastPlayTime": 1716000000000}]`
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(bytes.NewBufferString(body)),
+					}, nil
+				},
+			}
+
+			masteries, err := tt.get(NewClientWithHTTPClient("test-api-key", mockClient))
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if requested != tt.expected {
+				t.Errorf("expected request to %s, got %s", tt.expected, requested)
+			}
+			if len(masteries) != 1 || masteries[0].ChampionID != 103 || masteries[0].ChampionPoints != 154321 ||
+				masteries[0].LastPlayTime != 1716000000000 {
+				t.Errorf("unexpected masteries %+v", masteries)
+			}
+		})
+	}
+}
@@ -0,0 +1,275 @@
+package riot
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/draft"
+)
+
+// Team IDs used by match-v5 for the two sides of the map
+const (
+	TeamBlue = 100
+	TeamRed  = 200
+)
+
+// Match represents a match from the Riot API
+type Match struct {
+	Metadata MatchMetadata `json:"metadata"`
+	Info     MatchInfo     `json:"info"`
+}
+
+// MatchMetadata contains match metadata
+type MatchMetadata struct {
+	DataVersion  string   `json:"dataVersion"`
+	MatchID      string   `json:"matchId"`
+	Participants []string `json:"participants"`
+}
+
+// MatchInfo contains match information. Timestamps are in milliseconds
+// since the epoch and GameDuration is in seconds.
+type MatchInfo struct {
+	GameID             int64  `json:"gameId"`
+	GameMode           string `json:"gameMode"`
+	GameType           string `json:"gameType"`
+	GameName           string `json:"gameName"`
+	GameVersion        string `json:"gameVersion"`
+	GameDuration       int    `json:"gameDuration"`
+	GameCreation       int64  `json:"gameCreation"`
+	GameStartTimestamp int64  `json:"gameStartTimestamp"`
+	GameEndTimestamp   int64  `json:"gameEndTimestamp"`
+	EndOfGameResult    string `json:"endOfGameResult"`
+	MapID              int    `json:"mapId"`
+	PlatformID         string `json:"platformId"`
+	QueueID            int    `json:"queueId"`
+	TournamentCode     string `json:"tournamentCode"`
+
+	Participants []Participant `json:"participants"`
+	Teams        []MatchTeam   `json:"teams"`
+}
+
+// Participant is one player's champion, build and performance in a match
+type Participant struct {
+	ParticipantID  int    `json:"participantId"`
+	PUUID          string `json:"puuid"`
+	RiotIDGameName string `json:"riotIdGameName"`
+	RiotIDTagline  string `json:"riotIdTagline"`
+	SummonerName   string `json:"summonerName"`
+	TeamID         int    `json:"teamId"`
+	Win            bool   `json:"win"`
+
+	ChampionID   int    `json:"championId"`
+	ChampionName string `json:"championName"`
+	ChampLevel   int    `json:"champLevel"`
+
+	// TeamPosition is the position the player was assigned (TOP, JUNGLE,
+	// MIDDLE, BOTTOM, UTILITY); IndividualPosition, Lane and Role are
+	// guessed by the game from where the player went
+	TeamPosition       string `json:"teamPosition"`
+	IndividualPosition string `json:"individualPosition"`
+	Lane               string `json:"lane"`
+	Role               string `json:"role"`
+
+	Kills   int `json:"kills"`
+	Deaths  int `json:"deaths"`
+	Assists int `json:"assists"`
+
+	GoldEarned                  int `json:"goldEarned"`
+	GoldSpent                   int `json:"goldSpent"`
+	TotalDamageDealtToChampions int `json:"totalDamageDealtToChampions"`
+	TotalDamageTaken            int `json:"totalDamageTaken"`
+	TotalMinionsKilled          int `json:"totalMinionsKilled"`
+	NeutralMinionsKilled        int `json:"neutralMinionsKilled"`
+	VisionScore                 int `json:"visionScore"`
+
+	Item0 int `json:"item0"`
+	Item1 int `json:"item1"`
+	Item2 int `json:"item2"`
+	Item3 int `json:"item3"`
+	Item4 int `json:"item4"`
+	Item5 int `json:"item5"`
+	Item6 int `json:"item6"`
+
+	Summoner1ID int   `json:"summoner1Id"`
+	Summoner2ID int   `json:"summoner2Id"`
+	Perks       Perks `json:"perks"`
+}
+
+// Perks are a participant's runes: a primary and a secondary style plus the
+// three stat shards
+type Perks struct {
+	StatPerks StatPerks   `json:"statPerks"`
+	Styles    []PerkStyle `json:"styles"`
+}
+
+// StatPerks are the rune shards
+type StatPerks struct {
+	Defense int `json:"defense"`
+	Flex    int `json:"flex"`
+	Offense int `json:"offense"`
+}
+
+// PerkStyle is a rune tree and the runes taken from it
+type PerkStyle struct {
+	Description string          `json:"description"`
+	Style       int             `json:"style"`
+	Selections  []PerkSelection `json:"selections"`
+}
+
+// PerkSelection is a single rune
+type PerkSelection struct {
+	Perk int `json:"perk"`
+	Var1 int `json:"var1"`
+	Var2 int `json:"var2"`
+	Var3 int `json:"var3"`
+}
+
+// MatchTeam is one side's bans, objectives and result
+type MatchTeam struct {
+	TeamID     int        `json:"teamId"`
+	Win        bool       `json:"win"`
+	Bans       []MatchBan `json:"bans"`
+	Objectives Objectives `json:"objectives"`
+}
+
+// MatchBan is a ban. PickTurn is the pick slot of the player who banned and
+// ChampionID is -1 for a skipped ban.
+type MatchBan struct {
+	ChampionID int `json:"championId"`
+	PickTurn   int `json:"pickTurn"`
+}
+
+// Objectives are the objectives a team took
+type Objectives struct {
+	Atakhan    Objective `json:"atakhan"`
+	Baron      Objective `json:"baron"`
+	Champion   Objective `json:"champion"`
+	Dragon     Objective `json:"dragon"`
+	Horde      Objective `json:"horde"`
+	Inhibitor  Objective `json:"inhibitor"`
+	RiftHerald Objective `json:"riftHerald"`
+	Tower      Objective `json:"tower"`
+}
+
+// Objective is how many of an objective a team took and whether it took
+// the first one
+type Objective struct {
+	First bool `json:"first"`
+	Kills int  `json:"kills"`
+}
+
+// Patch returns the patch the match was played on, e.g. "14.10" for game
+// version "14.10.588.4919"
+func (m *Match) Patch() string {
+	parts := strings.SplitN(m.Info.GameVersion, ".", 3)
+	if len(parts) < 2 {
+		return m.Info.GameVersion
+	}
+	return parts[0] + "." + parts[1]
+}
+
+// Team returns the team with the given ID
+func (m *Match) Team(teamID int) (MatchTeam, bool) {
+	for _, team := range m.Info.Teams {
+		if team.TeamID == teamID {
+			return team, true
+		}
+	}
+	return MatchTeam{}, false
+}
+
+// Items returns the participant's six item slots followed by the trinket
+func (p Participant) Items() []int {
+	return []int{p.Item0, p.Item1, p.Item2, p.Item3, p.Item4, p.Item5, p.Item6}
+}
+
+// KDA returns (kills + assists) / deaths, counting no deaths as one
+func (p Participant) KDA() float64 {
+	deaths := p.Deaths
+	if deaths == 0 {
+		deaths = 1
+	}
+	return float64(p.Kills+p.Assists) / float64(deaths)
+}
+
+// positionRoles maps match-v5 team positions to the role names drafts use
+var positionRoles = map[string]string{
+	"TOP":     "top",
+	"JUNGLE":  "jungle",
+	"MIDDLE":  "mid",
+	"BOTTOM":  "bottom",
+	"UTILITY": "support",
+}
+
+// Position returns the role the participant played, or "" when the game
+// assigned none (e.g. in ARAM)
+func (p Participant) Position() string {
+	return positionRoles[p.TeamPosition]
+}
+
+// PickOrderKnown reports whether participant order is the order the picks
+// were made in, which match-v5 does not record for tournament code games
+func (m *Match) PickOrderKnown() bool {
+	return m.Info.TournamentCode == ""
+}
+
+// ToDraft converts the match's bans and picks into the draft model. Bans
+// are ordered by pick turn and picks by participant. In matchmade queues
+// participants are numbered in lobby order, which is the order each team
+// picked in; tournament code lobbies are free to pick in any order, so
+// their picks are only placed in draft slots (see PickOrderKnown).
+// Tournament code games follow the tournament draft and every other queue
+// the ranked draft.
+//
+// Bans are named by champion key (e.g. "62") since match-v5 does not name
+// them, so the draft should be normalized against the champion catalogue.
+// A champion banned by both teams only counts once, and games played
+// without bans get skipped bans so their picks still show.
+func (m *Match) ToDraft() (*draft.Draft, error) {
+	format := draft.FormatRanked
+	if m.Info.TournamentCode != "" {
+		format = draft.FormatTournament
+	}
+
+	banned := map[int]bool{}
+	selections := func(teamID int) draft.Selections {
+		var s draft.Selections
+
+		team, _ := m.Team(teamID)
+		bans := append([]MatchBan{}, team.Bans...)
+		sort.SliceStable(bans, func(i, j int) bool { return bans[i].PickTurn < bans[j].PickTurn })
+		for _, ban := range bans {
+			champion := draft.NoBan
+			if ban.ChampionID > 0 && !banned[ban.ChampionID] {
+				banned[ban.ChampionID] = true
+				champion = strconv.Itoa(ban.ChampionID)
+			}
+			s.Bans = append(s.Bans, champion)
+		}
+
+		var participants []Participant
+		for _, participant := range m.Info.Participants {
+			if participant.TeamID == teamID {
+				participants = append(participants, participant)
+			}
+		}
+		sort.SliceStable(participants, func(i, j int) bool {
+			return participants[i].ParticipantID < participants[j].ParticipantID
+		})
+		for _, participant := range participants {
+			s.Picks = append(s.Picks, participant.ChampionName)
+			s.Roles = append(s.Roles, participant.Position())
+		}
+
+		for len(s.Bans) < 5 && len(s.Picks) > 0 {
+			s.Bans = append(s.Bans, draft.NoBan)
+		}
+		return s
+	}
+
+	// Blue bans first, so it keeps a champion both teams banned
+	blue := selections(TeamBlue)
+	red := selections(TeamRed)
+	return draft.AssembleFormat(format, "Blue", "Red", blue, red)
+}
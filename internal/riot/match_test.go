@@ -0,0 +1,117 @@
+package riot
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/draft"
+)
+
+// rankedMatchJSON is a trimmed ranked solo queue game in which both teams
+// banned Azir (268) and blue skipped a ban
+const rankedMatchJSON = `{
+	"metadata": {"dataVersion": "2", "matchId": "KR_7012345678", "participants": []},
+	"info": {
+		"gameVersion": "14.10.588.4919",
+		"queueId": 420,
+		"participants": [
+			{"participantId": 1, "teamId": 100, "championName": "KSante", "teamPosition": "TOP", "kills": 2, "deaths": 0, "assists": 7, "item0": 3068, "item6": 3364,
+			 "perks": {"statPerks": {"defense": 5001, "flex": 5008, "offense": 5005}, "styles": [{"description": "primaryStyle", "style": 8400, "selections": [{"perk": 8437}]}]}},
+			{"participantId": 2, "teamId": 100, "championName": "Sejuani", "teamPosition": "JUNGLE"},
+			{"participantId": 3, "teamId": 100, "championName": "Taliyah", "teamPosition": "MIDDLE"},
+			{"participantId": 4, "teamId": 100, "championName": "Ashe", "teamPosition": "BOTTOM"},
+			{"participantId": 5, "teamId": 100, "championName": "Lulu", "teamPosition": "UTILITY"},
+			{"participantId": 6, "teamId": 200, "championName": "Jayce", "teamPosition": "TOP"},
+			{"participantId": 7, "teamId": 200, "championName": "Maokai", "teamPosition": "JUNGLE"},
+			{"participantId": 8, "teamId": 200, "championName": "Corki", "teamPosition": "MIDDLE"},
+			{"participantId": 9, "teamId": 200, "championName": "Xayah", "teamPosition": "BOTTOM"},
+			{"participantId": 10, "teamId": 200, "championName": "Rakan", "teamPosition": "UTILITY"}
+		],
+		"teams": [
+			{"teamId": 200, "win": false, "bans": [
+				{"championId": 268, "pickTurn": 6}, {"championId": 145, "pickTurn": 7}, {"championId": 61, "pickTurn": 8},
+				{"championId": 24, "pickTurn": 9}, {"championId": 223, "pickTurn": 10}
+			], "objectives": {"tower": {"first": false, "kills": 3}}},
+			{"teamId": 100, "win": true, "bans": [
+				{"championId": 103, "pickTurn": 2}, {"championId": 268, "pickTurn": 1}, {"championId": -1, "pickTurn": 3},
+				{"championId": 254, "pickTurn": 4}, {"championId": 111, "pickTurn": 5}
+			], "objectives": {"dragon": {"first": true, "kills": 4}, "tower": {"first": true, "kills": 9}}}
+		]
+	}
+}`
+
+func TestMatch_Decode(t *testing.T) {
+	var match Match
+	if err := json.Unmarshal([]byte(rankedMatchJSON), &match); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if match.Patch() != "14.10" {
+		t.Errorf("expected patch 14.10, got %s", match.Patch())
+	}
+	top := match.Info.Participants[0]
+	if top.Position() != "top" || top.KDA() != 9 || top.Items()[6] != 3364 {
+		t.Errorf("expected a top laner with 9 KDA and a trinket, got %+v", top)
+	}
+	if len(top.Perks.Styles) != 1 || top.Perks.Styles[0].Selections[0].Perk != 8437 {
+		t.Errorf("expected runes to be decoded, got %+v", top.Perks)
+	}
+	blue, ok := match.Team(TeamBlue)
+	if !ok || !blue.Win || !blue.Objectives.Dragon.First || blue.Objectives.Tower.Kills != 9 {
+		t.Errorf("expected blue to win with first dragon and 9 towers, got %+v", blue)
+	}
+}
+
+func TestMatch_ToDraft(t *testing.T) {
+	var match Match
+	if err := json.Unmarshal([]byte(rankedMatchJSON), &match); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	d, err := match.ToDraft()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if d.Format != draft.FormatRanked || !d.Complete() {
+		t.Fatalf("expected a complete ranked draft, got %+v", d)
+	}
+
+	// red's Azir ban was already taken by blue
+	bans := []string{"268", draft.NoBan, "103", "145", draft.NoBan, "61", "254", "24", "111", "223"}
+	for i, champion := range bans {
+		if d.Actions[i].Type != draft.Ban || d.Actions[i].Champion != champion {
+			t.Errorf("ban %d: expected %s, got %+v", i+1, champion, d.Actions[i])
+		}
+	}
+
+	first := d.Actions[10]
+	if first.Side != draft.Blue || first.Champion != "KSante" || first.Role != "top" {
+		t.Errorf("expected blue to first pick K'Sante top, got %+v", first)
+	}
+	last := d.Actions[19]
+	if last.Side != draft.Red || last.Champion != "Rakan" || last.Role != "support" {
+		t.Errorf("expected red to last pick Rakan support, got %+v", last)
+	}
+}
+
+func TestMatch_ToDraft_Tournament(t *testing.T) {
+	var match Match
+	if err := json.Unmarshal([]byte(rankedMatchJSON), &match); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !match.PickOrderKnown() {
+		t.Error("expected the pick order of a ranked game to be known")
+	}
+	match.Info.TournamentCode = "NA04-TOURNAMENT-CODE"
+	if match.PickOrderKnown() {
+		t.Error("expected the pick order of a tournament code game to be unknown")
+	}
+
+	d, err := match.ToDraft()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if d.Format != draft.FormatTournament || d.Actions[6].Type != draft.Pick {
+		t.Errorf("expected picks to start after six bans, got %+v", d.Actions[6])
+	}
+}
@@ -0,0 +1,124 @@
+package riot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// DefaultMatchCount is how many match IDs match-v5 returns when no count is
+// given, and MaxMatchesPerPage the most it returns per call
+const (
+	DefaultMatchCount = 20
+	MaxMatchesPerPage = 100
+)
+
+// Match types accepted by the match-v5 type filter
+const (
+	MatchTypeRanked   = "ranked"
+	MatchTypeNormal   = "normal"
+	MatchTypeTourney  = "tourney"
+	MatchTypeTutorial = "tutorial"
+)
+
+// Queue IDs of the most common queues, for the match-v5 queue filter
+const (
+	QueueNormalDraft = 400
+	QueueRankedSolo  = 420
+	QueueNormalBlind = 430
+	QueueRankedFlex  = 440
+	QueueARAM        = 450
+)
+
+var ErrInvalidMatchFilter = errors.New("invalid match filter")
+
+// MatchFilter narrows down a player's match history. Zero values leave a
+// filter out. Start skips that many of the most recent matches and Count
+// caps how many IDs are returned, every match that passes the filters when
+// it is zero. Queue is a pointer since queue 0 is custom games.
+type MatchFilter struct {
+	Start     int
+	Count     int
+	Queue     *int
+	Type      string
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// Validate checks the filter before any request is made
+func (f MatchFilter) Validate() error {
+	if f.Start < 0 {
+		return fmt.Errorf("%w: start must not be negative", ErrInvalidMatchFilter)
+	}
+	if f.Count < 0 {
+		return fmt.Errorf("%w: count must not be negative", ErrInvalidMatchFilter)
+	}
+	switch f.Type {
+	case "", MatchTypeRanked, MatchTypeNormal, MatchTypeTourney, MatchTypeTutorial:
+	default:
+		return fmt.Errorf("%w: unknown match type %q", ErrInvalidMatchFilter, f.Type)
+	}
+	if !f.StartTime.IsZero() && !f.EndTime.IsZero() && !f.EndTime.After(f.StartTime) {
+		return fmt.Errorf("%w: endTime must be after startTime", ErrInvalidMatchFilter)
+	}
+	return nil
+}
+
+// query returns the filter's parameters for one page of results
+func (f MatchFilter) query(start, count int) url.Values {
+	query := url.Values{}
+	query.Set("start", strconv.Itoa(start))
+	query.Set("count", strconv.Itoa(count))
+	if f.Queue != nil {
+		query.Set("queue", strconv.Itoa(*f.Queue))
+	}
+	if f.Type != "" {
+		query.Set("type", f.Type)
+	}
+	if !f.StartTime.IsZero() {
+		query.Set("startTime", strconv.FormatInt(f.StartTime.Unix(), 10))
+	}
+	if !f.EndTime.IsZero() {
+		query.Set("endTime", strconv.FormatInt(f.EndTime.Unix(), 10))
+	}
+	return query
+}
+
+// GetMatchesByPUUIDInRegion retrieves the IDs of a player's matches that
+// pass filter from a routing region, most recent first. Riot returns at most
+// 100 IDs per call, so larger counts are fetched page by page.
+func (c *Client) GetMatchesByPUUIDInRegion(ctx context.Context, region Region, puuid string, filter MatchFilter) ([]string, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	matchIDs := []string{}
+	start := filter.Start
+	for {
+		size := MaxMatchesPerPage
+		if filter.Count > 0 {
+			remaining := filter.Count - len(matchIDs)
+			if remaining <= 0 {
+				break
+			}
+			size = min(remaining, MaxMatchesPerPage)
+		}
+
+		endpoint := fmt.Sprintf("%s/lol/match/v5/matches/by-puuid/%s/ids?%s", c.regionURL(region), url.PathEscape(puuid), filter.query(start, size).Encode())
+		var page []string
+		if err := c.getJSON(ctx, "match-v5.getMatchIdsByPUUID", endpoint, &page); err != nil {
+			return nil, err
+		}
+		matchIDs = append(matchIDs, page...)
+
+		// a short page is the end of the history
+		if len(page) < size {
+			break
+		}
+		start += len(page)
+	}
+	return matchIDs, nil
+}
@@ -0,0 +1,138 @@
+package riot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// matchHistoryClient serves a history of total match IDs page by page and
+// records the query of every request
+func matchHistoryClient(total int, queries *[]string) *MockHTTPClient {
+	return &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			*queries = append(*queries, req.URL.RawQuery)
+			start, _ := strconv.Atoi(req.URL.Query().Get("start"))
+			count, _ := strconv.Atoi(req.URL.Query().Get("count"))
+
+			page := []string{}
+			for i := start; i < start+count && i < total; i++ {
+				page = append(page, fmt.Sprintf("NA1_%d", i))
+			}
+			body, _ := json.Marshal(page)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBuffer(body)),
+			}, nil
+		},
+	}
+}
+
+func TestGetMatchesByPUUIDInRegion_Filters(t *testing.T) {
+	var queries []string
+	client := NewClientWithHTTPClient("test-api-key", matchHistoryClient(5, &queries))
+
+	queue := QueueRankedSolo
+	filter := MatchFilter{
+		Start:     2,
+		Count:     10,
+		Queue:     &queue,
+		Type:      MatchTypeRanked,
+		StartTime: time.Unix(1715000000, 0),
+		EndTime:   time.Unix(1716000000, 0),
+	}
+	matchIDs, err := client.GetMatchesByPUUIDInRegion(context.Background(), Americas, "test-puuid", filter)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(matchIDs) != 3 || matchIDs[0] != "NA1_2" {
+		t.Errorf("expected the 3 matches after the first 2, got %v", matchIDs)
+	}
+	expected := "count=10&endTime=1716000000&queue=420&start=2&startTime=1715000000&type=ranked"
+	if len(queries) != 1 || queries[0] != expected {
+		t.Errorf("expected a single request with %s, got %v", expected, queries)
+	}
+}
+
+func TestGetMatchesByPUUIDInRegion_Paginates(t *testing.T) {
+	tests := []struct {
+		name     string
+		total    int
+		count    int
+		expected int
+		requests []string
+	}{
+		{
+			name:     "count above one page",
+			total:    500,
+			count:    250,
+			expected: 250,
+			requests: []string{"count=100&start=0", "count=100&start=100", "count=50&start=200"},
+		},
+		{
+			name:     "whole history",
+			total:    230,
+			count:    0,
+			expected: 230,
+			requests: []string{"count=100&start=0", "count=100&start=100", "count=100&start=200"},
+		},
+		{
+			name:     "history ends on a page boundary",
+			total:    200,
+			count:    0,
+			expected: 200,
+			requests: []string{"count=100&start=0", "count=100&start=100", "count=100&start=200"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var queries []string
+			client := NewClientWithHTTPClient("test-api-key", matchHistoryClient(tt.total, &queries))
+
+			matchIDs, err := client.GetMatchesByPUUIDInRegion(context.Background(), Americas, "test-puuid", MatchFilter{Count: tt.count})
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if len(matchIDs) != tt.expected {
+				t.Errorf("expected %d match IDs, got %d", tt.expected, len(matchIDs))
+			}
+			if fmt.Sprint(queries) != fmt.Sprint(tt.requests) {
+				t.Errorf("expected requests %v, got %v", tt.requests, queries)
+			}
+		})
+	}
+}
+
+func TestMatchFilter_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter MatchFilter
+		valid  bool
+	}{
+		{"empty", MatchFilter{}, true},
+		{"negative start", MatchFilter{Start: -1}, false},
+		{"unknown type", MatchFilter{Type: "arena"}, false},
+		{"end before start", MatchFilter{StartTime: time.Unix(200, 0), EndTime: time.Unix(100, 0)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.filter.Validate()
+			if tt.valid && err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+			if !tt.valid && !errors.Is(err, ErrInvalidMatchFilter) {
+				t.Errorf("expected an invalid filter error, got %v", err)
+			}
+		})
+	}
+}
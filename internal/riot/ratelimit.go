@@ -0,0 +1,271 @@
+package riot
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Rate limit headers sent by the Riot API. Limits are written as
+// "count:seconds" pairs, e.g. "20:1,100:120", and the matching -Count
+// headers say how much of each window is already used.
+const (
+	headerAppRateLimit         = "X-App-Rate-Limit"
+	headerAppRateLimitCount    = "X-App-Rate-Limit-Count"
+	headerMethodRateLimit      = "X-Method-Rate-Limit"
+	headerMethodRateLimitCount = "X-Method-Rate-Limit-Count"
+	headerRateLimitType        = "X-Rate-Limit-Type"
+	headerRetryAfter           = "Retry-After"
+)
+
+// DefaultMaxRetries is how many times a request answered with 429 is retried
+const DefaultMaxRetries = 3
+
+// RateLimiter keeps Riot API calls within the application and method rate
+// limits. Limits are learned from response headers, and are tracked per
+// host, since every routing region and platform has its own, and per
+// method. Requests over a limit wait for the window to reset instead of
+// failing. A limiter is safe for concurrent use and may be shared by
+// several clients using the same API key.
+type RateLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+
+	maxRetries int
+
+	// now and sleep are replaced in tests
+	now   func() time.Time
+	sleep func(context.Context, time.Duration) error
+}
+
+// bucket is one set of limits, such as the application limits of a host
+type bucket struct {
+	windows []*window
+
+	// blockedUntil is set when a 429 says to stay away for a while
+	blockedUntil time.Time
+}
+
+// window is a single limit of count requests per length
+type window struct {
+	count  int
+	length time.Duration
+	start  time.Time
+	used   int
+}
+
+// NewRateLimiter creates a rate limiter that knows no limits until the API
+// reports them
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{
+		buckets:    map[string]*bucket{},
+		maxRetries: DefaultMaxRetries,
+		now:        time.Now,
+		sleep:      sleep,
+	}
+}
+
+// SetMaxRetries sets how many times a request answered with 429 is retried
+func (l *RateLimiter) SetMaxRetries(n int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.maxRetries = n
+}
+
+func appKey(host string) string {
+	return "app " + host
+}
+
+func methodKey(host, method string) string {
+	return "method " + host + " " + method
+}
+
+// Wait blocks until a request to method on host fits within every known
+// limit, then counts it against them. It returns ctx's error without
+// counting the request when ctx is done first.
+func (l *RateLimiter) Wait(ctx context.Context, host, method string) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		delay := l.reserve(host, method)
+		if delay <= 0 {
+			return nil
+		}
+		if err := l.sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// reserve counts a request when it fits and otherwise returns how long to
+// wait before trying again
+func (l *RateLimiter) reserve(host, method string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	buckets := []*bucket{l.bucket(appKey(host)), l.bucket(methodKey(host, method))}
+
+	var delay time.Duration
+	for _, b := range buckets {
+		if d := b.delay(now); d > delay {
+			delay = d
+		}
+	}
+	if delay > 0 {
+		return delay
+	}
+
+	for _, b := range buckets {
+		for _, w := range b.windows {
+			w.used++
+		}
+	}
+	return 0
+}
+
+// Update learns the limits reported in the headers of a response from
+// method on host
+func (l *RateLimiter) Update(host, method string, header http.Header) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.bucket(appKey(host)).learn(now, header.Get(headerAppRateLimit), header.Get(headerAppRateLimitCount))
+	l.bucket(methodKey(host, method)).learn(now, header.Get(headerMethodRateLimit), header.Get(headerMethodRateLimitCount))
+}
+
+// Backoff handles a 429 from method on host, returning whether the request
+// should be retried. The bucket the response blames is blocked for as long
+// as Retry-After says, or with exponential backoff from one second when it
+// does not say.
+func (l *RateLimiter) Backoff(host, method string, header http.Header, attempt int) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if attempt >= l.maxRetries {
+		return false
+	}
+
+	wait := time.Second << attempt
+	if seconds, err := strconv.Atoi(strings.TrimSpace(header.Get(headerRetryAfter))); err == nil && seconds >= 0 {
+		wait = time.Duration(seconds) * time.Second
+	}
+
+	key := methodKey(host, method)
+	if header.Get(headerRateLimitType) == "application" {
+		key = appKey(host)
+	}
+	b := l.bucket(key)
+	if until := l.now().Add(wait); until.After(b.blockedUntil) {
+		b.blockedUntil = until
+	}
+	return true
+}
+
+func (l *RateLimiter) bucket(key string) *bucket {
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{}
+		l.buckets[key] = b
+	}
+	return b
+}
+
+// delay returns how long until the bucket has room for another request
+func (b *bucket) delay(now time.Time) time.Duration {
+	var delay time.Duration
+	if now.Before(b.blockedUntil) {
+		delay = b.blockedUntil.Sub(now)
+	}
+	for _, w := range b.windows {
+		if !now.Before(w.start.Add(w.length)) {
+			w.start = now
+			w.used = 0
+		}
+		if w.used >= w.count {
+			if d := w.start.Add(w.length).Sub(now); d > delay {
+				delay = d
+			}
+		}
+	}
+	return delay
+}
+
+// learn replaces the bucket's limits with the ones in a limit header,
+// keeping the state of windows that did not change, and catches the usage
+// up with the count header
+func (b *bucket) learn(now time.Time, limits, counts string) {
+	parsed := parseRateLimits(limits)
+	if len(parsed) == 0 {
+		return
+	}
+
+	used := map[time.Duration]int{}
+	for _, count := range parseRateLimits(counts) {
+		used[count.length] = count.count
+	}
+
+	windows := make([]*window, 0, len(parsed))
+	for _, limit := range parsed {
+		w := b.window(limit.length)
+		if w == nil {
+			// the request that reported the limit counts against it
+			w = &window{length: limit.length, start: now, used: 1}
+		}
+		w.count = limit.count
+		if n := used[limit.length]; n > w.used {
+			w.used = n
+		}
+		windows = append(windows, w)
+	}
+	b.windows = windows
+}
+
+func (b *bucket) window(length time.Duration) *window {
+	for _, w := range b.windows {
+		if w.length == length {
+			return w
+		}
+	}
+	return nil
+}
+
+// parseRateLimits parses a rate limit header such as "20:1,100:120" into
+// one window per pair, skipping malformed pairs
+func parseRateLimits(header string) []window {
+	var windows []window
+	for _, pair := range strings.Split(header, ",") {
+		count, seconds, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(count)
+		if err != nil || n < 0 {
+			continue
+		}
+		s, err := strconv.Atoi(seconds)
+		if err != nil || s <= 0 {
+			continue
+		}
+		windows = append(windows, window{count: n, length: time.Duration(s) * time.Second})
+	}
+	return windows
+}
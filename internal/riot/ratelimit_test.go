@@ -0,0 +1,181 @@
+package riot
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// fakeClock stands in for time.Now and time.Sleep, so waiting advances the
+// clock instantly
+type fakeClock struct {
+	now    time.Time
+	slept  time.Duration
+	sleeps int
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Sleep(ctx context.Context, d time.Duration) error {
+	c.now = c.now.Add(d)
+	c.slept += d
+	c.sleeps++
+	return nil
+}
+
+func newTestLimiter() (*RateLimiter, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2024, 5, 19, 9, 0, 0, 0, time.UTC)}
+	limiter := NewRateLimiter()
+	limiter.now = clock.Now
+	limiter.sleep = clock.Sleep
+	return limiter, clock
+}
+
+func TestParseRateLimits(t *testing.T) {
+	windows := parseRateLimits("20:1, 100:120,bad,5:0")
+	if len(windows) != 2 {
+		t.Fatalf("expected 2 windows, got %+v", windows)
+	}
+	if windows[0].count != 20 || windows[0].length != time.Second {
+		t.Errorf("expected 20 per second, got %+v", windows[0])
+	}
+	if windows[1].count != 100 || windows[1].length != 2*time.Minute {
+		t.Errorf("expected 100 per two minutes, got %+v", windows[1])
+	}
+}
+
+func TestRateLimiter_LearnsLimits(t *testing.T) {
+	limiter, clock := newTestLimiter()
+	header := http.Header{}
+	header.Set(headerAppRateLimit, "2:1,100:120")
+	header.Set(headerAppRateLimitCount, "1:1,1:120")
+
+	limiter.Wait(context.Background(), "americas.api.riotgames.com", "match-v5.getMatch")
+	limiter.Update("americas.api.riotgames.com", "match-v5.getMatch", header)
+	limiter.Wait(context.Background(), "americas.api.riotgames.com", "match-v5.getMatch")
+	if clock.sleeps != 0 {
+		t.Fatalf("expected the second request to fit the limit, slept %v", clock.slept)
+	}
+
+	limiter.Wait(context.Background(), "americas.api.riotgames.com", "match-v5.getMatch")
+	if clock.slept != time.Second {
+		t.Errorf("expected the third request to wait for the next second, slept %v", clock.slept)
+	}
+
+	// other regions have limits of their own
+	limiter.Wait(context.Background(), "europe.api.riotgames.com", "match-v5.getMatch")
+	limiter.Wait(context.Background(), "europe.api.riotgames.com", "match-v5.getMatch")
+	limiter.Wait(context.Background(), "europe.api.riotgames.com", "match-v5.getMatch")
+	if clock.slept != time.Second {
+		t.Errorf("expected europe not to wait for americas, slept %v", clock.slept)
+	}
+}
+
+func TestRateLimiter_MethodLimits(t *testing.T) {
+	limiter, clock := newTestLimiter()
+	header := http.Header{}
+	header.Set(headerMethodRateLimit, "1:10")
+
+	limiter.Wait(context.Background(), "kr.api.riotgames.com", "summoner-v4.getByPUUID")
+	limiter.Update("kr.api.riotgames.com", "summoner-v4.getByPUUID", header)
+
+	limiter.Wait(context.Background(), "kr.api.riotgames.com", "league-v4.getEntries")
+	if clock.sleeps != 0 {
+		t.Fatalf("expected other methods not to wait, slept %v", clock.slept)
+	}
+	limiter.Wait(context.Background(), "kr.api.riotgames.com", "summoner-v4.getByPUUID")
+	if clock.slept != 10*time.Second {
+		t.Errorf("expected the method to wait 10s, slept %v", clock.slept)
+	}
+}
+
+func TestGetJSON_RetriesAfter429(t *testing.T) {
+	limiter, clock := newTestLimiter()
+	calls := 0
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				header := http.Header{}
+				header.Set(headerRetryAfter, "3")
+				header.Set(headerRateLimitType, "application")
+				return &http.Response{
+					StatusCode: http.StatusTooManyRequests,
+					Header:     header,
+					Body:       io.NopCloser(bytes.NewBufferString(`{"status":{"status_code":429}}`)),
+				}, nil
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(`{"metadata": {"matchId": "NA1_1"}}`)),
+			}, nil
+		},
+	}
+
+	client := NewClientWithHTTPClient("test-api-key", mockClient)
+	client.SetRateLimiter(limiter)
+	match, err := client.GetMatchByID(context.Background(), "NA1_1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if calls != 2 || match.Metadata.MatchID != "NA1_1" {
+		t.Errorf("expected the match after one retry, got %d calls", calls)
+	}
+	if clock.slept != 3*time.Second {
+		t.Errorf("expected to wait the 3s Retry-After asked for, slept %v", clock.slept)
+	}
+}
+
+func TestGetJSON_GivesUpAfterRetries(t *testing.T) {
+	limiter, clock := newTestLimiter()
+	limiter.SetMaxRetries(2)
+	calls := 0
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			return &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Body:       io.NopCloser(bytes.NewBufferString(`{}`)),
+			}, nil
+		},
+	}
+
+	client := NewClientWithHTTPClient("test-api-key", mockClient)
+	client.SetRateLimiter(limiter)
+	_, err := client.GetMatchByID(context.Background(), "NA1_1")
+	if !IsRateLimited(err) {
+		t.Fatalf("expected a rate limited error, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected the first try and 2 retries, got %d calls", calls)
+	}
+	if clock.slept != 3*time.Second {
+		t.Errorf("expected to back off 1s then 2s without Retry-After, slept %v", clock.slept)
+	}
+}
+
+func TestRateLimiter_WaitStopsWithContext(t *testing.T) {
+	limiter := NewRateLimiter()
+	header := http.Header{}
+	header.Set(headerRetryAfter, "60")
+	header.Set(headerRateLimitType, "application")
+	limiter.Backoff("americas.api.riotgames.com", "match-v5.getMatch", header, 0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := limiter.Wait(ctx, "americas.api.riotgames.com", "match-v5.getMatch")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the wait to end with the context, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the wait to stop early, took %v", elapsed)
+	}
+}
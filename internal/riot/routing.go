@@ -0,0 +1,106 @@
+package riot
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrUnknownPlatform = errors.New("unknown platform")
+	ErrUnknownRegion   = errors.New("unknown routing region")
+)
+
+// Platform is the server a summoner plays on, such as na1 or kr. Summoner,
+// league and champion mastery endpoints are served per platform.
+type Platform string
+
+const (
+	BR1  Platform = "br1"
+	EUN1 Platform = "eun1"
+	EUW1 Platform = "euw1"
+	JP1  Platform = "jp1"
+	KR   Platform = "kr"
+	LA1  Platform = "la1"
+	LA2  Platform = "la2"
+	ME1  Platform = "me1"
+	NA1  Platform = "na1"
+	OC1  Platform = "oc1"
+	PH2  Platform = "ph2"
+	RU   Platform = "ru"
+	SG2  Platform = "sg2"
+	TH2  Platform = "th2"
+	TR1  Platform = "tr1"
+	TW2  Platform = "tw2"
+	VN2  Platform = "vn2"
+)
+
+// Region is a routing region. Account and match endpoints are served per
+// region rather than per platform.
+type Region string
+
+const (
+	Americas Region = "americas"
+	Asia     Region = "asia"
+	Europe   Region = "europe"
+	SEA      Region = "sea"
+)
+
+// platformRegions maps every platform to the region its matches are stored in
+var platformRegions = map[Platform]Region{
+	BR1: Americas, LA1: Americas, LA2: Americas, NA1: Americas,
+	EUN1: Europe, EUW1: Europe, ME1: Europe, RU: Europe, TR1: Europe,
+	JP1: Asia, KR: Asia,
+	OC1: SEA, PH2: SEA, SG2: SEA, TH2: SEA, TW2: SEA, VN2: SEA,
+}
+
+// ParsePlatform validates a platform ID, ignoring case
+func ParsePlatform(name string) (Platform, error) {
+	platform := Platform(strings.ToLower(strings.TrimSpace(name)))
+	if _, ok := platformRegions[platform]; !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownPlatform, name)
+	}
+	return platform, nil
+}
+
+// ParseRegion validates a routing region, ignoring case. A platform ID is
+// accepted too and resolves to its region.
+func ParseRegion(name string) (Region, error) {
+	region := Region(strings.ToLower(strings.TrimSpace(name)))
+	switch region {
+	case Americas, Asia, Europe, SEA:
+		return region, nil
+	}
+	if platform, err := ParsePlatform(name); err == nil {
+		return platform.Region(), nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownRegion, name)
+}
+
+// Region returns the routing region match-v5 serves the platform's games from
+func (p Platform) Region() Region {
+	return platformRegions[p]
+}
+
+// AccountRegion returns the routing region to query account-v1 from. Every
+// region but sea serves accounts, so sea platforms use asia.
+func (p Platform) AccountRegion() Region {
+	if region := p.Region(); region != SEA {
+		return region
+	}
+	return Asia
+}
+
+// MatchRegion returns the routing region of a match from its ID, whose
+// prefix is the platform it was played on (e.g. "KR_7012345678")
+func MatchRegion(matchID string) (Region, error) {
+	prefix, _, ok := strings.Cut(matchID, "_")
+	if !ok {
+		return "", fmt.Errorf("%w: match id %q has no platform prefix", ErrUnknownPlatform, matchID)
+	}
+	platform, err := ParsePlatform(prefix)
+	if err != nil {
+		return "", err
+	}
+	return platform.Region(), nil
+}
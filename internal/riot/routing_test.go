@@ -0,0 +1,120 @@
+package riot
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestParseRegion(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected Region
+		err      error
+	}{
+		{"europe", Europe, nil},
+		{"SEA", SEA, nil},
+		{"euw1", Europe, nil},
+		{"KR", Asia, nil},
+		{"oc1", SEA, nil},
+		{"la2", Americas, nil},
+		{"atlantis", "", ErrUnknownRegion},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			region, err := ParseRegion(tt.name)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected error %v, got %v", tt.err, err)
+			}
+			if region != tt.expected {
+				t.Errorf("expected region %q, got %q", tt.expected, region)
+			}
+		})
+	}
+}
+
+func TestAccountRegion(t *testing.T) {
+	tests := []struct {
+		platform Platform
+		expected Region
+	}{
+		{NA1, Americas},
+		{EUW1, Europe},
+		{KR, Asia},
+		{VN2, Asia},
+	}
+
+	for _, tt := range tests {
+		if region := tt.platform.AccountRegion(); region != tt.expected {
+			t.Errorf("%s: expected account region %q, got %q", tt.platform, tt.expected, region)
+		}
+	}
+}
+
+func TestMatchRegion(t *testing.T) {
+	tests := []struct {
+		matchID  string
+		expected Region
+		err      error
+	}{
+		{"KR_7012345678", Asia, nil},
+		{"EUW1_6543210987", Europe, nil},
+		{"NA1_1234567890", Americas, nil},
+		{"OC1_555", SEA, nil},
+		{"1234567890", "", ErrUnknownPlatform},
+		{"XX1_1234567890", "", ErrUnknownPlatform},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.matchID, func(t *testing.T) {
+			region, err := MatchRegion(tt.matchID)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected error %v, got %v", tt.err, err)
+			}
+			if region != tt.expected {
+				t.Errorf("expected region %q, got %q", tt.expected, region)
+			}
+		})
+	}
+}
+
+func TestRouting_Hosts(t *testing.T) {
+	var requested []string
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			requested = append(requested, req.URL.Host)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(`{}`)),
+			}, nil
+		},
+	}
+
+	client := NewClientWithHTTPClient("test-api-key", mockClient)
+	client.GetMatchByID(context.Background(), "KR_7012345678")
+	client.GetMatchByID(context.Background(), "1234567890")
+	client.GetSummonerByPUUID(context.Background(), EUW1, "test-puuid")
+	client.SetRegion(Europe)
+	client.GetMatchesByPUUID(context.Background(), "test-puuid", 1)
+	client.GetMatchesByPUUIDInRegion(context.Background(), SEA, "test-puuid", MatchFilter{Count: 1})
+
+	expected := []string{
+		"asia.api.riotgames.com",
+		"americas.api.riotgames.com",
+		"euw1.api.riotgames.com",
+		"europe.api.riotgames.com",
+		"sea.api.riotgames.com",
+	}
+	if len(requested) != len(expected) {
+		t.Fatalf("expected %d requests, got %v", len(expected), requested)
+	}
+	for i := range expected {
+		if requested[i] != expected[i] {
+			t.Errorf("request %d: expected host %s, got %s", i+1, expected[i], requested[i])
+		}
+	}
+}
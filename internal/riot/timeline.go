@@ -0,0 +1,208 @@
+package riot
+
+import (
+	"sort"
+	"strconv"
+)
+
+// Timeline event types sent by match-v5
+const (
+	EventChampionKill         = "CHAMPION_KILL"
+	EventChampionSpecialKill  = "CHAMPION_SPECIAL_KILL"
+	EventEliteMonsterKill     = "ELITE_MONSTER_KILL"
+	EventBuildingKill         = "BUILDING_KILL"
+	EventTurretPlateDestroyed = "TURRET_PLATE_DESTROYED"
+	EventDragonSoulGiven      = "DRAGON_SOUL_GIVEN"
+	EventWardPlaced           = "WARD_PLACED"
+	EventWardKill             = "WARD_KILL"
+	EventItemPurchased        = "ITEM_PURCHASED"
+	EventItemSold             = "ITEM_SOLD"
+	EventItemDestroyed        = "ITEM_DESTROYED"
+	EventItemUndo             = "ITEM_UNDO"
+	EventLevelUp              = "LEVEL_UP"
+	EventSkillLevelUp         = "SKILL_LEVEL_UP"
+	EventPauseEnd             = "PAUSE_END"
+	EventGameEnd              = "GAME_END"
+)
+
+// MatchTimeline is the match-v5 timeline of a match: a frame per minute
+// with every participant's state, and the events that happened in between
+type MatchTimeline struct {
+	Metadata MatchMetadata `json:"metadata"`
+	Info     TimelineInfo  `json:"info"`
+}
+
+// TimelineInfo contains the timeline frames. FrameInterval is in
+// milliseconds.
+type TimelineInfo struct {
+	GameID        int64                 `json:"gameId"`
+	FrameInterval int64                 `json:"frameInterval"`
+	Participants  []TimelineParticipant `json:"participants"`
+	Frames        []TimelineFrame       `json:"frames"`
+}
+
+// TimelineParticipant links a timeline participant ID to its player
+type TimelineParticipant struct {
+	ParticipantID int    `json:"participantId"`
+	PUUID         string `json:"puuid"`
+}
+
+// TimelineFrame is a snapshot of the game. ParticipantFrames is keyed by
+// participant ID ("1" to "10") and Events holds what happened since the
+// previous frame.
+type TimelineFrame struct {
+	Timestamp         int64                       `json:"timestamp"`
+	ParticipantFrames map[string]ParticipantFrame `json:"participantFrames"`
+	Events            []TimelineEvent             `json:"events"`
+}
+
+// ParticipantFrame is a participant's state at a frame
+type ParticipantFrame struct {
+	ParticipantID            int      `json:"participantId"`
+	Level                    int      `json:"level"`
+	XP                       int      `json:"xp"`
+	CurrentGold              int      `json:"currentGold"`
+	TotalGold                int      `json:"totalGold"`
+	GoldPerSecond            int      `json:"goldPerSecond"`
+	MinionsKilled            int      `json:"minionsKilled"`
+	JungleMinionsKilled      int      `json:"jungleMinionsKilled"`
+	TimeEnemySpentControlled int      `json:"timeEnemySpentControlled"`
+	Position                 Position `json:"position"`
+}
+
+// Position is a point on the map
+type Position struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// TimelineEvent is a single event. Type says which of the optional fields
+// are set: kills carry KillerID, VictimID and assists, objectives the
+// monster or building, wards WardType and CreatorID, items ItemID, and
+// level ups Level or SkillSlot. Timestamps are in milliseconds from the
+// start of the game.
+type TimelineEvent struct {
+	Type      string `json:"type"`
+	Timestamp int64  `json:"timestamp"`
+
+	ParticipantID           int       `json:"participantId,omitempty"`
+	KillerID                int       `json:"killerId,omitempty"`
+	VictimID                int       `json:"victimId,omitempty"`
+	AssistingParticipantIDs []int     `json:"assistingParticipantIds,omitempty"`
+	KillerTeamID            int       `json:"killerTeamId,omitempty"`
+	TeamID                  int       `json:"teamId,omitempty"`
+	Position                *Position `json:"position,omitempty"`
+	Bounty                  int       `json:"bounty,omitempty"`
+	ShutdownBounty          int       `json:"shutdownBounty,omitempty"`
+	KillStreakLength        int       `json:"killStreakLength,omitempty"`
+	KillType                string    `json:"killType,omitempty"`
+
+	MonsterType    string `json:"monsterType,omitempty"`
+	MonsterSubType string `json:"monsterSubType,omitempty"`
+	BuildingType   string `json:"buildingType,omitempty"`
+	LaneType       string `json:"laneType,omitempty"`
+	TowerType      string `json:"towerType,omitempty"`
+
+	WardType  string `json:"wardType,omitempty"`
+	CreatorID int    `json:"creatorId,omitempty"`
+
+	ItemID    int `json:"itemId,omitempty"`
+	BeforeID  int `json:"beforeId,omitempty"`
+	AfterID   int `json:"afterId,omitempty"`
+	GoldGain  int `json:"goldGain,omitempty"`
+	Level     int `json:"level,omitempty"`
+	SkillSlot int `json:"skillSlot,omitempty"`
+
+	LevelUpType string `json:"levelUpType,omitempty"`
+	Name        string `json:"name,omitempty"`
+	WinningTeam int    `json:"winningTeam,omitempty"`
+}
+
+// ParticipantMinute is a participant's gold, experience, creep score and
+// position at one frame of the timeline
+type ParticipantMinute struct {
+	Minute   int      `json:"minute"`
+	Gold     int      `json:"gold"`
+	XP       int      `json:"xp"`
+	CS       int      `json:"cs"`
+	Level    int      `json:"level"`
+	Position Position `json:"position"`
+}
+
+// ParticipantTimeline is one participant's state minute by minute
+type ParticipantTimeline struct {
+	ParticipantID int                 `json:"participantId"`
+	PUUID         string              `json:"puuid,omitempty"`
+	Minutes       []ParticipantMinute `json:"minutes"`
+}
+
+// Participants returns every participant's gold, experience, creep score
+// and position per frame, ordered by participant ID. Frames are a minute
+// apart, so the nth entry is minute n.
+func (t *MatchTimeline) Participants() []ParticipantTimeline {
+	puuids := map[int]string{}
+	for _, participant := range t.Info.Participants {
+		puuids[participant.ParticipantID] = participant.PUUID
+	}
+
+	byID := map[int]*ParticipantTimeline{}
+	for _, frame := range t.Info.Frames {
+		minute := t.minute(frame.Timestamp)
+		for key, state := range frame.ParticipantFrames {
+			id := state.ParticipantID
+			if id == 0 {
+				id, _ = strconv.Atoi(key)
+			}
+			participant, ok := byID[id]
+			if !ok {
+				participant = &ParticipantTimeline{ParticipantID: id, PUUID: puuids[id]}
+				byID[id] = participant
+			}
+			participant.Minutes = append(participant.Minutes, ParticipantMinute{
+				Minute:   minute,
+				Gold:     state.TotalGold,
+				XP:       state.XP,
+				CS:       state.MinionsKilled + state.JungleMinionsKilled,
+				Level:    state.Level,
+				Position: state.Position,
+			})
+		}
+	}
+
+	participants := make([]ParticipantTimeline, 0, len(byID))
+	for _, participant := range byID {
+		participants = append(participants, *participant)
+	}
+	sort.Slice(participants, func(i, j int) bool {
+		return participants[i].ParticipantID < participants[j].ParticipantID
+	})
+	return participants
+}
+
+// Events returns the timeline's events in order, keeping only the given
+// types when any are named
+func (t *MatchTimeline) Events(types ...string) []TimelineEvent {
+	keep := map[string]bool{}
+	for _, eventType := range types {
+		keep[eventType] = true
+	}
+
+	events := []TimelineEvent{}
+	for _, frame := range t.Info.Frames {
+		for _, event := range frame.Events {
+			if len(keep) == 0 || keep[event.Type] {
+				events = append(events, event)
+			}
+		}
+	}
+	return events
+}
+
+// minute returns the minute a frame timestamp falls in
+func (t *MatchTimeline) minute(timestamp int64) int {
+	interval := t.Info.FrameInterval
+	if interval <= 0 {
+		interval = 60000
+	}
+	return int(timestamp / interval)
+}
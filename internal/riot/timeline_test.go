@@ -0,0 +1,117 @@
+package riot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+const timelineJSON = `{
+	"metadata": {"matchId": "EUW1_6543210987"},
+	"info": {
+		"frameInterval": 60000,
+		"participants": [{"participantId": 1, "puuid": "puuid-1"}, {"participantId": 2, "puuid": "puuid-2"}],
+		"frames": [
+			{
+				"timestamp": 0,
+				"participantFrames": {
+					"1": {"participantId": 1, "level": 1, "xp": 0, "totalGold": 500, "position": {"x": 554, "y": 581}},
+					"2": {"participantId": 2, "level": 1, "xp": 0, "totalGold": 500, "position": {"x": 14340, "y": 14390}}
+				},
+				"events": [{"type": "PAUSE_END", "timestamp": 0}]
+			},
+			{
+				"timestamp": 60021,
+				"participantFrames": {
+					"1": {"participantId": 1, "level": 2, "xp": 280, "totalGold": 620, "minionsKilled": 4, "jungleMinionsKilled": 1, "position": {"x": 5200, "y": 6100}},
+					"2": {"participantId": 2, "level": 1, "xp": 150, "totalGold": 560, "minionsKilled": 2, "position": {"x": 9000, "y": 8800}}
+				},
+				"events": [
+					{"type": "ITEM_PURCHASED", "timestamp": 1200, "participantId": 1, "itemId": 1055},
+					{"type": "WARD_PLACED", "timestamp": 45000, "creatorId": 2, "wardType": "YELLOW_TRINKET"},
+					{"type": "LEVEL_UP", "timestamp": 52000, "participantId": 1, "level": 2},
+					{"type": "CHAMPION_KILL", "timestamp": 58000, "killerId": 1, "victimId": 2, "assistingParticipantIds": [3], "bounty": 300, "position": {"x": 7000, "y": 7100}},
+					{"type": "ELITE_MONSTER_KILL", "timestamp": 59000, "killerId": 1, "killerTeamId": 100, "monsterType": "DRAGON", "monsterSubType": "FIRE_DRAGON"}
+				]
+			}
+		]
+	}
+}`
+
+func TestMatchTimeline_Participants(t *testing.T) {
+	var requested string
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			requested = req.URL.String()
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(timelineJSON)),
+			}, nil
+		},
+	}
+
+	client := NewClientWithHTTPClient("test-api-key", mockClient)
+	timeline, err := client.GetMatchTimeline(context.Background(), "EUW1_6543210987")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if requested != "https://europe.api.riotgames.com/lol/match/v5/matches/EUW1_6543210987/timeline" {
+		t.Errorf("expected the timeline to be fetched from europe, got %s", requested)
+	}
+
+	participants := timeline.Participants()
+	if len(participants) != 2 || participants[0].ParticipantID != 1 || participants[0].PUUID != "puuid-1" {
+		t.Fatalf("expected two participants ordered by ID, got %+v", participants)
+	}
+	minute := participants[0].Minutes[1]
+	if minute.Minute != 1 || minute.Gold != 620 || minute.XP != 280 || minute.CS != 5 || minute.Level != 2 || minute.Position.X != 5200 {
+		t.Errorf("expected minute 1 with 620 gold, 280 xp and 5 cs, got %+v", minute)
+	}
+}
+
+func TestMatchTimeline_EscapesMatchID(t *testing.T) {
+	var requested string
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			requested = req.URL.String()
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(timelineJSON)),
+			}, nil
+		},
+	}
+
+	client := NewClientWithHTTPClient("test-api-key", mockClient)
+	if _, err := client.GetMatchTimeline(context.Background(), "EUW1_1/../../riot/account?x=1"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if requested != "https://europe.api.riotgames.com/lol/match/v5/matches/EUW1_1%2F..%2F..%2Friot%2Faccount%3Fx=1/timeline" {
+		t.Errorf("expected the match ID to stay one path segment, got %s", requested)
+	}
+}
+
+func TestMatchTimeline_Events(t *testing.T) {
+	var timeline MatchTimeline
+	if err := json.Unmarshal([]byte(timelineJSON), &timeline); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if events := timeline.Events(); len(events) != 6 {
+		t.Errorf("expected 6 events, got %d", len(events))
+	}
+
+	kills := timeline.Events(EventChampionKill, EventEliteMonsterKill)
+	if len(kills) != 2 {
+		t.Fatalf("expected a champion kill and a dragon, got %+v", kills)
+	}
+	kill := kills[0]
+	if kill.KillerID != 1 || kill.VictimID != 2 || len(kill.AssistingParticipantIDs) != 1 || kill.Position == nil || kill.Position.X != 7000 {
+		t.Errorf("expected participant 1 to kill participant 2, got %+v", kill)
+	}
+	if kills[1].MonsterSubType != "FIRE_DRAGON" || kills[1].KillerTeamID != TeamBlue {
+		t.Errorf("expected blue to take a fire dragon, got %+v", kills[1])
+	}
+}
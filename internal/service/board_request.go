@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/champions"
+	"github.com/gvieiragoulart/draft-visualizer/internal/draft"
+)
+
+// BoardRequest identifies a draft to analyse: either a live session, or the
+// teams and actions of a board posted directly
+type BoardRequest struct {
+	SessionID string                  `json:"sessionId"`
+	BlueTeam  string                  `json:"blueTeam"`
+	RedTeam   string                  `json:"redTeam"`
+	Format    string                  `json:"format"`
+	Actions   []draft.Action          `json:"actions"`
+	Locked    map[draft.Side][]string `json:"locked"`
+	Disabled  []string                `json:"disabled"`
+}
+
+// buildBoardDraft replays a board into a draft, normalizing champion names
+// when a catalogue is given. Invalid boards are wrapped in errInvalid.
+func buildBoardDraft(ctx context.Context, sessions *DraftSessionService, catalogue *champions.Catalogue, req BoardRequest, errInvalid error) (*draft.Draft, error) {
+	if req.SessionID != "" {
+		session, err := sessions.GetSession(ctx, req.SessionID)
+		if err != nil {
+			return nil, err
+		}
+		req.BlueTeam = session.Board.Blue.Team
+		req.RedTeam = session.Board.Red.Team
+		req.Format = string(session.Board.Format)
+		req.Actions = session.Board.Actions
+		req.Locked = map[draft.Side][]string{
+			draft.Blue: session.Board.Blue.Locked,
+			draft.Red:  session.Board.Red.Locked,
+		}
+		req.Disabled = session.Board.Disabled
+	}
+
+	format, err := draft.ParseFormat(req.Format)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalid, err)
+	}
+
+	d := draft.New(req.BlueTeam, req.RedTeam)
+	d.Format = format
+	d.Locked = req.Locked
+	d.Disabled = req.Disabled
+	for i, action := range req.Actions {
+		if catalogue != nil && action.Champion != "" {
+			if action.Champion, err = catalogue.Canonical(action.Champion); err != nil {
+				return nil, fmt.Errorf("%w: action %d: %w", errInvalid, i+1, err)
+			}
+		}
+		if err := d.Apply(action); err != nil {
+			return nil, fmt.Errorf("%w: %w", errInvalid, err)
+		}
+	}
+	return d, nil
+}
@@ -1,18 +1,209 @@
 package service
 
 import (
+	"fmt"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/champions"
 	"github.com/gvieiragoulart/draft-visualizer/internal/clients/cargo"
 	"github.com/gvieiragoulart/draft-visualizer/internal/clients/cargo/model/news_items"
+	"github.com/gvieiragoulart/draft-visualizer/internal/clients/cargo/model/picks_and_bans"
+	"github.com/gvieiragoulart/draft-visualizer/internal/draft"
 )
 
 type CargoService struct {
 	cargoClient *cargo.Client
+	catalogue   *champions.Catalogue
 }
 
 func NewCargoService(cargoClient *cargo.Client) *CargoService {
 	return &CargoService{cargoClient: cargoClient}
 }
 
+// SetCatalogue lets series validation flag champions that were not yet
+// released on a match's patch
+func (s *CargoService) SetCatalogue(catalogue *champions.Catalogue) {
+	s.catalogue = catalogue
+}
+
 func (s *CargoService) GetNewsItems() ([]news_items.NewsItems, error) {
 	return s.cargoClient.GetNewsLatest()
 }
+
+func (s *CargoService) GetPicksAndBans(tournament string) ([]picks_and_bans.PicksAndBans, error) {
+	return s.cargoClient.GetPicksAndBans(tournament)
+}
+
+// ExportDrafts converts a tournament's Leaguepedia games into records for
+// export, with each game's number in its series. Games whose draft cannot be
+// replayed, such as rows with a champion listed twice, are left out.
+func (s *CargoService) ExportDrafts(tournament string) ([]draft.Record, error) {
+	picksAndBans, err := s.cargoClient.GetPicksAndBans(tournament)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]draft.Record, 0, len(picksAndBans))
+	for _, game := range picksAndBans {
+		d, err := picksAndBansDraft(game)
+		if err != nil {
+			continue
+		}
+		record := draft.Record{Draft: d}
+		if game.N_GameInMatch != nil {
+			record.Series = &draft.SeriesContext{Game: *game.N_GameInMatch}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// SeriesReport is the result of checking one imported series against a ruleset
+type SeriesReport struct {
+	MatchId    string              `json:"matchId"`
+	Team1      string              `json:"team1"`
+	Team2      string              `json:"team2"`
+	BestOf     int                 `json:"bestOf"`
+	Ruleset    draft.Ruleset       `json:"ruleset"`
+	Patch      string              `json:"patch,omitempty"`
+	Games      int                 `json:"games"`
+	Locked     map[string][]string `json:"locked"`
+	Violations []string            `json:"violations"`
+}
+
+// ValidateSeries replays every series of a tournament under the given
+// ruleset and reports picks that break it. Series length comes from the
+// MatchSchedule BestOf field when Leaguepedia has it, and bans or picks of
+// champions outside the match's patch pool are reported as well.
+func (s *CargoService) ValidateSeries(tournament string, ruleset draft.Ruleset) ([]SeriesReport, error) {
+	picksAndBans, err := s.cargoClient.GetPicksAndBans(tournament)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := s.cargoClient.GetMatchSchedule(tournament)
+	if err != nil {
+		return nil, err
+	}
+	bestOf := make(map[string]int)
+	pools := make(map[string]*ChampionPool)
+	for _, match := range schedule {
+		if match.BestOf != nil {
+			bestOf[match.MatchId] = *match.BestOf
+		}
+		pools[match.MatchId] = MatchChampionPool(s.catalogue, match)
+	}
+
+	// Games arrive ordered by page, match and game number
+	var order []string
+	games := make(map[string][]picks_and_bans.PicksAndBans)
+	for _, game := range picksAndBans {
+		if _, ok := games[game.MatchId]; !ok {
+			order = append(order, game.MatchId)
+		}
+		games[game.MatchId] = append(games[game.MatchId], game)
+	}
+
+	reports := make([]SeriesReport, 0, len(order))
+	for _, matchId := range order {
+		report, err := s.validateSeries(matchId, games[matchId], bestOf[matchId], ruleset, pools[matchId])
+		if err != nil {
+			return nil, fmt.Errorf("error validating series %s: %w", matchId, err)
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+func (s *CargoService) validateSeries(matchId string, games []picks_and_bans.PicksAndBans, bestOf int, ruleset draft.Ruleset, pool *ChampionPool) (SeriesReport, error) {
+	if bestOf < len(games) {
+		bestOf = len(games)
+	}
+	if bestOf%2 == 0 {
+		bestOf++
+	}
+
+	first := games[0]
+	series, err := draft.NewSeries(first.Team1, first.Team2, bestOf, ruleset)
+	if err != nil {
+		return SeriesReport{}, err
+	}
+
+	report := SeriesReport{
+		MatchId:    matchId,
+		Team1:      first.Team1,
+		Team2:      first.Team2,
+		BestOf:     bestOf,
+		Ruleset:    ruleset,
+		Games:      len(games),
+		Violations: []string{},
+	}
+	if pool != nil {
+		report.Patch = pool.Patch
+	}
+
+	for _, game := range games {
+		d, err := picksAndBansDraft(game)
+		if err != nil {
+			// Keep the game in the series with the picks it lists so its
+			// champions still lock later games and game numbers stay right
+			report.Violations = append(report.Violations, fmt.Sprintf("game %d: %v", len(series.Games)+1, err))
+			d = rawPicksDraft(game)
+		} else if pool != nil {
+			d.Disabled = pool.Illegal()
+			if err := d.DisabledViolations(); err != nil {
+				for _, violation := range unwrapJoined(err) {
+					report.Violations = append(report.Violations, fmt.Sprintf("game %d %s", len(series.Games)+1, violation))
+				}
+			}
+		}
+
+		winner := ""
+		if game.Winner != nil && *game.Winner == 1 {
+			winner = game.Team1
+		} else if game.Winner != nil && *game.Winner == 2 {
+			winner = game.Team2
+		}
+
+		if err := series.AddGame(d, winner); err != nil {
+			for _, violation := range unwrapJoined(err) {
+				report.Violations = append(report.Violations, violation.Error())
+			}
+		}
+	}
+
+	report.Locked = series.LockedChampions()
+	return report, nil
+}
+
+// unwrapJoined splits an errors.Join result back into its errors
+func unwrapJoined(err error) []error {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		return joined.Unwrap()
+	}
+	return []error{err}
+}
+
+// picksAndBansDraft converts a Leaguepedia row into the draft model,
+// interleaving both teams' bans and picks into the tournament draft order.
+// Leaguepedia stores the blue side team as Team1.
+func picksAndBansDraft(p picks_and_bans.PicksAndBans) (*draft.Draft, error) {
+	return draft.Assemble(
+		p.Team1,
+		p.Team2,
+		draft.Selections{Bans: p.Team1Bans, Picks: p.Team1Picks, Roles: p.Team1Roles},
+		draft.Selections{Bans: p.Team2Bans, Picks: p.Team2Picks, Roles: p.Team2Roles},
+	)
+}
+
+// rawPicksDraft keeps only the picks of a row whose draft cannot be replayed,
+// unchecked and in the order Leaguepedia lists them
+func rawPicksDraft(p picks_and_bans.PicksAndBans) *draft.Draft {
+	d := draft.New(p.Team1, p.Team2)
+	for _, champion := range p.Team1Picks {
+		d.Actions = append(d.Actions, draft.Action{Side: draft.Blue, Type: draft.Pick, Champion: champion})
+	}
+	for _, champion := range p.Team2Picks {
+		d.Actions = append(d.Actions, draft.Action{Side: draft.Red, Type: draft.Pick, Champion: champion})
+	}
+	return d
+}
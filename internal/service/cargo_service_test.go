@@ -0,0 +1,235 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/clients/cargo"
+	"github.com/gvieiragoulart/draft-visualizer/internal/clients/cargo/model/picks_and_bans"
+	"github.com/gvieiragoulart/draft-visualizer/internal/draft"
+)
+
+func TestGetPicksAndBans_Parses(t *testing.T) {
+	var where string
+	client := cargo.NewClientWithHTTPClient(&mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			where = req.URL.Query().Get("where")
+			body := `{"cargoquery":[{"title":{"Team1":"T1","Team2":"Gen.G","Winner":"1",
+				"Team1Ban1":"Azir","Team1Ban2":"Ahri","Team2Ban1":"Kalista",
+				"Team1Pick1":"Ashe","Team1Pick2":"Sejuani","Team1Pick3":"Taliyah",
+				"Team1Role1":"Bot","Team1Role2":"","Team1Role3":"Mid",
+				"Team2Pick1":"Xayah","Team2Role1":"Bot",
+				"N GameInMatch":"2","GameId":"LCK_1_1_2","MatchId":"LCK_1_1"}}]}`
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	})
+
+	games, err := NewCargoService(client).GetPicksAndBans(`LCK" OR "1"="1`)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if where != `OverviewPage="LCK\" OR \"1\"=\"1"` {
+		t.Errorf("expected the tournament to be escaped, got %s", where)
+	}
+	if len(games) != 1 {
+		t.Fatalf("expected 1 game, got %d", len(games))
+	}
+	game := games[0]
+	if game.Team1Side != picks_and_bans.SideBlue || game.Winner == nil || *game.Winner != 1 {
+		t.Errorf("expected T1 on blue to win, got %+v", game)
+	}
+	if strings.Join(game.Team1Bans, ",") != "Azir,Ahri" || strings.Join(game.Team2Bans, ",") != "Kalista" {
+		t.Errorf("expected empty ban slots to be dropped, got %v and %v", game.Team1Bans, game.Team2Bans)
+	}
+	if len(game.Team1Roles) != len(game.Team1Picks) || game.Team1Roles[1] != "" || game.Team1Roles[2] != "Mid" {
+		t.Errorf("expected roles to line up with picks, got %v for %v", game.Team1Roles, game.Team1Picks)
+	}
+	if game.N_GameInMatch == nil || *game.N_GameInMatch != 2 || game.MatchId != "LCK_1_1" {
+		t.Errorf("expected the game number and match to be parsed, got %+v", game)
+	}
+}
+
+func TestGetPicksAndBans_EscapesBackslashes(t *testing.T) {
+	var where string
+	client := cargo.NewClientWithHTTPClient(&mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			where = req.URL.Query().Get("where")
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(`{"cargoquery":[]}`)),
+			}, nil
+		},
+	})
+
+	if _, err := NewCargoService(client).GetPicksAndBans(`x\" OR 1=1 OR \"`); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if where != `OverviewPage="x\\\" OR 1=1 OR \\\""` {
+		t.Errorf("expected backslashes and quotes to be escaped, got %s", where)
+	}
+}
+
+func TestGetPicksAndBans_Paginates(t *testing.T) {
+	var offsets []string
+	client := cargo.NewClientWithHTTPClient(&mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			offset := req.URL.Query().Get("offset")
+			offsets = append(offsets, offset)
+
+			rows := 500
+			if n, _ := strconv.Atoi(offset); n > 0 {
+				rows = 3
+			}
+			items := make([]string, rows)
+			for i := range items {
+				items[i] = fmt.Sprintf(`{"title":{"Team1":"T1","Team2":"Gen.G","GameId":"%s_%d"}}`, offset, i)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(`{"cargoquery":[` + strings.Join(items, ",") + `]}`)),
+			}, nil
+		},
+	})
+
+	games, err := NewCargoService(client).GetPicksAndBans("LCK/2024 Season/Summer Season")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(games) != 503 || len(offsets) != 2 || offsets[1] != "500" {
+		t.Errorf("expected 503 games over 2 pages, got %d games for offsets %v", len(games), offsets)
+	}
+}
+
+func TestGetPicksAndBans_TooManyRows(t *testing.T) {
+	requests := 0
+	client := cargo.NewClientWithHTTPClient(&mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			requests++
+			items := make([]string, 500)
+			for i := range items {
+				items[i] = `{"title":{"Team1":"T1","Team2":"Gen.G"}}`
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(`{"cargoquery":[` + strings.Join(items, ",") + `]}`)),
+			}, nil
+		},
+	})
+
+	games, err := NewCargoService(client).GetPicksAndBans("LCK/2024 Season/Summer Season")
+	if !errors.Is(err, cargo.ErrTooManyRows) {
+		t.Fatalf("expected ErrTooManyRows, got %v", err)
+	}
+	if games != nil || requests != 20 {
+		t.Errorf("expected no games after 20 pages, got %d games after %d requests", len(games), requests)
+	}
+}
+
+func TestGetMatchSchedule_Paginates(t *testing.T) {
+	var offsets []string
+	client := cargo.NewClientWithHTTPClient(&mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			offset := req.URL.Query().Get("offset")
+			offsets = append(offsets, offset)
+
+			rows := 500
+			if n, _ := strconv.Atoi(offset); n > 0 {
+				rows = 2
+			}
+			items := make([]string, rows)
+			for i := range items {
+				items[i] = fmt.Sprintf(`{"title":{"Team1":"T1","Team2":"Gen.G","MatchId":"%s_%d"}}`, offset, i)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(`{"cargoquery":[` + strings.Join(items, ",") + `]}`)),
+			}, nil
+		},
+	})
+
+	matches, err := client.GetMatchSchedule("LCK/2024 Season/Summer Season")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(matches) != 502 || len(offsets) != 2 || offsets[1] != "500" {
+		t.Errorf("expected 502 matches over 2 pages, got %d matches for offsets %v", len(matches), offsets)
+	}
+}
+
+func TestPicksAndBansDraft(t *testing.T) {
+	pb := picks_and_bans.PicksAndBans{
+		Team1:      "T1",
+		Team2:      "Gen.G",
+		Team1Bans:  []string{"Azir", "Ahri", "Rell", "Vi", "Nautilus"},
+		Team2Bans:  []string{"Kalista", "Varus", "Orianna", "Jax", "Rakan"},
+		Team1Picks: []string{"Ashe", "Sejuani", "Taliyah", "K'Sante", "Lulu"},
+		Team2Picks: []string{"Xayah", "Maokai", "Corki", "Jayce", "Braum"},
+		Team1Roles: []string{"Bot", "Jungle", "Mid", "Top", "Support"},
+		Team2Roles: []string{"Bot", "Jungle", "Mid", "Top", "Support"},
+	}
+
+	d, err := picksAndBansDraft(pb)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if d.BlueTeam != "T1" || d.RedTeam != "Gen.G" {
+		t.Errorf("expected T1 on blue and Gen.G on red, got %s and %s", d.BlueTeam, d.RedTeam)
+	}
+	if !d.Complete() {
+		t.Errorf("expected complete draft, got %d actions", len(d.Actions))
+	}
+	// Red's first two picks come back to back after blue's first pick
+	if d.Actions[7].Champion != "Xayah" || d.Actions[8].Champion != "Maokai" {
+		t.Errorf("expected Xayah and Maokai as red's first picks, got %s and %s", d.Actions[7].Champion, d.Actions[8].Champion)
+	}
+	if d.Actions[8].Role != "Jungle" {
+		t.Errorf("expected Maokai to be played Jungle, got %s", d.Actions[8].Role)
+	}
+}
+
+func TestValidateSeries_KeepsUnreplayableGames(t *testing.T) {
+	games := []picks_and_bans.PicksAndBans{
+		{
+			// Ahri is listed twice, so the draft cannot be replayed
+			Team1:      "T1",
+			Team2:      "Gen.G",
+			Team1Bans:  []string{"Kalista", "Varus", "Rumble", "Vi", "Jax"},
+			Team2Bans:  []string{"Azir", "Rell", "Orianna", "Rakan", "Nautilus"},
+			Team1Picks: []string{"Ahri", "Ashe", "Sejuani", "Taliyah", "Lulu"},
+			Team2Picks: []string{"Ahri", "Maokai", "Corki", "Jayce", "Braum"},
+		},
+		{
+			Team1:      "Gen.G",
+			Team2:      "T1",
+			Team1Bans:  []string{"Kalista", "Varus", "Rumble", "Skarner", "Poppy"},
+			Team2Bans:  []string{"Leona", "Renata Glasc", "Gnar", "Yone", "Lee Sin"},
+			Team1Picks: []string{"Ashe", "Vi", "Azir", "Jax", "Rakan"},
+			Team2Picks: []string{"Xayah", "Rell", "Orianna", "K'Sante", "Nautilus"},
+		},
+	}
+
+	report, err := NewCargoService(nil).validateSeries("LCK_1_1", games, 3, draft.RulesetFearless, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(report.Violations) != 2 {
+		t.Fatalf("expected the broken game and the locked pick to be reported, got %v", report.Violations)
+	}
+	if !strings.HasPrefix(report.Violations[0], "game 1:") {
+		t.Errorf("expected the broken game to be numbered 1, got %s", report.Violations[0])
+	}
+	if !strings.HasPrefix(report.Violations[1], "game 2 ") || !strings.Contains(report.Violations[1], "Ashe") {
+		t.Errorf("expected Ashe to be locked in game 2, got %s", report.Violations[1])
+	}
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/champions"
+	"github.com/gvieiragoulart/draft-visualizer/internal/draft"
+)
+
+var ErrInvalidConversion = errors.New("invalid draft conversion")
+
+// Formats drafts can be converted between
+const (
+	DraftNotation = "notation"
+	DraftJSON     = "json"
+	DraftCSV      = "csv"
+)
+
+// ContentType returns the media type drafts are served as in format
+func ContentType(format string) string {
+	switch strings.ToLower(format) {
+	case DraftNotation:
+		return "text/plain; charset=utf-8"
+	case DraftCSV:
+		return "text/csv; charset=utf-8"
+	}
+	return "application/json"
+}
+
+// EncodeDrafts writes records in draft notation, JSON or CSV
+func EncodeDrafts(records []draft.Record, format string) ([]byte, error) {
+	switch strings.ToLower(format) {
+	case DraftNotation:
+		return []byte(draft.FormatNotation(records)), nil
+	case DraftCSV:
+		var b bytes.Buffer
+		if err := draft.WriteCSV(&b, records); err != nil {
+			return nil, err
+		}
+		return b.Bytes(), nil
+	case "", DraftJSON:
+		data, err := json.Marshal(records)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding drafts: %w", err)
+		}
+		return data, nil
+	}
+	return nil, fmt.Errorf("%w: unknown format %q", ErrInvalidConversion, format)
+}
+
+// DecodeDrafts reads records in draft notation, JSON or CSV. JSON drafts are
+// replayed so they are validated like the other formats.
+func DecodeDrafts(data []byte, format string) ([]draft.Record, error) {
+	switch strings.ToLower(format) {
+	case DraftNotation:
+		records, err := draft.ParseNotation(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidConversion, err)
+		}
+		return records, nil
+	case DraftCSV:
+		records, err := draft.ReadCSV(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidConversion, err)
+		}
+		return records, nil
+	case "", DraftJSON:
+		var records []draft.Record
+		if err := json.Unmarshal(data, &records); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidConversion, err)
+		}
+		for i, record := range records {
+			if record.Draft == nil {
+				return nil, fmt.Errorf("%w: record %d has no draft", ErrInvalidConversion, i+1)
+			}
+			if err := record.Draft.Validate(); err != nil {
+				return nil, fmt.Errorf("%w: record %d: %w", ErrInvalidConversion, i+1, err)
+			}
+		}
+		return records, nil
+	}
+	return nil, fmt.Errorf("%w: unknown format %q", ErrInvalidConversion, format)
+}
+
+// ConvertService converts drafts between draft notation, JSON and CSV
+type ConvertService struct {
+	catalogue *champions.Catalogue
+}
+
+func NewConvertService() *ConvertService {
+	return &ConvertService{}
+}
+
+// SetCatalogue normalizes champion spellings while converting
+func (s *ConvertService) SetCatalogue(catalogue *champions.Catalogue) {
+	s.catalogue = catalogue
+}
+
+// Convert decodes data in one format and encodes it in another
+func (s *ConvertService) Convert(data []byte, from, to string) ([]byte, error) {
+	records, err := DecodeDrafts(data, from)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.catalogue != nil {
+		for i, record := range records {
+			if err := s.catalogue.NormalizeDraft(record.Draft); err != nil {
+				return nil, fmt.Errorf("%w: record %d: %w", ErrInvalidConversion, i+1, err)
+			}
+		}
+	}
+
+	return EncodeDrafts(records, to)
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/champions"
+	"github.com/gvieiragoulart/draft-visualizer/internal/database"
+	"github.com/gvieiragoulart/draft-visualizer/internal/draft"
+)
+
+var (
+	ErrInvalidDiff       = errors.New("invalid draft comparison")
+	ErrDraftGameNotFound = errors.New("draft game not found")
+)
+
+// DraftSource names one draft to compare. Exactly one of Notation, Draft,
+// SessionID or GameID is used, in that order of preference. Game picks a
+// game of a session's series and defaults to the current one.
+type DraftSource struct {
+	Notation  string       `json:"notation"`
+	Draft     *draft.Draft `json:"draft"`
+	SessionID string       `json:"sessionId"`
+	Game      int          `json:"game"`
+	GameID    string       `json:"gameId"`
+}
+
+// DiffRequest compares draft A against draft B. Team aligns the sides of a
+// team that played both drafts.
+type DiffRequest struct {
+	A    DraftSource `json:"a"`
+	B    DraftSource `json:"b"`
+	Team string      `json:"team"`
+}
+
+// DraftDiff is a comparison together with the drafts that were compared
+type DraftDiff struct {
+	A *draft.Draft `json:"a"`
+	B *draft.Draft `json:"b"`
+	draft.Diff
+}
+
+// DiffService compares drafts from notation, posted boards, draft sessions
+// and stored pro games
+type DiffService struct {
+	sessions  *DraftSessionService
+	db        *database.Client
+	catalogue *champions.Catalogue
+}
+
+func NewDiffService(sessions *DraftSessionService, db *database.Client) *DiffService {
+	return &DiffService{
+		sessions: sessions,
+		db:       db,
+	}
+}
+
+// SetCatalogue normalizes champion spellings so the same champion matches
+// across sources
+func (s *DiffService) SetCatalogue(catalogue *champions.Catalogue) {
+	s.catalogue = catalogue
+}
+
+// Compare resolves both drafts and diffs them
+func (s *DiffService) Compare(ctx context.Context, req DiffRequest) (*DraftDiff, error) {
+	a, err := s.resolve(ctx, req.A)
+	if err != nil {
+		return nil, fmt.Errorf("draft a: %w", err)
+	}
+	b, err := s.resolve(ctx, req.B)
+	if err != nil {
+		return nil, fmt.Errorf("draft b: %w", err)
+	}
+
+	return &DraftDiff{A: a, B: b, Diff: draft.Compare(a, b, req.Team)}, nil
+}
+
+func (s *DiffService) resolve(ctx context.Context, source DraftSource) (*draft.Draft, error) {
+	return resolveDraftSource(ctx, s.sessions, s.db, s.catalogue, source, ErrInvalidDiff)
+}
+
+// resolveDraftSource loads the draft a source names, normalizing champion
+// names when a catalogue is given. Invalid sources are wrapped in errInvalid.
+func resolveDraftSource(ctx context.Context, sessions *DraftSessionService, db *database.Client, catalogue *champions.Catalogue, source DraftSource, errInvalid error) (*draft.Draft, error) {
+	var d *draft.Draft
+	switch {
+	case source.Notation != "":
+		records, err := draft.ParseNotation(source.Notation)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errInvalid, err)
+		}
+		if len(records) != 1 {
+			return nil, fmt.Errorf("%w: expected one draft in notation, got %d", errInvalid, len(records))
+		}
+		d = records[0].Draft
+	case source.Draft != nil:
+		if err := source.Draft.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: %w", errInvalid, err)
+		}
+		d = source.Draft
+	case source.SessionID != "":
+		board, err := sessionBoard(ctx, sessions, source.SessionID, source.Game, errInvalid)
+		if err != nil {
+			return nil, err
+		}
+		d = &draft.Draft{
+			Format:   board.Format,
+			BlueTeam: board.Blue.Team,
+			RedTeam:  board.Red.Team,
+			Actions:  board.Actions,
+			Swaps:    board.Swaps,
+		}
+	case source.GameID != "":
+		if db == nil {
+			return nil, ErrDatabaseUnavailable
+		}
+		record, err := db.GetDraftGame(source.GameID)
+		if err != nil {
+			return nil, err
+		}
+		if record == nil {
+			return nil, fmt.Errorf("%w: %s", ErrDraftGameNotFound, source.GameID)
+		}
+		if d, err = fromDraftGameRecord(*record).Draft(); err != nil {
+			return nil, fmt.Errorf("%w: %w", errInvalid, err)
+		}
+	default:
+		return nil, fmt.Errorf("%w: notation, draft, sessionId or gameId is required", errInvalid)
+	}
+
+	if catalogue != nil {
+		if err := catalogue.NormalizeDraft(d); err != nil {
+			return nil, fmt.Errorf("%w: %w", errInvalid, err)
+		}
+	}
+	return d, nil
+}
+
+// sessionBoard returns game n of a session's series, or its current game
+// when n is zero
+func sessionBoard(ctx context.Context, sessions *DraftSessionService, id string, n int, errInvalid error) (draft.Board, error) {
+	session, err := sessions.GetSession(ctx, id)
+	if err != nil {
+		return draft.Board{}, err
+	}
+
+	switch {
+	case n == 0 || n == session.GameNumber:
+		return session.Board, nil
+	case n > 0 && n <= len(session.History):
+		return session.History[n-1], nil
+	}
+	return draft.Board{}, fmt.Errorf("%w: session %s has no game %d", errInvalid, id, n)
+}
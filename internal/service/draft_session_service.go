@@ -0,0 +1,643 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/champions"
+	"github.com/gvieiragoulart/draft-visualizer/internal/draft"
+	"github.com/gvieiragoulart/draft-visualizer/internal/stream"
+)
+
+var (
+	ErrSessionNotFound = errors.New("draft session not found")
+	ErrIllegalAction   = errors.New("illegal draft action")
+	ErrInvalidSession  = errors.New("invalid draft session")
+	ErrTooManySessions = errors.New("too many draft sessions are open")
+)
+
+// DefaultMaxDraftSessions caps how many sessions are kept in memory at once
+const DefaultMaxDraftSessions = 1000
+
+// DefaultDraftSessionIdle is how long a session is kept after its last
+// action, swap, hover or game change
+const DefaultDraftSessionIdle = 2 * time.Hour
+
+// CreateDraftSessionRequest describes a new mock draft. BestOf and Ruleset
+// turn it into a series where the ruleset is enforced between games.
+type CreateDraftSessionRequest struct {
+	Team1     string     `json:"team1"`
+	Team2     string     `json:"team2"`
+	Format    string     `json:"format"`
+	Team1Side draft.Side `json:"team1Side"`
+	BestOf    int        `json:"bestOf"`
+	Ruleset   string     `json:"ruleset"`
+
+	// TurnSeconds starts a countdown for every turn; zero leaves the draft
+	// untimed
+	TurnSeconds int `json:"turnSeconds"`
+
+	// MatchId takes the patch and disabled champions from a Leaguepedia
+	// match. Otherwise Patch and DisabledChampions set them directly; either
+	// way champions outside the legal pool cannot be banned or picked.
+	MatchId           string   `json:"matchId"`
+	Patch             string   `json:"patch"`
+	DisabledChampions []string `json:"disabledChampions"`
+}
+
+// NextGameRequest closes the current game of a series and opens the next one
+type NextGameRequest struct {
+	Winner    string     `json:"winner"`
+	Team1Side draft.Side `json:"team1Side"`
+}
+
+// SwapRequest trades two champions picked by the same side
+type SwapRequest struct {
+	Side      draft.Side `json:"side"`
+	Champions [2]string  `json:"champions"`
+}
+
+// HoverRequest shows the champion the side to play is considering. An empty
+// champion clears the hover.
+type HoverRequest struct {
+	Side     draft.Side `json:"side"`
+	Champion string     `json:"champion"`
+}
+
+// DraftTimeline is the step by step replay of one game of a session
+type DraftTimeline struct {
+	SessionID  string                `json:"sessionId"`
+	GameNumber int                   `json:"gameNumber"`
+	BlueTeam   string                `json:"blueTeam"`
+	RedTeam    string                `json:"redTeam"`
+	Complete   bool                  `json:"complete"`
+	Entries    []draft.TimelineEntry `json:"entries"`
+}
+
+// TurnTimer is published whenever a timed turn starts or runs out
+type TurnTimer struct {
+	Turn     draft.Turn `json:"turn"`
+	Index    int        `json:"index"`
+	Seconds  int        `json:"seconds"`
+	Deadline time.Time  `json:"deadline"`
+}
+
+// DraftEvent is the payload of ban, pick, swap and hover events
+type DraftEvent struct {
+	GameNumber int           `json:"gameNumber"`
+	Action     *draft.Action `json:"action,omitempty"`
+	Swap       *draft.Swap   `json:"swap,omitempty"`
+	Hover      *draft.Hover  `json:"hover,omitempty"`
+	Board      draft.Board   `json:"board"`
+	At         *time.Time    `json:"at,omitempty"`
+}
+
+// DraftTopic is the stream topic a session publishes its events on
+func DraftTopic(id string) string {
+	return "draft:" + id
+}
+
+// DraftSession is a snapshot of a mock draft run through the API
+type DraftSession struct {
+	ID         string              `json:"id"`
+	Team1      string              `json:"team1"`
+	Team2      string              `json:"team2"`
+	Team1Side  draft.Side          `json:"team1Side"`
+	BestOf     int                 `json:"bestOf"`
+	Ruleset    draft.Ruleset       `json:"ruleset"`
+	GameNumber int                 `json:"gameNumber"`
+	Score      map[string]int      `json:"score"`
+	SeriesOver bool                `json:"seriesOver"`
+	Locked     map[string][]string `json:"locked"`
+	Board      draft.Board         `json:"board"`
+	History    []draft.Board       `json:"history"`
+	Deadline   *time.Time          `json:"turnDeadline,omitempty"`
+	MatchId    string              `json:"matchId,omitempty"`
+	Patch      string              `json:"patch,omitempty"`
+	CreatedAt  time.Time           `json:"createdAt"`
+	UpdatedAt  time.Time           `json:"updatedAt"`
+}
+
+type draftSession struct {
+	mu          sync.Mutex
+	id          string
+	format      draft.Format
+	series      *draft.Series
+	broker      *stream.Broker
+	turnSeconds int
+	timer       *time.Timer
+	deadline    *time.Time
+	pool        *ChampionPool
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+func (s *draftSession) snapshot() *DraftSession {
+	current := s.series.Current()
+	history := []draft.Board{}
+	for _, game := range s.series.Games[:len(s.series.Games)-1] {
+		history = append(history, game.Board())
+	}
+
+	team1Side := draft.Blue
+	if current.BlueTeam != s.series.Team1 {
+		team1Side = draft.Red
+	}
+
+	snapshot := &DraftSession{
+		ID:         s.id,
+		Team1:      s.series.Team1,
+		Team2:      s.series.Team2,
+		Team1Side:  team1Side,
+		BestOf:     s.series.BestOf,
+		Ruleset:    s.series.Ruleset,
+		GameNumber: len(s.series.Games),
+		Score: map[string]int{
+			s.series.Team1: s.series.Wins(s.series.Team1),
+			s.series.Team2: s.series.Wins(s.series.Team2),
+		},
+		SeriesOver: current.Complete() && s.series.Over(),
+		Locked:     s.series.LockedChampions(),
+		Board:      current.Board(),
+		History:    history,
+		Deadline:   s.deadline,
+		CreatedAt:  s.createdAt,
+		UpdatedAt:  s.updatedAt,
+	}
+	if s.pool != nil {
+		snapshot.MatchId = s.pool.MatchId
+		snapshot.Patch = s.pool.Patch
+	}
+	return snapshot
+}
+
+// startGame opens the next game with team1 on the requested side
+func (s *draftSession) startGame(team1Side draft.Side) error {
+	if team1Side == "" {
+		team1Side = draft.Blue
+	}
+	if !team1Side.Valid() {
+		return fmt.Errorf("%w: team1Side must be blue or red", ErrInvalidSession)
+	}
+
+	blueTeam := s.series.Team1
+	if team1Side == draft.Red {
+		blueTeam = s.series.Team2
+	}
+
+	game, err := s.series.StartGame(blueTeam)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrIllegalAction, err)
+	}
+	game.Format = s.format
+	game.Start(time.Now())
+
+	s.publish("game_start", DraftEvent{GameNumber: len(s.series.Games), Board: game.Board()})
+	s.startTurnTimer()
+	return nil
+}
+
+// idle reports whether the session was last changed more than timeout ago
+func (s *draftSession) idle(now time.Time, timeout time.Duration) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return now.Sub(s.updatedAt) > timeout
+}
+
+// close stops the session's turn timer
+func (s *draftSession) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+}
+
+func (s *draftSession) publish(eventType string, data interface{}) {
+	if s.broker != nil {
+		s.broker.Publish(DraftTopic(s.id), eventType, data)
+	}
+}
+
+// startTurnTimer publishes the countdown of the turn about to be played and
+// a timer_expired event if nobody acts before the deadline. Callers must
+// hold the session lock.
+func (s *draftSession) startTurnTimer() {
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	s.deadline = nil
+
+	game := s.series.Current()
+	turn, ok := game.NextTurn()
+	if s.turnSeconds <= 0 || !ok {
+		return
+	}
+
+	duration := time.Duration(s.turnSeconds) * time.Second
+	deadline := time.Now().Add(duration)
+	s.deadline = &deadline
+
+	timer := TurnTimer{
+		Turn:     turn,
+		Index:    len(game.Actions),
+		Seconds:  s.turnSeconds,
+		Deadline: deadline,
+	}
+	s.publish("timer", timer)
+
+	s.timer = time.AfterFunc(duration, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		// Only report turns that are still waiting for an action
+		if s.series.Current() == game && len(game.Actions) == timer.Index {
+			s.publish("timer_expired", timer)
+		}
+	})
+}
+
+// DraftSessionService keeps interactive draft sessions in memory. Sessions
+// left idle for idleTimeout are forgotten, and no more than maxSessions are
+// kept at once.
+type DraftSessionService struct {
+	mu          sync.RWMutex
+	sessions    map[string]*draftSession
+	broker      *stream.Broker
+	catalogue   *champions.Catalogue
+	pools       *PoolService
+	maxSessions int
+	idleTimeout time.Duration
+}
+
+func NewDraftSessionService() *DraftSessionService {
+	return NewDraftSessionServiceWithBroker(nil)
+}
+
+// NewDraftSessionServiceWithBroker creates a service that publishes every
+// session event to the broker for streaming
+func NewDraftSessionServiceWithBroker(broker *stream.Broker) *DraftSessionService {
+	return &DraftSessionService{
+		sessions:    make(map[string]*draftSession),
+		broker:      broker,
+		maxSessions: DefaultMaxDraftSessions,
+		idleTimeout: DefaultDraftSessionIdle,
+	}
+}
+
+// SetCatalogue makes the service resolve every submitted champion against
+// the catalogue, so "kaisa", "Kaisa" and "Kai'Sa" are stored the same way
+// and unknown champions are rejected
+func (s *DraftSessionService) SetCatalogue(catalogue *champions.Catalogue) {
+	s.catalogue = catalogue
+}
+
+// SetPools lets sessions take their champion pool from a Leaguepedia match
+func (s *DraftSessionService) SetPools(pools *PoolService) {
+	s.pools = pools
+}
+
+// pool resolves the legal champion pool of a new session, or nil when the
+// request does not restrict it
+func (s *DraftSessionService) pool(req CreateDraftSessionRequest) (*ChampionPool, error) {
+	if req.MatchId != "" {
+		if s.pools == nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidSession, ErrNoPoolSchedule)
+		}
+		pool, err := s.pools.GetMatchPool(req.MatchId)
+		if errors.Is(err, ErrMatchNotFound) {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidSession, err)
+		}
+		return pool, err
+	}
+	if req.Patch == "" && len(req.DisabledChampions) == 0 {
+		return nil, nil
+	}
+	return BuildChampionPool(s.catalogue, req.Patch, req.DisabledChampions), nil
+}
+
+// canonical returns the catalogue spelling of a champion, or the name as
+// given when no catalogue is set
+func (s *DraftSessionService) canonical(name string) (string, error) {
+	if s.catalogue == nil {
+		return name, nil
+	}
+	canonical, err := s.catalogue.Canonical(name)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrIllegalAction, err)
+	}
+	return canonical, nil
+}
+
+// CreateSession starts a series between two teams and opens its first draft
+func (s *DraftSessionService) CreateSession(ctx context.Context, req CreateDraftSessionRequest) (*DraftSession, error) {
+	if req.Team1 == "" || req.Team2 == "" {
+		return nil, fmt.Errorf("%w: team1 and team2 are required", ErrInvalidSession)
+	}
+	if req.Team1 == req.Team2 {
+		return nil, fmt.Errorf("%w: team1 and team2 must be different", ErrInvalidSession)
+	}
+
+	format, err := draft.ParseFormat(req.Format)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSession, err)
+	}
+
+	ruleset, err := draft.ParseRuleset(req.Ruleset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSession, err)
+	}
+
+	bestOf := req.BestOf
+	if bestOf == 0 {
+		bestOf = 1
+	}
+	series, err := draft.NewSeries(req.Team1, req.Team2, bestOf, ruleset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSession, err)
+	}
+
+	id, err := newSessionID()
+	if err != nil {
+		return nil, fmt.Errorf("error creating session id: %w", err)
+	}
+
+	if req.TurnSeconds < 0 {
+		return nil, fmt.Errorf("%w: turnSeconds cannot be negative", ErrInvalidSession)
+	}
+
+	pool, err := s.pool(req)
+	if err != nil {
+		return nil, err
+	}
+	if pool != nil {
+		series.Disabled = pool.Illegal()
+	}
+
+	now := time.Now()
+	session := &draftSession{
+		id:          id,
+		format:      format,
+		series:      series,
+		broker:      s.broker,
+		turnSeconds: req.TurnSeconds,
+		pool:        pool,
+		createdAt:   now,
+		updatedAt:   now,
+	}
+	if err := session.startGame(req.Team1Side); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.expireIdle(now)
+	if len(s.sessions) >= s.maxSessions {
+		s.mu.Unlock()
+		session.close()
+		if s.broker != nil {
+			s.broker.Delete(DraftTopic(id))
+		}
+		return nil, fmt.Errorf("%w: at most %d", ErrTooManySessions, s.maxSessions)
+	}
+	s.sessions[id] = session
+	s.mu.Unlock()
+
+	return session.snapshot(), nil
+}
+
+// GetSession returns the current board of a session
+func (s *DraftSessionService) GetSession(ctx context.Context, id string) (*DraftSession, error) {
+	session, err := s.session(id)
+	if err != nil {
+		return nil, err
+	}
+
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	return session.snapshot(), nil
+}
+
+// SubmitAction validates a ban or pick against the draft order and the
+// series ruleset, then records it
+func (s *DraftSessionService) SubmitAction(ctx context.Context, id string, action draft.Action) (*DraftSession, error) {
+	session, err := s.session(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if action.Champion, err = s.canonical(action.Champion); err != nil {
+		return nil, err
+	}
+
+	session.mu.Lock()
+	defer session.mu.Unlock()
+
+	now := time.Now()
+	game := session.series.Current()
+	if err := game.ApplyAt(action, now); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrIllegalAction, err)
+	}
+	session.updatedAt = now
+
+	recorded := game.Actions[len(game.Actions)-1]
+	session.publish(string(recorded.Type), DraftEvent{
+		GameNumber: len(session.series.Games),
+		Action:     &recorded,
+		Board:      game.Board(),
+		At:         &now,
+	})
+	session.startTurnTimer()
+
+	return session.snapshot(), nil
+}
+
+// SubmitSwap trades two champions between teammates of the current game
+func (s *DraftSessionService) SubmitSwap(ctx context.Context, id string, req SwapRequest) (*DraftSession, error) {
+	session, err := s.session(id)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, champion := range req.Champions {
+		if req.Champions[i], err = s.canonical(champion); err != nil {
+			return nil, err
+		}
+	}
+
+	session.mu.Lock()
+	defer session.mu.Unlock()
+
+	now := time.Now()
+	game := session.series.Current()
+	if err := game.SwapAt(req.Side, req.Champions[0], req.Champions[1], now); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrIllegalAction, err)
+	}
+	session.updatedAt = now
+
+	swap := game.Swaps[len(game.Swaps)-1]
+	session.publish("swap", DraftEvent{
+		GameNumber: len(session.series.Games),
+		Swap:       &swap,
+		Board:      game.Board(),
+		At:         &now,
+	})
+
+	return session.snapshot(), nil
+}
+
+// SubmitHover records the champion the side to play is hovering, so the
+// game's timeline shows what was considered before each lock in
+func (s *DraftSessionService) SubmitHover(ctx context.Context, id string, req HoverRequest) (*DraftSession, error) {
+	session, err := s.session(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Champion != "" {
+		if req.Champion, err = s.canonical(req.Champion); err != nil {
+			return nil, err
+		}
+	}
+
+	session.mu.Lock()
+	defer session.mu.Unlock()
+
+	now := time.Now()
+	game := session.series.Current()
+	if err := game.HoverChampion(req.Side, req.Champion, now); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrIllegalAction, err)
+	}
+	session.updatedAt = now
+
+	hover := game.Timing.Hovers[len(game.Timing.Hovers)-1]
+	session.publish("hover", DraftEvent{
+		GameNumber: len(session.series.Games),
+		Hover:      &hover,
+		Board:      game.Board(),
+		At:         &now,
+	})
+
+	return session.snapshot(), nil
+}
+
+// GetTimeline returns the timed replay of game n of a session's series, or
+// of its current game when n is zero
+func (s *DraftSessionService) GetTimeline(ctx context.Context, id string, n int) (*DraftTimeline, error) {
+	session, err := s.session(id)
+	if err != nil {
+		return nil, err
+	}
+
+	session.mu.Lock()
+	defer session.mu.Unlock()
+
+	games := session.series.Games
+	if n == 0 {
+		n = len(games)
+	}
+	if n < 1 || n > len(games) {
+		return nil, fmt.Errorf("%w: session %s has no game %d", ErrInvalidSession, id, n)
+	}
+
+	game := games[n-1]
+	return &DraftTimeline{
+		SessionID:  id,
+		GameNumber: n,
+		BlueTeam:   game.BlueTeam,
+		RedTeam:    game.RedTeam,
+		Complete:   game.Complete(),
+		Entries:    game.Timeline(),
+	}, nil
+}
+
+// NextGame records the winner of the finished game and opens the next draft
+// of the series. When the winner clinches the series no new game is opened.
+func (s *DraftSessionService) NextGame(ctx context.Context, id string, req NextGameRequest) (*DraftSession, error) {
+	session, err := s.session(id)
+	if err != nil {
+		return nil, err
+	}
+
+	session.mu.Lock()
+	defer session.mu.Unlock()
+
+	if !session.series.Current().Complete() {
+		return nil, fmt.Errorf("%w: %w", ErrIllegalAction, draft.ErrGameInProgress)
+	}
+	if req.Team1Side != "" && !req.Team1Side.Valid() {
+		return nil, fmt.Errorf("%w: team1Side must be blue or red", ErrInvalidSession)
+	}
+	if req.Winner == "" {
+		if session.series.Over() {
+			return nil, fmt.Errorf("%w: %w", ErrIllegalAction, draft.ErrSeriesOver)
+		}
+		return nil, fmt.Errorf("%w: winner is required", ErrInvalidSession)
+	}
+
+	if err := session.series.RecordWinner(req.Winner); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrIllegalAction, err)
+	}
+	if !session.series.Over() {
+		if err := session.startGame(req.Team1Side); err != nil {
+			return nil, err
+		}
+	} else {
+		session.publish("series_end", session.snapshot())
+	}
+	session.updatedAt = time.Now()
+
+	return session.snapshot(), nil
+}
+
+// GetLockedChampions returns the champions each team can no longer pick
+func (s *DraftSessionService) GetLockedChampions(ctx context.Context, id string) (map[string][]string, error) {
+	session, err := s.session(id)
+	if err != nil {
+		return nil, err
+	}
+
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	return session.series.LockedChampions(), nil
+}
+
+func (s *DraftSessionService) session(id string) (*draftSession, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	session, ok := s.sessions[id]
+	if !ok || session.idle(time.Now(), s.idleTimeout) {
+		return nil, fmt.Errorf("%w: %s", ErrSessionNotFound, id)
+	}
+	return session, nil
+}
+
+// expireIdle forgets the sessions nobody has touched for idleTimeout, along
+// with their turn timers and stream topics. Callers must hold the write lock.
+func (s *DraftSessionService) expireIdle(now time.Time) {
+	for id, session := range s.sessions {
+		if !session.idle(now, s.idleTimeout) {
+			continue
+		}
+		session.close()
+		delete(s.sessions, id)
+		if s.broker != nil {
+			s.broker.Delete(DraftTopic(id))
+		}
+	}
+}
+
+func newSessionID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
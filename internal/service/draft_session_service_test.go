@@ -0,0 +1,344 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/champions"
+	"github.com/gvieiragoulart/draft-visualizer/internal/draft"
+	"github.com/gvieiragoulart/draft-visualizer/internal/stream"
+)
+
+func TestCreateSession(t *testing.T) {
+	svc := NewDraftSessionService()
+	ctx := context.Background()
+
+	session, err := svc.CreateSession(ctx, CreateDraftSessionRequest{
+		Team1:     "T1",
+		Team2:     "Gen.G",
+		Team1Side: draft.Red,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if session.ID == "" {
+		t.Error("expected session to have an id")
+	}
+	if session.Board.Blue.Team != "Gen.G" || session.Board.Red.Team != "T1" {
+		t.Errorf("expected Gen.G on blue and T1 on red, got %s and %s", session.Board.Blue.Team, session.Board.Red.Team)
+	}
+	if session.Board.Format != draft.FormatTournament {
+		t.Errorf("expected default format to be tournament, got %s", session.Board.Format)
+	}
+	if session.Board.NextTurn == nil || session.Board.NextTurn.Side != draft.Blue || session.Board.NextTurn.Type != draft.Ban {
+		t.Errorf("expected blue ban first, got %+v", session.Board.NextTurn)
+	}
+}
+
+func TestCreateSession_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		req  CreateDraftSessionRequest
+	}{
+		{name: "missing team", req: CreateDraftSessionRequest{Team1: "T1"}},
+		{name: "unknown format", req: CreateDraftSessionRequest{Team1: "T1", Team2: "Gen.G", Format: "all-random"}},
+		{name: "unknown side", req: CreateDraftSessionRequest{Team1: "T1", Team2: "Gen.G", Team1Side: "purple"}},
+	}
+
+	svc := NewDraftSessionService()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.CreateSession(context.Background(), tt.req)
+			if !errors.Is(err, ErrInvalidSession) {
+				t.Errorf("expected ErrInvalidSession, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSubmitAction(t *testing.T) {
+	svc := NewDraftSessionService()
+	ctx := context.Background()
+
+	session, err := svc.CreateSession(ctx, CreateDraftSessionRequest{Team1: "T1", Team2: "Gen.G"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if _, err := svc.SubmitAction(ctx, session.ID, draft.Action{Side: draft.Blue, Type: draft.Ban, Champion: "Azir"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	_, err = svc.SubmitAction(ctx, session.ID, draft.Action{Side: draft.Blue, Type: draft.Ban, Champion: "Ahri"})
+	if !errors.Is(err, ErrIllegalAction) || !errors.Is(err, draft.ErrWrongSide) {
+		t.Errorf("expected illegal action for wrong side, got %v", err)
+	}
+
+	_, err = svc.SubmitAction(ctx, session.ID, draft.Action{Side: draft.Red, Type: draft.Ban, Champion: "Azir"})
+	if !errors.Is(err, draft.ErrDuplicateChampion) {
+		t.Errorf("expected duplicate champion error, got %v", err)
+	}
+
+	current, err := svc.GetSession(ctx, session.ID)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(current.Board.Actions) != 1 || current.Board.Blue.Bans[0] != "Azir" {
+		t.Errorf("expected only Azir to be banned, got %+v", current.Board.Actions)
+	}
+}
+
+func TestGetSession_NotFound(t *testing.T) {
+	svc := NewDraftSessionService()
+	_, err := svc.GetSession(context.Background(), "missing")
+	if !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("expected ErrSessionNotFound, got %v", err)
+	}
+}
+
+func TestCreateSession_ExpiresIdleSessions(t *testing.T) {
+	broker := stream.NewBroker(10)
+	svc := NewDraftSessionServiceWithBroker(broker)
+	svc.maxSessions = 1
+	ctx := context.Background()
+
+	first, err := svc.CreateSession(ctx, CreateDraftSessionRequest{Team1: "T1", Team2: "Gen.G", TurnSeconds: 30})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := svc.CreateSession(ctx, CreateDraftSessionRequest{Team1: "T1", Team2: "Gen.G"}); !errors.Is(err, ErrTooManySessions) {
+		t.Fatalf("expected ErrTooManySessions, got %v", err)
+	}
+
+	session, _ := svc.session(first.ID)
+	session.mu.Lock()
+	session.updatedAt = time.Now().Add(-DefaultDraftSessionIdle - time.Minute)
+	session.mu.Unlock()
+
+	if _, err := svc.GetSession(ctx, first.ID); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("expected an idle session to be gone, got %v", err)
+	}
+	if _, err := svc.CreateSession(ctx, CreateDraftSessionRequest{Team1: "T1", Team2: "Gen.G"}); err != nil {
+		t.Fatalf("expected the idle session to make room, got %v", err)
+	}
+	if len(svc.sessions) != 1 || session.timer != nil || len(broker.History(DraftTopic(first.ID))) != 0 {
+		t.Errorf("expected the idle session, its timer and its topic to be dropped")
+	}
+}
+
+func TestNextGame_Fearless(t *testing.T) {
+	svc := NewDraftSessionService()
+	ctx := context.Background()
+
+	session, err := svc.CreateSession(ctx, CreateDraftSessionRequest{
+		Team1:   "T1",
+		Team2:   "Gen.G",
+		BestOf:  3,
+		Ruleset: "fearless",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if _, err := svc.NextGame(ctx, session.ID, NextGameRequest{Winner: "T1"}); !errors.Is(err, draft.ErrGameInProgress) {
+		t.Errorf("expected ErrGameInProgress, got %v", err)
+	}
+
+	champions := []string{
+		"Azir", "Kalista", "Ahri", "Varus", "Rell", "Orianna",
+		"Ashe", "Xayah", "Maokai", "Sejuani", "Taliyah", "Corki",
+		"Jax", "Vi", "Rakan", "Nautilus",
+		"Jayce", "K'Sante", "Lulu", "Braum",
+	}
+	for i, turn := range draft.TournamentOrder {
+		action := draft.Action{Side: turn.Side, Type: turn.Type, Champion: champions[i]}
+		if _, err := svc.SubmitAction(ctx, session.ID, action); err != nil {
+			t.Fatalf("action %d: expected no error, got %v", i+1, err)
+		}
+	}
+
+	if _, err := svc.NextGame(ctx, session.ID, NextGameRequest{}); !errors.Is(err, ErrInvalidSession) {
+		t.Errorf("expected a missing winner to be rejected, got %v", err)
+	}
+
+	next, err := svc.NextGame(ctx, session.ID, NextGameRequest{Winner: "T1", Team1Side: draft.Red})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if next.GameNumber != 2 || next.Score["T1"] != 1 || next.Board.Blue.Team != "Gen.G" {
+		t.Errorf("expected game 2 with Gen.G on blue and T1 up 1-0, got %+v", next)
+	}
+	if len(next.Locked["Gen.G"]) != 10 {
+		t.Errorf("expected 10 locked champions, got %v", next.Locked["Gen.G"])
+	}
+
+	for i := 0; i < 6; i++ {
+		turn := draft.TournamentOrder[i]
+		svc.SubmitAction(ctx, session.ID, draft.Action{Side: turn.Side, Type: turn.Type, Champion: champions[i]})
+	}
+	_, err = svc.SubmitAction(ctx, session.ID, draft.Action{Side: draft.Blue, Type: draft.Pick, Champion: "Xayah"})
+	if !errors.Is(err, ErrIllegalAction) || !errors.Is(err, draft.ErrChampionLocked) {
+		t.Errorf("expected locked champion to be rejected, got %v", err)
+	}
+}
+
+func TestSubmitAction_PublishesEvents(t *testing.T) {
+	broker := stream.NewBroker(100)
+	svc := NewDraftSessionServiceWithBroker(broker)
+	ctx := context.Background()
+
+	session, err := svc.CreateSession(ctx, CreateDraftSessionRequest{Team1: "T1", Team2: "Gen.G", TurnSeconds: 30})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if session.Deadline == nil {
+		t.Error("expected a timed session to expose the turn deadline")
+	}
+
+	sub := broker.Subscribe(DraftTopic(session.ID), 0)
+	defer sub.Close()
+
+	svc.SubmitAction(ctx, session.ID, draft.Action{Side: draft.Blue, Type: draft.Ban, Champion: "Azir"})
+
+	var types []string
+	for len(types) < 4 {
+		select {
+		case event := <-sub.Events:
+			types = append(types, event.Type)
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for events, got %v", types)
+		}
+	}
+	expected := []string{"game_start", "timer", "ban", "timer"}
+	for i := range expected {
+		if types[i] != expected[i] {
+			t.Fatalf("expected events %v, got %v", expected, types)
+		}
+	}
+}
+
+func TestSubmitAction_NormalizesChampions(t *testing.T) {
+	catalogue, err := champions.LoadBundled()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	svc := NewDraftSessionService()
+	svc.SetCatalogue(catalogue)
+	ctx := context.Background()
+
+	session, err := svc.CreateSession(ctx, CreateDraftSessionRequest{Team1: "T1", Team2: "Gen.G"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	session, err = svc.SubmitAction(ctx, session.ID, draft.Action{Side: draft.Blue, Type: draft.Ban, Champion: "kaisa"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got := session.Board.Blue.Bans[0]; got != "Kai'Sa" {
+		t.Errorf("expected Kai'Sa, got %s", got)
+	}
+
+	_, err = svc.SubmitAction(ctx, session.ID, draft.Action{Side: draft.Red, Type: draft.Ban, Champion: "Kai'Sa"})
+	if !errors.Is(err, draft.ErrDuplicateChampion) {
+		t.Errorf("expected ErrDuplicateChampion, got %v", err)
+	}
+
+	_, err = svc.SubmitAction(ctx, session.ID, draft.Action{Side: draft.Red, Type: draft.Ban, Champion: "Not A Champion"})
+	if !errors.Is(err, champions.ErrUnknownChampion) || !errors.Is(err, ErrIllegalAction) {
+		t.Errorf("expected ErrIllegalAction wrapping ErrUnknownChampion, got %v", err)
+	}
+}
+
+func TestCreateSession_PatchPool(t *testing.T) {
+	catalogue, err := champions.LoadBundled()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	svc := NewDraftSessionService()
+	svc.SetCatalogue(catalogue)
+	ctx := context.Background()
+
+	session, err := svc.CreateSession(ctx, CreateDraftSessionRequest{
+		Team1:             "T1",
+		Team2:             "Gen.G",
+		Patch:             "14.8",
+		DisabledChampions: []string{"skarner"},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if session.Patch != "14.8" {
+		t.Errorf("expected patch 14.8, got %s", session.Patch)
+	}
+
+	for _, champion := range []string{"Skarner", "Aurora"} {
+		_, err = svc.SubmitAction(ctx, session.ID, draft.Action{Side: draft.Blue, Type: draft.Ban, Champion: champion})
+		if !errors.Is(err, draft.ErrChampionDisabled) || !errors.Is(err, ErrIllegalAction) {
+			t.Errorf("%s: expected ErrIllegalAction wrapping ErrChampionDisabled, got %v", champion, err)
+		}
+	}
+
+	if _, err := svc.SubmitAction(ctx, session.ID, draft.Action{Side: draft.Blue, Type: draft.Ban, Champion: "Smolder"}); err != nil {
+		t.Errorf("expected Smolder to be legal on 14.8, got %v", err)
+	}
+}
+
+func TestCreateSession_MatchWithoutSchedule(t *testing.T) {
+	svc := NewDraftSessionService()
+
+	_, err := svc.CreateSession(context.Background(), CreateDraftSessionRequest{Team1: "T1", Team2: "Gen.G", MatchId: "match-1"})
+	if !errors.Is(err, ErrInvalidSession) {
+		t.Errorf("expected ErrInvalidSession, got %v", err)
+	}
+}
+
+func TestGetTimeline(t *testing.T) {
+	svc := NewDraftSessionService()
+	ctx := context.Background()
+
+	session, err := svc.CreateSession(ctx, CreateDraftSessionRequest{Team1: "T1", Team2: "Gen.G"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if _, err := svc.SubmitHover(ctx, session.ID, HoverRequest{Side: draft.Blue, Champion: "Orianna"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := svc.SubmitHover(ctx, session.ID, HoverRequest{Side: draft.Red, Champion: "Azir"}); !errors.Is(err, ErrIllegalAction) {
+		t.Errorf("expected illegal action hovering out of turn, got %v", err)
+	}
+	if _, err := svc.SubmitAction(ctx, session.ID, draft.Action{Side: draft.Blue, Type: draft.Ban, Champion: "Azir"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	timeline, err := svc.GetTimeline(ctx, session.ID, 0)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if timeline.GameNumber != 1 || len(timeline.Entries) != 3 {
+		t.Fatalf("expected start, hover and ban in game 1, got %+v", timeline)
+	}
+
+	kinds := []string{draft.TimelineStart, draft.TimelineHover, draft.TimelineBan}
+	for i, entry := range timeline.Entries {
+		if entry.Kind != kinds[i] {
+			t.Errorf("step %d: expected %s, got %s", i, kinds[i], entry.Kind)
+		}
+		if entry.At == nil || entry.Elapsed == nil {
+			t.Errorf("step %d: expected a timestamp, got %+v", i, entry)
+		}
+	}
+	if ban := timeline.Entries[2]; ban.TurnTime == nil || ban.Champion != "Azir" {
+		t.Errorf("expected Azir with its turn time, got %+v", ban)
+	}
+
+	if _, err := svc.GetTimeline(ctx, session.ID, 2); !errors.Is(err, ErrInvalidSession) {
+		t.Errorf("expected ErrInvalidSession for a game not played, got %v", err)
+	}
+}
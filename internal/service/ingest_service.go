@@ -0,0 +1,509 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/database"
+	"github.com/gvieiragoulart/draft-visualizer/internal/riot"
+)
+
+var (
+	ErrInvalidIngestRequest = errors.New("invalid ingest request")
+	ErrIngestJobNotFound    = errors.New("ingest job not found")
+)
+
+// DefaultIngestConcurrency is how many matches a job fetches at once
+const DefaultIngestConcurrency = 4
+
+// MaxIngestConcurrency caps how many matches a job fetches at once, since
+// every fetch shares the client's rate limit
+const MaxIngestConcurrency = 16
+
+// ingestJobRetention is how long a finished job's progress can still be
+// read before it is dropped
+const ingestJobRetention = time.Hour
+
+// maxIngestErrors caps how many failures a job reports in its progress
+const maxIngestErrors = 20
+
+// ingestCheckpointOverlap is how far before the listing a checkpoint is
+// placed. Match lists filter on when a game started, and a game still in
+// progress when the history was listed only shows up once it ends, so the
+// next crawl lists again from further back than the longest game.
+const ingestCheckpointOverlap = 6 * time.Hour
+
+// Ingest job statuses
+const (
+	IngestRunning  = "running"
+	IngestDone     = "done"
+	IngestFailed   = "failed"
+	IngestCanceled = "canceled"
+)
+
+// IngestRequest names the players whose match history to crawl. Players
+// are PUUIDs or Riot IDs (gameName#tagLine); Riot IDs are looked up on
+// Platform, which also picks the region histories are listed from unless
+// Region is set. Queue, Type and StartTime narrow the matches down like
+// the /matches filters.
+type IngestRequest struct {
+	Players     []string  `json:"players"`
+	Platform    string    `json:"platform"`
+	Region      string    `json:"region"`
+	Queue       *int      `json:"queue"`
+	Type        string    `json:"type"`
+	StartTime   time.Time `json:"startTime"`
+	Concurrency int       `json:"concurrency"`
+}
+
+// IngestProgress is a snapshot of an ingest job
+type IngestProgress struct {
+	ID         string     `json:"id"`
+	Status     string     `json:"status"`
+	Players    int        `json:"players"`
+	Crawled    int        `json:"playersCrawled"`
+	Found      int        `json:"matchesFound"`
+	Stored     int        `json:"matchesStored"`
+	Skipped    int        `json:"matchesSkipped"`
+	Failed     int        `json:"matchesFailed"`
+	Errors     []string   `json:"errors,omitempty"`
+	StartedAt  time.Time  `json:"startedAt"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+}
+
+// IngestService crawls players' match histories into the matches table. A
+// match already stored is never fetched again, and every fully crawled
+// player gets a checkpoint so the next crawl only lists newer matches, so
+// an interrupted job is resumed by running it again.
+type IngestService struct {
+	riotClient *riot.Client
+	db         *database.Client
+	now        func() time.Time
+
+	mu   sync.Mutex
+	jobs map[string]*ingestJob
+}
+
+type ingestJob struct {
+	mu       sync.Mutex
+	progress IngestProgress
+	cancel   context.CancelFunc
+}
+
+// NewIngestService creates a new ingest service
+func NewIngestService(riotClient *riot.Client, db *database.Client) *IngestService {
+	return &IngestService{
+		riotClient: riotClient,
+		db:         db,
+		now:        time.Now,
+		jobs:       map[string]*ingestJob{},
+	}
+}
+
+// Start validates the request and runs the job in the background,
+// returning its first progress snapshot
+func (s *IngestService) Start(req IngestRequest) (IngestProgress, error) {
+	if err := s.validate(req); err != nil {
+		return IngestProgress{}, err
+	}
+	if s.db == nil {
+		return IngestProgress{}, ErrDatabaseUnavailable
+	}
+
+	id, err := newSessionID()
+	if err != nil {
+		return IngestProgress{}, fmt.Errorf("failed to create job id: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	job := &ingestJob{
+		progress: IngestProgress{ID: id, Status: IngestRunning, Players: len(req.Players), StartedAt: time.Now()},
+		cancel:   cancel,
+	}
+	s.mu.Lock()
+	s.prune()
+	s.jobs[id] = job
+	s.mu.Unlock()
+
+	go func() {
+		defer cancel()
+		s.run(ctx, req, job, nil)
+	}()
+	return job.snapshot(), nil
+}
+
+// Run crawls synchronously, calling report after every change of
+// progress. report may be called from several goroutines at once.
+func (s *IngestService) Run(ctx context.Context, req IngestRequest, report func(IngestProgress)) (IngestProgress, error) {
+	if err := s.validate(req); err != nil {
+		return IngestProgress{}, err
+	}
+	if s.db == nil {
+		return IngestProgress{}, ErrDatabaseUnavailable
+	}
+
+	job := &ingestJob{
+		progress: IngestProgress{Status: IngestRunning, Players: len(req.Players), StartedAt: time.Now()},
+	}
+	s.run(ctx, req, job, report)
+
+	progress := job.snapshot()
+	switch progress.Status {
+	case IngestCanceled:
+		return progress, ctx.Err()
+	case IngestFailed:
+		return progress, fmt.Errorf("ingest failed: %s", strings.Join(progress.Errors, "; "))
+	}
+	return progress, nil
+}
+
+// Progress returns a snapshot of a job started with Start
+func (s *IngestService) Progress(id string) (IngestProgress, error) {
+	job, err := s.job(id)
+	if err != nil {
+		return IngestProgress{}, err
+	}
+	return job.snapshot(), nil
+}
+
+// Cancel stops a running job. Matches stored so far are kept, and a
+// checkpoint is only written for players crawled in full.
+func (s *IngestService) Cancel(id string) (IngestProgress, error) {
+	job, err := s.job(id)
+	if err != nil {
+		return IngestProgress{}, err
+	}
+	job.cancel()
+	return job.snapshot(), nil
+}
+
+func (s *IngestService) job(id string) (*ingestJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prune()
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrIngestJobNotFound, id)
+	}
+	return job, nil
+}
+
+// prune drops the jobs finished more than ingestJobRetention ago. Callers
+// must hold s.mu.
+func (s *IngestService) prune() {
+	cutoff := s.now().Add(-ingestJobRetention)
+	for id, job := range s.jobs {
+		if finished := job.snapshot().FinishedAt; finished != nil && finished.Before(cutoff) {
+			delete(s.jobs, id)
+		}
+	}
+}
+
+func (s *IngestService) validate(req IngestRequest) error {
+	if len(req.Players) == 0 {
+		return fmt.Errorf("%w: at least one player is required", ErrInvalidIngestRequest)
+	}
+	if req.Concurrency < 0 {
+		return fmt.Errorf("%w: concurrency must not be negative", ErrInvalidIngestRequest)
+	}
+	if req.Platform != "" {
+		if _, err := riot.ParsePlatform(req.Platform); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidIngestRequest, err)
+		}
+	}
+	if req.Region != "" {
+		if _, err := riot.ParseRegion(req.Region); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidIngestRequest, err)
+		}
+	}
+	for _, player := range req.Players {
+		if strings.Contains(player, "#") && req.Platform == "" {
+			return fmt.Errorf("%w: platform is required to look up Riot ID %q", ErrInvalidIngestRequest, player)
+		}
+	}
+	filter := riot.MatchFilter{Queue: req.Queue, Type: req.Type, StartTime: req.StartTime}
+	if err := filter.Validate(); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidIngestRequest, err)
+	}
+	return nil
+}
+
+// ingestRun is the state of one pass over a job's players
+type ingestRun struct {
+	job         *ingestJob
+	platform    riot.Platform
+	region      riot.Region
+	filter      riot.MatchFilter
+	concurrency int
+	report      func(IngestProgress)
+
+	// seen holds the matches already handled, since players who played
+	// together share them
+	seen map[string]bool
+}
+
+func (r *ingestRun) notify() {
+	if r.report != nil {
+		r.report(r.job.snapshot())
+	}
+}
+
+// run crawls every player in turn, fetching their new matches with bounded
+// concurrency. report may be nil.
+func (s *IngestService) run(ctx context.Context, req IngestRequest, job *ingestJob, report func(IngestProgress)) {
+	run := &ingestRun{
+		job:         job,
+		region:      s.riotClient.Region(),
+		filter:      riot.MatchFilter{Queue: req.Queue, Type: req.Type, StartTime: req.StartTime},
+		concurrency: req.Concurrency,
+		report:      report,
+		seen:        map[string]bool{},
+	}
+	if req.Platform != "" {
+		run.platform, _ = riot.ParsePlatform(req.Platform)
+		run.region = run.platform.Region()
+	}
+	if req.Region != "" {
+		run.region, _ = riot.ParseRegion(req.Region)
+	}
+	if run.concurrency == 0 {
+		run.concurrency = DefaultIngestConcurrency
+	}
+	run.concurrency = min(run.concurrency, MaxIngestConcurrency)
+
+	for _, player := range req.Players {
+		if ctx.Err() != nil {
+			break
+		}
+
+		player = strings.TrimSpace(player)
+		if err := s.crawl(ctx, run, player); err != nil {
+			job.update(func(p *IngestProgress) { p.addError(fmt.Sprintf("%s: %v", player, err)) })
+		}
+		job.update(func(p *IngestProgress) { p.Crawled++ })
+		run.notify()
+	}
+
+	job.update(func(p *IngestProgress) {
+		now := s.now()
+		p.FinishedAt = &now
+		switch {
+		case ctx.Err() != nil:
+			p.Status = IngestCanceled
+		case p.Stored+p.Skipped == 0 && len(p.Errors) > 0:
+			p.Status = IngestFailed
+		default:
+			p.Status = IngestDone
+		}
+	})
+	run.notify()
+}
+
+// crawl stores one player's matches outside their checkpoint and widens it
+// once every match was stored
+func (s *IngestService) crawl(ctx context.Context, run *ingestRun, player string) error {
+	puuid := player
+	if strings.Contains(player, "#") {
+		account, err := s.riotClient.GetPlayerByRiotID(ctx, run.platform, player)
+		if err != nil {
+			return err
+		}
+		puuid = account.Account.PUUID
+	}
+
+	queue := database.AnyQueue
+	if run.filter.Queue != nil {
+		queue = *run.filter.Queue
+	}
+	checkpoint, err := s.db.GetIngestCheckpoint(puuid, string(run.region), queue, run.filter.Type)
+	if err != nil {
+		return err
+	}
+	from := run.filter.StartTime
+	// a checkpoint ending before the requested start leaves a gap it cannot
+	// describe, so the crawl starts over from the request
+	merge := checkpoint != nil && !from.After(checkpoint.CrawledUntil)
+	filters := []riot.MatchFilter{run.filter}
+	if merge {
+		filters = nil
+		if checkpoint.CrawledFrom != nil && (from.IsZero() || from.Before(*checkpoint.CrawledFrom)) {
+			earlier := run.filter
+			earlier.EndTime = *checkpoint.CrawledFrom
+			filters = append(filters, earlier)
+		}
+		newer := run.filter
+		newer.StartTime = checkpoint.CrawledUntil
+		filters = append(filters, newer)
+	}
+
+	listedAt := time.Now()
+	var pending []string
+	for _, filter := range filters {
+		matchIDs, err := s.riotClient.GetMatchesByPUUIDInRegion(ctx, run.region, puuid, filter)
+		if err != nil {
+			return err
+		}
+		for _, matchID := range matchIDs {
+			if !run.seen[matchID] {
+				run.seen[matchID] = true
+				pending = append(pending, matchID)
+			}
+		}
+	}
+	run.job.update(func(p *IngestProgress) { p.Found += len(pending) })
+	run.notify()
+
+	stored, failed := s.fetchMatches(ctx, run, pending)
+	if failed > 0 || ctx.Err() != nil {
+		// leave the checkpoint so the next run retries what is missing
+		return nil
+	}
+
+	var crawledFrom *time.Time
+	if !from.IsZero() {
+		crawledFrom = &from
+	}
+	crawledUntil := listedAt.Add(-ingestCheckpointOverlap)
+	matches := stored
+	if checkpoint != nil {
+		matches += checkpoint.Matches
+	}
+	if merge {
+		if checkpoint.CrawledFrom == nil || (crawledFrom != nil && checkpoint.CrawledFrom.Before(from)) {
+			crawledFrom = checkpoint.CrawledFrom
+		}
+		if checkpoint.CrawledUntil.After(crawledUntil) {
+			crawledUntil = checkpoint.CrawledUntil
+		}
+	}
+	return s.db.SaveIngestCheckpoint(&database.IngestCheckpoint{
+		PUUID:        puuid,
+		Region:       string(run.region),
+		Queue:        queue,
+		Type:         run.filter.Type,
+		CrawledFrom:  crawledFrom,
+		CrawledUntil: crawledUntil,
+		Matches:      matches,
+	})
+}
+
+// fetchMatches stores the matches not stored yet, at most run.concurrency
+// at a time, returning how many were stored and how many failed
+func (s *IngestService) fetchMatches(ctx context.Context, run *ingestRun, matchIDs []string) (int, int) {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	stored, failed := 0, 0
+	slots := make(chan struct{}, run.concurrency)
+
+	for _, matchID := range matchIDs {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return stored, failed
+		case slots <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(matchID string) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			saved, err := s.storeMatch(ctx, matchID)
+			if err != nil && ctx.Err() != nil {
+				// canceled mid-request; the next run fetches it
+				return
+			}
+			run.job.update(func(p *IngestProgress) {
+				switch {
+				case err != nil:
+					p.Failed++
+					p.addError(fmt.Sprintf("%s: %v", matchID, err))
+				case saved:
+					p.Stored++
+				default:
+					p.Skipped++
+				}
+			})
+			mu.Lock()
+			if err != nil {
+				failed++
+			} else if saved {
+				stored++
+			}
+			mu.Unlock()
+			run.notify()
+		}(matchID)
+	}
+	wg.Wait()
+	return stored, failed
+}
+
+// storeMatch fetches and saves a match unless it is already stored,
+// reporting whether it was saved
+func (s *IngestService) storeMatch(ctx context.Context, matchID string) (bool, error) {
+	exists, err := s.db.MatchExists(matchID)
+	if err != nil || exists {
+		return false, err
+	}
+
+	match, err := s.riotClient.GetMatchByID(ctx, matchID)
+	if err != nil {
+		return false, err
+	}
+	record, err := matchRecord(match)
+	if err != nil {
+		return false, err
+	}
+	if err := s.db.SaveMatch(record); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// matchRecord converts a match into the row stored in the matches table,
+// keeping the whole payload as its data
+func matchRecord(match *riot.Match) (*database.Match, error) {
+	payload, err := json.Marshal(match)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal match: %w", err)
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(payload, &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal match: %w", err)
+	}
+
+	return &database.Match{
+		MatchID:      match.Metadata.MatchID,
+		GameMode:     match.Info.GameMode,
+		GameDuration: match.Info.GameDuration,
+		GameCreation: match.Info.GameCreation,
+		Data:         data,
+	}, nil
+}
+
+func (j *ingestJob) update(change func(*IngestProgress)) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	change(&j.progress)
+}
+
+func (j *ingestJob) snapshot() IngestProgress {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	progress := j.progress
+	progress.Errors = append([]string(nil), j.progress.Errors...)
+	return progress
+}
+
+func (p *IngestProgress) addError(message string) {
+	if len(p.Errors) < maxIngestErrors {
+		p.Errors = append(p.Errors, message)
+	}
+}
@@ -0,0 +1,249 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gvieiragoulart/draft-visualizer/internal/database"
+	"github.com/gvieiragoulart/draft-visualizer/internal/riot"
+)
+
+// ingestRiotClient serves a match history of NA1_1 and NA1_2 and records
+// every request
+func ingestRiotClient(requested *[]string) *riot.Client {
+	mockHTTP := &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			*requested = append(*requested, req.URL.String())
+
+			body := `{"metadata": {"matchId": "NA1_2"}, "info": {"gameMode": "CLASSIC", "gameDuration": 1800, "queueId": 420}}`
+			if strings.Contains(req.URL.Path, "/by-puuid/") {
+				body = `["NA1_1", "NA1_2"]`
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	return riot.NewClientWithHTTPClient("test-key", mockHTTP)
+}
+
+func TestIngestRun_StoresNewMatches(t *testing.T) {
+	var requested []string
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+	now := time.Now()
+
+	mock.ExpectQuery(`FROM ingest_checkpoints`).WithArgs("puuid-1", "americas", database.AnyQueue, "").WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery(`SELECT EXISTS`).WithArgs("NA1_1").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(`SELECT EXISTS`).WithArgs("NA1_2").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectQuery(`INSERT INTO matches`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(1, now, now))
+	mock.ExpectQuery(`INSERT INTO ingest_checkpoints`).
+		WithArgs("puuid-1", "americas", database.AnyQueue, "", nil, sqlmock.AnyArg(), 1, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(now))
+
+	service := NewIngestService(ingestRiotClient(&requested), database.NewClientWithDB(db))
+	reports := 0
+	progress, err := service.Run(context.Background(), IngestRequest{Players: []string{"puuid-1"}, Concurrency: 1},
+		func(IngestProgress) { reports++ })
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if progress.Status != IngestDone || progress.Found != 2 || progress.Stored != 1 || progress.Skipped != 1 {
+		t.Errorf("expected 1 match stored and 1 skipped, got %+v", progress)
+	}
+	if reports == 0 {
+		t.Error("expected progress to be reported")
+	}
+	if len(requested) != 2 || !strings.HasSuffix(requested[1], "/matches/NA1_2") {
+		t.Errorf("expected only NA1_2 to be fetched, got %v", requested)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %s", err)
+	}
+}
+
+func TestIngestRun_ResumesFromCheckpoint(t *testing.T) {
+	var requested []string
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+	crawledUntil := time.Unix(1716000000, 0)
+
+	mock.ExpectQuery(`FROM ingest_checkpoints`).WithArgs("puuid-1", "americas", database.AnyQueue, "").
+		WillReturnRows(sqlmock.NewRows([]string{"puuid", "region", "queue", "match_type", "crawled_from", "crawled_until", "matches", "updated_at"}).
+			AddRow("puuid-1", "americas", database.AnyQueue, "", nil, crawledUntil, 40, crawledUntil))
+	mock.ExpectQuery(`SELECT EXISTS`).WithArgs("NA1_1").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(`SELECT EXISTS`).WithArgs("NA1_2").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(`INSERT INTO ingest_checkpoints`).
+		WithArgs("puuid-1", "americas", database.AnyQueue, "", nil, sqlmock.AnyArg(), 40, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(time.Now()))
+
+	service := NewIngestService(ingestRiotClient(&requested), database.NewClientWithDB(db))
+	progress, err := service.Run(context.Background(), IngestRequest{Players: []string{"puuid-1"}, Concurrency: 1}, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if progress.Stored != 0 || progress.Skipped != 2 {
+		t.Errorf("expected both matches to be already stored, got %+v", progress)
+	}
+	if len(requested) != 1 || !strings.Contains(requested[0], "startTime=1716000000") {
+		t.Errorf("expected the history to be listed from the checkpoint, got %v", requested)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %s", err)
+	}
+}
+
+func TestIngestRun_ListsBeforeCheckpoint(t *testing.T) {
+	var requested []string
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+	startTime := time.Unix(1700000000, 0)
+	crawledFrom := time.Unix(1710000000, 0)
+	crawledUntil := time.Unix(1716000000, 0)
+
+	mock.ExpectQuery(`FROM ingest_checkpoints`).WithArgs("puuid-1", "americas", database.AnyQueue, "").
+		WillReturnRows(sqlmock.NewRows([]string{"puuid", "region", "queue", "match_type", "crawled_from", "crawled_until", "matches", "updated_at"}).
+			AddRow("puuid-1", "americas", database.AnyQueue, "", crawledFrom, crawledUntil, 40, crawledUntil))
+	mock.ExpectQuery(`SELECT EXISTS`).WithArgs("NA1_1").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(`SELECT EXISTS`).WithArgs("NA1_2").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(`INSERT INTO ingest_checkpoints`).
+		WithArgs("puuid-1", "americas", database.AnyQueue, "", startTime, sqlmock.AnyArg(), 40, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(time.Now()))
+
+	service := NewIngestService(ingestRiotClient(&requested), database.NewClientWithDB(db))
+	_, err = service.Run(context.Background(), IngestRequest{Players: []string{"puuid-1"}, StartTime: startTime, Concurrency: 1}, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(requested) != 2 ||
+		!strings.Contains(requested[0], "startTime=1700000000") || !strings.Contains(requested[0], "endTime=1710000000") ||
+		!strings.Contains(requested[1], "startTime=1716000000") {
+		t.Errorf("expected the history before and after the checkpoint to be listed, got %v", requested)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %s", err)
+	}
+}
+
+// crawledBetween matches a checkpoint time within [from, to]
+type crawledBetween struct{ from, to time.Time }
+
+func (c crawledBetween) Match(v driver.Value) bool {
+	t, ok := v.(time.Time)
+	return ok && !t.Before(c.from) && !t.After(c.to)
+}
+
+func TestIngestRun_CheckpointPerFilter(t *testing.T) {
+	var requested []string
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+	queue := 420
+	start := time.Now()
+
+	// a checkpoint saved by an unfiltered crawl must not be used here
+	mock.ExpectQuery(`FROM ingest_checkpoints`).WithArgs("puuid-1", "americas", 420, "ranked").WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery(`SELECT EXISTS`).WithArgs("NA1_1").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(`SELECT EXISTS`).WithArgs("NA1_2").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(`INSERT INTO ingest_checkpoints`).
+		WithArgs("puuid-1", "americas", 420, "ranked", nil,
+			crawledBetween{start.Add(-ingestCheckpointOverlap), time.Now().Add(-ingestCheckpointOverlap + time.Minute)}, 0, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(time.Now()))
+
+	service := NewIngestService(ingestRiotClient(&requested), database.NewClientWithDB(db))
+	_, err = service.Run(context.Background(), IngestRequest{Players: []string{"puuid-1"}, Queue: &queue, Type: "ranked", Concurrency: 1}, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(requested) != 1 || strings.Contains(requested[0], "startTime") {
+		t.Errorf("expected the whole filtered history to be listed, got %v", requested)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %s", err)
+	}
+}
+
+func TestIngestStart_Invalid(t *testing.T) {
+	service := NewIngestService(riot.NewClient("test-key"), nil)
+
+	tests := []struct {
+		name string
+		req  IngestRequest
+	}{
+		{name: "no players", req: IngestRequest{}},
+		{name: "riot id without platform", req: IngestRequest{Players: []string{"Faker#KR1"}}},
+		{name: "unknown platform", req: IngestRequest{Players: []string{"Faker#KR1"}, Platform: "xx1"}},
+		{name: "unknown match type", req: IngestRequest{Players: []string{"puuid-1"}, Type: "arena"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.Start(tt.req); !errors.Is(err, ErrInvalidIngestRequest) {
+				t.Errorf("expected an invalid request error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestIngestProgress_DropsFinishedJobs(t *testing.T) {
+	service := NewIngestService(riot.NewClient("test-key"), nil)
+	now := time.Now()
+	service.now = func() time.Time { return now }
+
+	finishedAt := now
+	service.jobs["done"] = &ingestJob{progress: IngestProgress{ID: "done", Status: IngestDone, FinishedAt: &finishedAt}}
+	service.jobs["running"] = &ingestJob{progress: IngestProgress{ID: "running", Status: IngestRunning}}
+
+	if _, err := service.Progress("done"); err != nil {
+		t.Fatalf("expected a recently finished job to be kept, got %v", err)
+	}
+
+	now = now.Add(ingestJobRetention + time.Second)
+	if _, err := service.Progress("done"); !errors.Is(err, ErrIngestJobNotFound) {
+		t.Errorf("expected the finished job to be dropped, got %v", err)
+	}
+	if _, err := service.Progress("running"); err != nil {
+		t.Errorf("expected the running job to be kept, got %v", err)
+	}
+}
+
+func TestIngestStart_NoDatabase(t *testing.T) {
+	service := NewIngestService(riot.NewClient("test-key"), nil)
+
+	if _, err := service.Start(IngestRequest{Players: []string{"puuid-1"}}); !errors.Is(err, ErrDatabaseUnavailable) {
+		t.Errorf("expected the database to be reported unavailable, got %v", err)
+	}
+}
@@ -0,0 +1,282 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/clients/esports"
+	"github.com/gvieiragoulart/draft-visualizer/internal/clients/esports/dto"
+	"github.com/gvieiragoulart/draft-visualizer/internal/draft"
+	"github.com/gvieiragoulart/draft-visualizer/internal/stream"
+)
+
+var (
+	ErrLiveGameNotTracked = errors.New("live game is not being tracked")
+	ErrInvalidLiveGame    = errors.New("invalid live game")
+	ErrTooManyLiveGames   = errors.New("too many live games are being tracked")
+)
+
+// DefaultMaxLiveGames caps how many games are polled at once
+const DefaultMaxLiveGames = 20
+
+// DefaultLiveGameGrace is how long a finished game and its stream history
+// are kept so clients that reconnect can still replay them
+const DefaultLiveGameGrace = 10 * time.Minute
+
+// LivePick is a champion seen in the livestats feed of a pro game. The feed
+// only exposes champions once the game has loaded, so bans, hovers and lock
+// times are not available; SeenAt is when the pick first showed up.
+type LivePick struct {
+	Side          draft.Side `json:"side"`
+	Champion      string     `json:"champion"`
+	Role          string     `json:"role"`
+	Player        string     `json:"player"`
+	ParticipantID int        `json:"participantId"`
+	SeenAt        time.Time  `json:"seenAt"`
+}
+
+// LiveGame is what has been seen so far of a tracked pro game
+type LiveGame struct {
+	GameID    string     `json:"gameId"`
+	MatchID   string     `json:"matchId"`
+	Patch     string     `json:"patch"`
+	State     string     `json:"state"`
+	Picks     []LivePick `json:"picks"`
+	StartedAt time.Time  `json:"startedAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+	EndedAt   *time.Time `json:"endedAt,omitempty"`
+}
+
+// LiveGameTopic is the stream topic a tracked pro game publishes on
+func LiveGameTopic(gameID string) string {
+	return "live:" + gameID
+}
+
+// LiveDraftService polls the lolesports livestats feed for games it was
+// asked to track and publishes what changes to the broker
+type LiveDraftService struct {
+	esportsClient *esports.EsportsClient
+	broker        *stream.Broker
+	pollInterval  time.Duration
+	maxDuration   time.Duration
+	endGrace      time.Duration
+	maxGames      int
+
+	mu      sync.Mutex
+	tracked map[string]*LiveGame
+}
+
+func NewLiveDraftService(esportsClient *esports.EsportsClient, broker *stream.Broker) *LiveDraftService {
+	return &LiveDraftService{
+		esportsClient: esportsClient,
+		broker:        broker,
+		pollInterval:  10 * time.Second,
+		maxDuration:   3 * time.Hour,
+		endGrace:      DefaultLiveGameGrace,
+		maxGames:      DefaultMaxLiveGames,
+		tracked:       make(map[string]*LiveGame),
+	}
+}
+
+// Track starts polling a game in the background once the livestats feed
+// confirms it exists. Tracking the same game twice is a no-op. When the game
+// finishes, or after maxDuration, a game_end event is published and the game
+// is forgotten along with its stream topic once endGrace has passed.
+func (s *LiveDraftService) Track(gameID string) (*LiveGame, error) {
+	if game, err := s.trackedGame(gameID); game != nil || err != nil {
+		return game, err
+	}
+
+	if !isDigits(gameID) {
+		return nil, fmt.Errorf("%w: game id must be numeric", ErrInvalidLiveGame)
+	}
+	if _, err := s.esportsClient.GetLiveWindow(gameID); err != nil && !errors.Is(err, esports.ErrNoLiveData) {
+		if errors.Is(err, esports.ErrUnknownGame) {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidLiveGame, err)
+		}
+		return nil, fmt.Errorf("failed to check live game: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if game, ok := s.tracked[gameID]; ok {
+		return game.copy(), nil
+	}
+	if s.polling() >= s.maxGames {
+		return nil, fmt.Errorf("%w: at most %d", ErrTooManyLiveGames, s.maxGames)
+	}
+
+	now := time.Now()
+	game := &LiveGame{
+		GameID:    gameID,
+		Picks:     []LivePick{},
+		StartedAt: now,
+		UpdatedAt: now,
+	}
+	s.tracked[gameID] = game
+	s.broker.Publish(LiveGameTopic(gameID), "tracking", game.copy())
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.maxDuration)
+	go func() {
+		defer cancel()
+		s.poll(ctx, gameID)
+		s.end(gameID)
+		time.AfterFunc(s.endGrace, func() { s.untrack(gameID) })
+	}()
+
+	return game.copy(), nil
+}
+
+// trackedGame returns the game when it is already tracked, or an error when
+// no more games can be tracked
+func (s *LiveDraftService) trackedGame(gameID string) (*LiveGame, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if game, ok := s.tracked[gameID]; ok {
+		return game.copy(), nil
+	}
+	if s.polling() >= s.maxGames {
+		return nil, fmt.Errorf("%w: at most %d", ErrTooManyLiveGames, s.maxGames)
+	}
+	return nil, nil
+}
+
+// polling counts the tracked games that have not ended. Callers must hold
+// the lock.
+func (s *LiveDraftService) polling() int {
+	n := 0
+	for _, game := range s.tracked {
+		if game.EndedAt == nil {
+			n++
+		}
+	}
+	return n
+}
+
+// end marks a game as no longer polled and publishes its final state
+func (s *LiveDraftService) end(gameID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	game := s.tracked[gameID]
+	now := time.Now()
+	game.EndedAt = &now
+	s.broker.Publish(LiveGameTopic(gameID), "game_end", game.copy())
+}
+
+// untrack forgets a game once its grace period is over, closing its stream
+func (s *LiveDraftService) untrack(gameID string) {
+	s.mu.Lock()
+	delete(s.tracked, gameID)
+	s.mu.Unlock()
+
+	s.broker.Delete(LiveGameTopic(gameID))
+}
+
+// GetLiveGame returns what has been seen of a tracked game
+func (s *LiveDraftService) GetLiveGame(gameID string) (*LiveGame, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	game, ok := s.tracked[gameID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrLiveGameNotTracked, gameID)
+	}
+	return game.copy(), nil
+}
+
+func (s *LiveDraftService) poll(ctx context.Context, gameID string) {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		window, err := s.esportsClient.GetLiveWindow(gameID)
+		if err != nil && !errors.Is(err, esports.ErrNoLiveData) {
+			log.Printf("Error polling live game %s: %v", gameID, err)
+		}
+		if err == nil && s.update(gameID, window) {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// update applies a window to the tracked game and reports whether the game
+// has finished
+func (s *LiveDraftService) update(gameID string, window dto.WindowDTO) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	game := s.tracked[gameID]
+	topic := LiveGameTopic(gameID)
+	game.MatchID = window.EsportsMatchID
+	game.Patch = window.GameMetadata.PatchVersion
+
+	now := time.Now()
+	seen := make(map[int]bool)
+	for _, pick := range game.Picks {
+		seen[pick.ParticipantID] = true
+	}
+
+	sides := []struct {
+		side     draft.Side
+		metadata dto.TeamMetadata
+	}{
+		{draft.Blue, window.GameMetadata.BlueTeamMetadata},
+		{draft.Red, window.GameMetadata.RedTeamMetadata},
+	}
+	for _, side := range sides {
+		for _, participant := range side.metadata.ParticipantMetadata {
+			if participant.ChampionID == "" || seen[participant.ParticipantID] {
+				continue
+			}
+			pick := LivePick{
+				Side:          side.side,
+				Champion:      participant.ChampionID,
+				Role:          participant.Role,
+				Player:        participant.SummonerName,
+				ParticipantID: participant.ParticipantID,
+				SeenAt:        now,
+			}
+			game.Picks = append(game.Picks, pick)
+			s.broker.Publish(topic, "pick", pick)
+		}
+	}
+
+	if state := window.GameState(); state != "" && state != game.State {
+		game.State = state
+		s.broker.Publish(topic, "game_state", game.copy())
+	}
+	game.UpdatedAt = now
+
+	return game.State == "finished"
+}
+
+func (g *LiveGame) copy() *LiveGame {
+	c := *g
+	c.Picks = append([]LivePick{}, g.Picks...)
+	return &c
+}
+
+func isDigits(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/clients/esports"
+	"github.com/gvieiragoulart/draft-visualizer/internal/stream"
+)
+
+// liveFeedClient answers the livestats feed with a finished game for game
+// 1, no data yet for game 2 and not found for anything else
+func liveFeedClient() *esports.EsportsClient {
+	client := esports.NewClient("test-key")
+	client.HttpClient = &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			switch {
+			case strings.HasSuffix(req.URL.Path, "/window/1"):
+				body := `{"esportsGameId": "1", "frames": [{"gameState": "finished"}]}`
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(body))}, nil
+			case strings.HasSuffix(req.URL.Path, "/window/2"):
+				return &http.Response{StatusCode: http.StatusNoContent, Body: io.NopCloser(bytes.NewBuffer(nil))}, nil
+			}
+			return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(bytes.NewBuffer(nil))}, nil
+		},
+	}
+	return client
+}
+
+func TestTrack_ValidatesGame(t *testing.T) {
+	svc := NewLiveDraftService(liveFeedClient(), stream.NewBroker(10))
+
+	for _, gameID := range []string{"not-a-game", "3"} {
+		if _, err := svc.Track(gameID); !errors.Is(err, ErrInvalidLiveGame) {
+			t.Errorf("%s: expected ErrInvalidLiveGame, got %v", gameID, err)
+		}
+	}
+
+	svc.maxGames = 1
+	if _, err := svc.Track("2"); err != nil {
+		t.Fatalf("expected a game without data yet to be tracked, got %v", err)
+	}
+	if _, err := svc.Track("2"); err != nil {
+		t.Errorf("expected tracking the same game twice to be a no-op, got %v", err)
+	}
+	if _, err := svc.Track("1"); !errors.Is(err, ErrTooManyLiveGames) {
+		t.Errorf("expected ErrTooManyLiveGames, got %v", err)
+	}
+}
+
+func TestTrack_ForgetsFinishedGames(t *testing.T) {
+	broker := stream.NewBroker(10)
+	svc := NewLiveDraftService(liveFeedClient(), broker)
+	svc.endGrace = 0
+	sub := broker.Subscribe(LiveGameTopic("1"), 0)
+
+	if _, err := svc.Track("1"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var last string
+	deadline := time.After(time.Second)
+	for open := true; open; {
+		select {
+		case event, ok := <-sub.Events:
+			if open = ok; ok {
+				last = event.Type
+			}
+		case <-deadline:
+			t.Fatal("timed out waiting for the stream to close")
+		}
+	}
+
+	if last != "game_end" || !sub.Ended() {
+		t.Errorf("expected the stream to end after a game_end event, got %q", last)
+	}
+
+	if _, err := svc.GetLiveGame("1"); !errors.Is(err, ErrLiveGameNotTracked) {
+		t.Errorf("expected the finished game to be forgotten, got %v", err)
+	}
+	if history := broker.History(LiveGameTopic("1")); len(history) != 0 {
+		t.Errorf("expected the topic to be deleted, got %v", history)
+	}
+}
+
+func TestTrack_KeepsFinishedGamesForGrace(t *testing.T) {
+	broker := stream.NewBroker(10)
+	svc := NewLiveDraftService(liveFeedClient(), broker)
+	svc.maxGames = 1
+	sub := broker.Subscribe(LiveGameTopic("1"), 0)
+	defer sub.Close()
+
+	if _, err := svc.Track("1"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	deadline := time.After(time.Second)
+	for ended := false; !ended; {
+		select {
+		case event := <-sub.Events:
+			ended = event.Type == "game_end"
+		case <-deadline:
+			t.Fatal("timed out waiting for game_end")
+		}
+	}
+
+	game, err := svc.GetLiveGame("1")
+	if err != nil || game.EndedAt == nil || game.State != "finished" {
+		t.Fatalf("expected the finished game to be kept, got %+v and %v", game, err)
+	}
+	if history := broker.History(LiveGameTopic("1")); len(history) == 0 || history[len(history)-1].Type != "game_end" {
+		t.Errorf("expected the history to be kept for replay, got %v", history)
+	}
+	if _, err := svc.Track("2"); err != nil {
+		t.Errorf("expected a finished game not to count against the cap, got %v", err)
+	}
+}
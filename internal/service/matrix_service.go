@@ -0,0 +1,140 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/analytics"
+	"github.com/gvieiragoulart/draft-visualizer/internal/clients/cargo/model/draft_games"
+	"github.com/gvieiragoulart/draft-visualizer/internal/database"
+	"github.com/gvieiragoulart/draft-visualizer/internal/draft"
+)
+
+var ErrInvalidMatrixQuery = errors.New("invalid matrix query")
+
+// MatrixQuery selects the stored games a synergy or counter matrix is built
+// from and the rows returned
+type MatrixQuery struct {
+	Champion string
+	Role     string
+	Window   analytics.PatchWindow
+	Filter   database.DraftGameFilter
+	MinGames int
+}
+
+// MatrixService stores pro drafts in Postgres and builds synergy and counter
+// matrices from them
+type MatrixService struct {
+	stats *StatsService
+	db    *database.Client
+}
+
+func NewMatrixService(stats *StatsService, db *database.Client) *MatrixService {
+	return &MatrixService{
+		stats: stats,
+		db:    db,
+	}
+}
+
+// SyncGames fetches the drafts matching filter from Leaguepedia and stores
+// them, returning how many were saved
+func (s *MatrixService) SyncGames(filter draft_games.Filter) (int, error) {
+	if s.db == nil {
+		return 0, ErrDatabaseUnavailable
+	}
+	games, err := s.stats.GetGames(filter)
+	if err != nil {
+		return 0, err
+	}
+
+	saved := 0
+	for _, game := range games {
+		if game.GameID == "" {
+			continue
+		}
+		if err := s.db.SaveDraftGame(toDraftGameRecord(game)); err != nil {
+			return saved, fmt.Errorf("error saving game %s: %w", game.GameID, err)
+		}
+		saved++
+	}
+	return saved, nil
+}
+
+// StoredGames returns the stored games matching filter and window. The patch
+// window is applied by the database so only the games it covers are loaded.
+func (s *MatrixService) StoredGames(filter database.DraftGameFilter, window analytics.PatchWindow) ([]analytics.Game, error) {
+	if s.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+	filter.PatchFrom, filter.PatchTo = window.From, window.To
+	records, err := s.db.ListDraftGames(filter)
+	if err != nil {
+		return nil, fmt.Errorf("error listing draft games: %w", err)
+	}
+
+	games := make([]analytics.Game, 0, len(records))
+	for _, record := range records {
+		games = append(games, fromDraftGameRecord(record))
+	}
+	return games, nil
+}
+
+// GetSynergies returns the same-team pairs of a champion ranked by lift
+func (s *MatrixService) GetSynergies(query MatrixQuery) ([]analytics.PairStats, error) {
+	games, err := s.matrixGames(query)
+	if err != nil {
+		return nil, err
+	}
+	return analytics.PairsFor(analytics.SynergyMatrix(games, query.MinGames), query.Champion, ""), nil
+}
+
+// GetCounters returns a champion's same-role matchups ranked by lift
+func (s *MatrixService) GetCounters(query MatrixQuery) ([]analytics.PairStats, error) {
+	games, err := s.matrixGames(query)
+	if err != nil {
+		return nil, err
+	}
+	return analytics.PairsFor(analytics.CounterMatrix(games, query.MinGames), query.Champion, query.Role), nil
+}
+
+func (s *MatrixService) matrixGames(query MatrixQuery) ([]analytics.Game, error) {
+	if query.Champion == "" {
+		return nil, fmt.Errorf("%w: champion is required", ErrInvalidMatrixQuery)
+	}
+	if query.MinGames < 0 {
+		return nil, fmt.Errorf("%w: minGames cannot be negative", ErrInvalidMatrixQuery)
+	}
+	return s.StoredGames(query.Filter, query.Window)
+}
+
+func toDraftGameRecord(game analytics.Game) *database.DraftGame {
+	return &database.DraftGame{
+		GameID:     game.GameID,
+		MatchID:    game.MatchID,
+		Tournament: game.Tournament,
+		League:     game.League,
+		Patch:      game.Patch,
+		PlayedAt:   game.Date,
+		BlueTeam:   game.BlueTeam,
+		RedTeam:    game.RedTeam,
+		Winner:     string(game.Winner),
+		Blue:       database.DraftSelections(game.Blue),
+		Red:        database.DraftSelections(game.Red),
+	}
+}
+
+func fromDraftGameRecord(record database.DraftGame) analytics.Game {
+	return analytics.Game{
+		GameID:     record.GameID,
+		MatchID:    record.MatchID,
+		Tournament: record.Tournament,
+		League:     record.League,
+		Patch:      record.Patch,
+		Date:       record.PlayedAt,
+		BlueTeam:   record.BlueTeam,
+		RedTeam:    record.RedTeam,
+		Winner:     draft.Side(record.Winner),
+		Blue:       draft.Selections(record.Blue),
+		Red:        draft.Selections(record.Red),
+	}
+}
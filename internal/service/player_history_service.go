@@ -0,0 +1,390 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/champions"
+	"github.com/gvieiragoulart/draft-visualizer/internal/database"
+	"github.com/gvieiragoulart/draft-visualizer/internal/riot"
+)
+
+var ErrInvalidSnapshotRequest = errors.New("invalid snapshot request")
+
+// maxSnapshotErrors caps how many failures a snapshot report lists
+const maxSnapshotErrors = 20
+
+// SnapshotRequest names the players whose ranked standing and champion
+// mastery to record. Players are PUUIDs or Riot IDs (gameName#tagLine);
+// both league-v4 and champion-mastery-v4 are per platform, so Platform is
+// required.
+type SnapshotRequest struct {
+	Players  []string `json:"players"`
+	Platform string   `json:"platform"`
+}
+
+// Validate checks that the request names players and a known platform
+func (r SnapshotRequest) Validate() error {
+	if len(r.Players) == 0 {
+		return fmt.Errorf("%w: at least one player is required", ErrInvalidSnapshotRequest)
+	}
+	if r.Platform == "" {
+		return fmt.Errorf("%w: platform is required", ErrInvalidSnapshotRequest)
+	}
+	if _, err := riot.ParsePlatform(r.Platform); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSnapshotRequest, err)
+	}
+	return nil
+}
+
+// SnapshotReport sums up one round of snapshots
+type SnapshotReport struct {
+	Players       int       `json:"players"`
+	Snapshotted   int       `json:"playersSnapshotted"`
+	LeagueEntries int       `json:"leagueEntries"`
+	Masteries     int       `json:"masteries"`
+	Errors        []string  `json:"errors,omitempty"`
+	TakenAt       time.Time `json:"takenAt"`
+}
+
+// HistoryQuery narrows a player's snapshot history down. Queue is a
+// league-v4 queue type or "solo"/"flex" and only applies to the league
+// history; Champion is any spelling the catalogue resolves and only applies
+// to the mastery history.
+type HistoryQuery struct {
+	PUUID    string
+	Queue    string
+	Champion string
+	From     *time.Time
+	To       *time.Time
+}
+
+// LeagueHistory is a player's ranked standing over time, one series per
+// queue
+type LeagueHistory struct {
+	PUUID  string         `json:"puuid"`
+	Queues []LeagueSeries `json:"queues"`
+}
+
+type LeagueSeries struct {
+	QueueType string        `json:"queueType"`
+	Points    []LeaguePoint `json:"points"`
+}
+
+// LeaguePoint is one snapshot of a queue. LadderPoints places tier,
+// division and LP on a single scale (Iron IV 0 LP is 0, every division is
+// worth 100 and Master starts at 2800) so a chart can cross promotions.
+type LeaguePoint struct {
+	TakenAt      time.Time `json:"takenAt"`
+	Tier         string    `json:"tier"`
+	Rank         string    `json:"rank,omitempty"`
+	LeaguePoints int       `json:"leaguePoints"`
+	LadderPoints int       `json:"ladderPoints"`
+	Wins         int       `json:"wins"`
+	Losses       int       `json:"losses"`
+}
+
+// MasteryHistory is a player's champion mastery over time, one series per
+// champion with the most mastered champion first
+type MasteryHistory struct {
+	PUUID     string          `json:"puuid"`
+	Champions []MasterySeries `json:"champions"`
+}
+
+// MasterySeries is one champion's mastery over time. Gained is the points
+// earned between the first and the last snapshot.
+type MasterySeries struct {
+	ChampionID int            `json:"championId"`
+	Champion   string         `json:"champion,omitempty"`
+	Gained     int            `json:"gained"`
+	Points     []MasteryPoint `json:"points"`
+}
+
+type MasteryPoint struct {
+	TakenAt        time.Time `json:"takenAt"`
+	ChampionLevel  int       `json:"championLevel"`
+	ChampionPoints int       `json:"championPoints"`
+}
+
+// PlayerHistoryService records periodic snapshots of tracked players'
+// ranked standing and champion mastery and serves them back as series to
+// chart over a split
+type PlayerHistoryService struct {
+	riotClient *riot.Client
+	db         *database.Client
+	catalogue  *champions.Catalogue
+	now        func() time.Time
+}
+
+func NewPlayerHistoryService(riotClient *riot.Client, db *database.Client) *PlayerHistoryService {
+	return &PlayerHistoryService{
+		riotClient: riotClient,
+		db:         db,
+		now:        time.Now,
+	}
+}
+
+// SetCatalogue names the champions in mastery histories and lets queries
+// filter by champion name
+func (s *PlayerHistoryService) SetCatalogue(catalogue *champions.Catalogue) {
+	s.catalogue = catalogue
+}
+
+// Snapshot records every player's current league entries and champion
+// masteries under one timestamp. A player that fails is reported and
+// skipped; an error is only returned when the request is invalid, the
+// context is canceled or no player could be recorded.
+func (s *PlayerHistoryService) Snapshot(ctx context.Context, req SnapshotRequest) (SnapshotReport, error) {
+	if err := req.Validate(); err != nil {
+		return SnapshotReport{}, err
+	}
+	if s.db == nil {
+		return SnapshotReport{}, ErrDatabaseUnavailable
+	}
+	platform, _ := riot.ParsePlatform(req.Platform)
+
+	report := SnapshotReport{Players: len(req.Players), TakenAt: s.now().UTC()}
+	for _, player := range req.Players {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		player = strings.TrimSpace(player)
+		entries, masteries, err := s.snapshotPlayer(ctx, platform, player, report.TakenAt)
+		if err != nil {
+			if len(report.Errors) < maxSnapshotErrors {
+				report.Errors = append(report.Errors, fmt.Sprintf("%s: %v", player, err))
+			}
+			continue
+		}
+		report.Snapshotted++
+		report.LeagueEntries += entries
+		report.Masteries += masteries
+	}
+
+	if report.Snapshotted == 0 {
+		return report, fmt.Errorf("snapshot failed: %s", strings.Join(report.Errors, "; "))
+	}
+	return report, nil
+}
+
+// snapshotPlayer records one player, returning how many league entries and
+// masteries were stored
+func (s *PlayerHistoryService) snapshotPlayer(ctx context.Context, platform riot.Platform, player string, takenAt time.Time) (int, int, error) {
+	puuid := player
+	if strings.Contains(player, "#") {
+		account, err := s.riotClient.GetPlayerByRiotID(ctx, platform, player)
+		if err != nil {
+			return 0, 0, err
+		}
+		puuid = account.Account.PUUID
+	}
+
+	entries, err := s.riotClient.GetLeagueEntries(ctx, platform, puuid)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get league entries: %w", err)
+	}
+	masteries, err := s.riotClient.GetChampionMasteries(ctx, platform, puuid)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get champion masteries: %w", err)
+	}
+
+	leagueSnapshots := make([]database.LeagueSnapshot, 0, len(entries))
+	for _, entry := range entries {
+		leagueSnapshots = append(leagueSnapshots, database.LeagueSnapshot{
+			PUUID:        puuid,
+			Platform:     string(platform),
+			QueueType:    entry.QueueType,
+			Tier:         entry.Tier,
+			Rank:         entry.Rank,
+			LeaguePoints: entry.LeaguePoints,
+			Wins:         entry.Wins,
+			Losses:       entry.Losses,
+			TakenAt:      takenAt,
+		})
+	}
+	masterySnapshots := make([]database.MasterySnapshot, 0, len(masteries))
+	for _, mastery := range masteries {
+		masterySnapshots = append(masterySnapshots, database.MasterySnapshot{
+			PUUID:          puuid,
+			Platform:       string(platform),
+			ChampionID:     mastery.ChampionID,
+			ChampionLevel:  mastery.ChampionLevel,
+			ChampionPoints: mastery.ChampionPoints,
+			LastPlayTime:   mastery.LastPlayTime,
+			TakenAt:        takenAt,
+		})
+	}
+
+	if err := s.db.SavePlayerSnapshots(leagueSnapshots, masterySnapshots); err != nil {
+		return 0, 0, err
+	}
+	return len(leagueSnapshots), len(masterySnapshots), nil
+}
+
+// RunPeriodic takes a snapshot right away and then every interval until ctx
+// is canceled, handing each report (and error) to report
+func (s *PlayerHistoryService) RunPeriodic(ctx context.Context, req SnapshotRequest, interval time.Duration, report func(SnapshotReport, error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		result, err := s.Snapshot(ctx, req)
+		if ctx.Err() != nil {
+			return
+		}
+		report(result, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// GetLeagueHistory returns a player's ranked standing per queue over time
+func (s *PlayerHistoryService) GetLeagueHistory(query HistoryQuery) (*LeagueHistory, error) {
+	if s.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+	snapshots, err := s.db.ListLeagueSnapshots(query.PUUID, database.SnapshotFilter{
+		QueueType: leagueQueueType(query.Queue),
+		From:      query.From,
+		To:        query.To,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	history := &LeagueHistory{PUUID: query.PUUID, Queues: []LeagueSeries{}}
+	series := map[string]int{}
+	for _, snapshot := range snapshots {
+		i, ok := series[snapshot.QueueType]
+		if !ok {
+			i = len(history.Queues)
+			series[snapshot.QueueType] = i
+			history.Queues = append(history.Queues, LeagueSeries{QueueType: snapshot.QueueType})
+		}
+		history.Queues[i].Points = append(history.Queues[i].Points, LeaguePoint{
+			TakenAt:      snapshot.TakenAt,
+			Tier:         snapshot.Tier,
+			Rank:         snapshot.Rank,
+			LeaguePoints: snapshot.LeaguePoints,
+			LadderPoints: ladderPoints(snapshot.Tier, snapshot.Rank, snapshot.LeaguePoints),
+			Wins:         snapshot.Wins,
+			Losses:       snapshot.Losses,
+		})
+	}
+	return history, nil
+}
+
+// GetMasteryHistory returns a player's mastery per champion over time
+func (s *PlayerHistoryService) GetMasteryHistory(query HistoryQuery) (*MasteryHistory, error) {
+	if s.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+	filter := database.SnapshotFilter{From: query.From, To: query.To}
+	if query.Champion != "" {
+		if s.catalogue == nil {
+			return nil, fmt.Errorf("%w: %s", champions.ErrUnknownChampion, query.Champion)
+		}
+		champion, ok := s.catalogue.Resolve(query.Champion)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", champions.ErrUnknownChampion, query.Champion)
+		}
+		filter.ChampionID = champion.Key
+	}
+
+	snapshots, err := s.db.ListMasterySnapshots(query.PUUID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &MasteryHistory{PUUID: query.PUUID, Champions: []MasterySeries{}}
+	series := map[int]int{}
+	for _, snapshot := range snapshots {
+		i, ok := series[snapshot.ChampionID]
+		if !ok {
+			i = len(history.Champions)
+			series[snapshot.ChampionID] = i
+			history.Champions = append(history.Champions, MasterySeries{ChampionID: snapshot.ChampionID})
+			if s.catalogue != nil {
+				if champion, ok := s.catalogue.ByKey(snapshot.ChampionID); ok {
+					history.Champions[i].Champion = champion.Name
+				}
+			}
+		}
+		history.Champions[i].Points = append(history.Champions[i].Points, MasteryPoint{
+			TakenAt:        snapshot.TakenAt,
+			ChampionLevel:  snapshot.ChampionLevel,
+			ChampionPoints: snapshot.ChampionPoints,
+		})
+	}
+
+	for i := range history.Champions {
+		points := history.Champions[i].Points
+		history.Champions[i].Gained = points[len(points)-1].ChampionPoints - points[0].ChampionPoints
+	}
+	sort.SliceStable(history.Champions, func(i, j int) bool {
+		return latestPoints(history.Champions[i]) > latestPoints(history.Champions[j])
+	})
+	return history, nil
+}
+
+func latestPoints(series MasterySeries) int {
+	return series.Points[len(series.Points)-1].ChampionPoints
+}
+
+// leagueQueueType maps the solo and flex shorthands to league-v4 queue
+// types and upper-cases anything else
+func leagueQueueType(queue string) string {
+	switch strings.ToLower(strings.TrimSpace(queue)) {
+	case "":
+		return ""
+	case "solo", "soloq", "solo/duo":
+		return riot.QueueTypeRankedSolo
+	case "flex":
+		return riot.QueueTypeRankedFlex
+	}
+	// RANKED_SOLO_5x5 has a lower-case x, so match the known types first
+	for _, queueType := range []string{riot.QueueTypeRankedSolo, riot.QueueTypeRankedFlex} {
+		if strings.EqualFold(queue, queueType) {
+			return queueType
+		}
+	}
+	return strings.ToUpper(queue)
+}
+
+// rankedTiers are the tiers with four divisions, lowest first
+var rankedTiers = []string{"IRON", "BRONZE", "SILVER", "GOLD", "PLATINUM", "EMERALD", "DIAMOND"}
+
+// rankedDivisions are the divisions within a tier, lowest first
+var rankedDivisions = []string{"IV", "III", "II", "I"}
+
+// ladderPoints places a ranked standing on one scale: every division below
+// is worth 100 points and Master, Grandmaster and Challenger, which have no
+// divisions, share the scale above Diamond I with their LP added on top
+func ladderPoints(tier, rank string, leaguePoints int) int {
+	tier = strings.ToUpper(tier)
+	switch tier {
+	case "MASTER", "GRANDMASTER", "CHALLENGER":
+		return len(rankedTiers)*len(rankedDivisions)*100 + leaguePoints
+	}
+
+	for t, name := range rankedTiers {
+		if name != tier {
+			continue
+		}
+		for d, division := range rankedDivisions {
+			if division == strings.ToUpper(rank) {
+				return (t*len(rankedDivisions)+d)*100 + leaguePoints
+			}
+		}
+	}
+	return leaguePoints
+}
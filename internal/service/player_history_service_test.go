@@ -0,0 +1,155 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gvieiragoulart/draft-visualizer/internal/champions"
+	"github.com/gvieiragoulart/draft-visualizer/internal/database"
+	"github.com/gvieiragoulart/draft-visualizer/internal/riot"
+)
+
+func TestSnapshot_StoresLeagueAndMastery(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+	takenAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	mockHTTP := &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			body := `[{"championId": 103, "championLevel": 12, "championPoints": 154321}, {"championId": 7, "championLevel": 5, "championPoints": 21000}]`
+			if strings.Contains(req.URL.Path, "/league/") {
+				body = `[{"queueType": "RANKED_SOLO_5x5", "tier": "DIAMOND", "rank": "II", "leaguePoints": 64, "wins": 120, "losses": 98}]`
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectPrepare(`INSERT INTO league_snapshots`).ExpectQuery().
+		WithArgs("puuid-1", "kr", "RANKED_SOLO_5x5", "DIAMOND", "II", 64, 120, 98, takenAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	masteryInsert := mock.ExpectPrepare(`INSERT INTO mastery_snapshots`)
+	masteryInsert.ExpectQuery().
+		WithArgs("puuid-1", "kr", 103, 12, 154321, int64(0), takenAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	masteryInsert.ExpectQuery().
+		WithArgs("puuid-1", "kr", 7, 5, 21000, int64(0), takenAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+	mock.ExpectCommit()
+
+	service := NewPlayerHistoryService(riot.NewClientWithHTTPClient("test-key", mockHTTP), database.NewClientWithDB(db))
+	service.now = func() time.Time { return takenAt }
+	report, err := service.Snapshot(context.Background(), SnapshotRequest{Players: []string{"puuid-1"}, Platform: "kr"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if report.Snapshotted != 1 || report.LeagueEntries != 1 || report.Masteries != 2 || !report.TakenAt.Equal(takenAt) {
+		t.Errorf("unexpected report %+v", report)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSnapshot_Invalid(t *testing.T) {
+	service := NewPlayerHistoryService(riot.NewClient("test-key"), nil)
+
+	tests := []struct {
+		name string
+		req  SnapshotRequest
+	}{
+		{name: "no players", req: SnapshotRequest{Platform: "kr"}},
+		{name: "no platform", req: SnapshotRequest{Players: []string{"puuid-1"}}},
+		{name: "unknown platform", req: SnapshotRequest{Players: []string{"puuid-1"}, Platform: "xx1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.Snapshot(context.Background(), tt.req); !errors.Is(err, ErrInvalidSnapshotRequest) {
+				t.Errorf("expected an invalid request error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestGetMasteryHistory_GroupsByChampion(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+	first := time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC)
+	second := first.AddDate(0, 1, 0)
+
+	mock.ExpectQuery(`FROM mastery_snapshots`).WithArgs("puuid-1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "puuid", "platform", "champion_id", "champion_level",
+			"champion_points", "last_play_time", "taken_at"}).
+			AddRow(1, "puuid-1", "kr", 7, 5, 21000, 0, first).
+			AddRow(2, "puuid-1", "kr", 103, 10, 120000, 0, first).
+			AddRow(3, "puuid-1", "kr", 7, 6, 26000, 0, second).
+			AddRow(4, "puuid-1", "kr", 103, 12, 154321, 0, second))
+
+	catalogue, err := champions.LoadBundled()
+	if err != nil {
+		t.Fatalf("failed to load catalogue: %v", err)
+	}
+	service := NewPlayerHistoryService(riot.NewClient("test-key"), database.NewClientWithDB(db))
+	service.SetCatalogue(catalogue)
+
+	history, err := service.GetMasteryHistory(HistoryQuery{PUUID: "puuid-1"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(history.Champions) != 2 {
+		t.Fatalf("expected 2 champions, got %+v", history.Champions)
+	}
+	ahri := history.Champions[0]
+	if ahri.Champion != "Ahri" || ahri.Gained != 34321 || len(ahri.Points) != 2 {
+		t.Errorf("expected Ahri first with 34321 points gained, got %+v", ahri)
+	}
+	if history.Champions[1].Champion != "LeBlanc" || history.Champions[1].Gained != 5000 {
+		t.Errorf("expected LeBlanc second with 5000 points gained, got %+v", history.Champions[1])
+	}
+
+	if _, err := service.GetMasteryHistory(HistoryQuery{PUUID: "puuid-1", Champion: "Not A Champion"}); !errors.Is(err, champions.ErrUnknownChampion) {
+		t.Errorf("expected an unknown champion error, got %v", err)
+	}
+}
+
+func TestLadderPoints(t *testing.T) {
+	tests := []struct {
+		tier     string
+		rank     string
+		lp       int
+		expected int
+	}{
+		{tier: "IRON", rank: "IV", lp: 0, expected: 0},
+		{tier: "GOLD", rank: "I", lp: 50, expected: 1550},
+		{tier: "DIAMOND", rank: "II", lp: 64, expected: 2664},
+		{tier: "MASTER", rank: "I", lp: 120, expected: 2920},
+		{tier: "CHALLENGER", rank: "I", lp: 1500, expected: 4300},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tier+" "+tt.rank, func(t *testing.T) {
+			if got := ladderPoints(tt.tier, tt.rank, tt.lp); got != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, got)
+			}
+		})
+	}
+}
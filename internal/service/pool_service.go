@@ -0,0 +1,130 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/analytics"
+	"github.com/gvieiragoulart/draft-visualizer/internal/champions"
+	"github.com/gvieiragoulart/draft-visualizer/internal/clients/cargo"
+	match_schedule "github.com/gvieiragoulart/draft-visualizer/internal/clients/cargo/model/match_shedule"
+	"github.com/gvieiragoulart/draft-visualizer/internal/draft"
+)
+
+var (
+	ErrMatchNotFound  = errors.New("match not found")
+	ErrNoPoolSchedule = errors.New("match schedules are not available")
+)
+
+// ChampionPool is the set of champions legal in a match: everything released
+// by its patch that the tournament has not disabled
+type ChampionPool struct {
+	MatchId    string   `json:"matchId,omitempty"`
+	Tournament string   `json:"tournament,omitempty"`
+	Patch      string   `json:"patch,omitempty"`
+	Hotfix     string   `json:"hotfix,omitempty"`
+	Disabled   []string `json:"disabled"`
+	Unreleased []string `json:"unreleased"`
+	Available  []string `json:"available"`
+}
+
+// Illegal returns every champion that may not be banned or picked
+func (p *ChampionPool) Illegal() []string {
+	illegal := append([]string{}, p.Disabled...)
+	return append(illegal, p.Unreleased...)
+}
+
+// BuildChampionPool works out the legal pool for a patch from the catalogue.
+// Disabled names are normalized, and kept as given when the catalogue does
+// not know them yet. An empty patch only removes the disabled champions, and
+// without a catalogue only the disabled champions are known.
+func BuildChampionPool(catalogue *champions.Catalogue, patch string, disabled []string) *ChampionPool {
+	isDisabled := make(map[string]bool)
+	for _, name := range disabled {
+		if catalogue != nil {
+			if canonical, err := catalogue.Canonical(name); err == nil {
+				name = canonical
+			}
+		}
+		if name != "" && name != draft.NoBan {
+			isDisabled[name] = true
+		}
+	}
+
+	pool := &ChampionPool{
+		Patch:      patch,
+		Disabled:   []string{},
+		Unreleased: []string{},
+		Available:  []string{},
+	}
+	for name := range isDisabled {
+		pool.Disabled = append(pool.Disabled, name)
+	}
+	sort.Strings(pool.Disabled)
+
+	if catalogue == nil {
+		return pool
+	}
+	for _, champion := range catalogue.All() {
+		switch {
+		case isDisabled[champion.Name]:
+		case patch != "" && champion.ReleasePatch != "" && analytics.ComparePatches(champion.ReleasePatch, patch) > 0:
+			pool.Unreleased = append(pool.Unreleased, champion.Name)
+		default:
+			pool.Available = append(pool.Available, champion.Name)
+		}
+	}
+	return pool
+}
+
+// MatchChampionPool returns the pool of a Leaguepedia schedule row, from its
+// patch and disabled champions
+func MatchChampionPool(catalogue *champions.Catalogue, match match_schedule.MatchSchedule) *ChampionPool {
+	patch := match.Patch
+	if patch == "" {
+		patch = match.LegacyPatch
+	}
+
+	pool := BuildChampionPool(catalogue, patch, match.DisabledChampions)
+	pool.MatchId = match.MatchId
+	pool.Tournament = match.OverviewPage
+	pool.Hotfix = match.Hotfix
+	return pool
+}
+
+// PoolService resolves the champion pool legal in Leaguepedia matches
+type PoolService struct {
+	cargoClient *cargo.Client
+	catalogue   *champions.Catalogue
+}
+
+func NewPoolService(cargoClient *cargo.Client, catalogue *champions.Catalogue) *PoolService {
+	return &PoolService{
+		cargoClient: cargoClient,
+		catalogue:   catalogue,
+	}
+}
+
+// GetMatchPool returns the pool of a match from its patch and disabled
+// champions on Leaguepedia
+func (s *PoolService) GetMatchPool(matchID string) (*ChampionPool, error) {
+	if s.cargoClient == nil {
+		return nil, ErrNoPoolSchedule
+	}
+
+	match, err := s.cargoClient.GetMatchScheduleByID(matchID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting match schedule: %w", err)
+	}
+	if match == nil {
+		return nil, fmt.Errorf("%w: %s", ErrMatchNotFound, matchID)
+	}
+	return MatchChampionPool(s.catalogue, *match), nil
+}
+
+// GetPatchPool returns the pool for a patch with the given champions
+// disabled, for drafts not tied to a Leaguepedia match
+func (s *PoolService) GetPatchPool(patch string, disabled []string) *ChampionPool {
+	return BuildChampionPool(s.catalogue, patch, disabled)
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/champions"
+	match_schedule "github.com/gvieiragoulart/draft-visualizer/internal/clients/cargo/model/match_shedule"
+)
+
+func TestMatchChampionPool(t *testing.T) {
+	catalogue, err := champions.LoadBundled()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	pool := MatchChampionPool(catalogue, match_schedule.MatchSchedule{
+		MatchId:           "match-1",
+		OverviewPage:      "LCK/2024 Season/Spring Season",
+		LegacyPatch:       "14.4",
+		Hotfix:            "14.4b",
+		DisabledChampions: []string{"Smolder", "Not Yet In Data Dragon"},
+	})
+
+	if pool.MatchId != "match-1" || pool.Patch != "14.4" || pool.Hotfix != "14.4b" {
+		t.Errorf("expected match details, got %+v", pool)
+	}
+	if len(pool.Disabled) != 2 || pool.Disabled[0] != "Not Yet In Data Dragon" || pool.Disabled[1] != "Smolder" {
+		t.Errorf("expected disabled champions to be kept, got %v", pool.Disabled)
+	}
+
+	unreleased := map[string]bool{}
+	for _, name := range pool.Unreleased {
+		unreleased[name] = true
+	}
+	if !unreleased["Aurora"] || !unreleased["Ambessa"] || unreleased["Hwei"] {
+		t.Errorf("expected champions released after 14.4 only, got %v", pool.Unreleased)
+	}
+
+	for _, name := range pool.Available {
+		if name == "Smolder" || name == "Aurora" {
+			t.Errorf("expected %s not to be available", name)
+		}
+	}
+	if got := len(pool.Available) + len(pool.Unreleased) + 1; got != len(catalogue.All()) {
+		t.Errorf("expected every champion to be placed once, got %d of %d", got, len(catalogue.All()))
+	}
+}
+
+func TestBuildChampionPool_ReleasePatches(t *testing.T) {
+	catalogue, err := champions.LoadBundled()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	tests := []struct {
+		champion string
+		before   string
+		release  string
+	}{
+		{champion: "Lillia", before: "10.14", release: "10.15"},
+		{champion: "Aurora", before: "14.13", release: "14.14"},
+		{champion: "Ambessa", before: "14.21", release: "14.22"},
+	}
+	for _, tt := range tests {
+		if !slices.Contains(BuildChampionPool(catalogue, tt.before, nil).Unreleased, tt.champion) {
+			t.Errorf("expected %s to be unreleased on %s", tt.champion, tt.before)
+		}
+		if !slices.Contains(BuildChampionPool(catalogue, tt.release, nil).Available, tt.champion) {
+			t.Errorf("expected %s to be available on %s", tt.champion, tt.release)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/analytics"
+	"github.com/gvieiragoulart/draft-visualizer/internal/champions"
+	"github.com/gvieiragoulart/draft-visualizer/internal/database"
+	"github.com/gvieiragoulart/draft-visualizer/internal/draft"
+	"github.com/gvieiragoulart/draft-visualizer/internal/recommend"
+)
+
+var ErrInvalidRecommendation = errors.New("invalid recommendation request")
+
+// RecommendRequest is a partial draft to recommend the next action for
+type RecommendRequest struct {
+	BoardRequest
+
+	// Pools lists, per side and role, the champions each player is
+	// comfortable on
+	Pools map[draft.Side]map[string][]string `json:"pools"`
+
+	// The historical games the model is built from
+	Tournament string                `json:"tournament"`
+	League     string                `json:"league"`
+	Patches    analytics.PatchWindow `json:"patches"`
+	MinGames   int                   `json:"minGames"`
+
+	Limit int `json:"limit"`
+}
+
+// recommendModelTTL is how long a model built from the stored games is
+// reused before games synced since are read again
+const recommendModelTTL = 10 * time.Minute
+
+// maxRecommendModels caps how many models are cached at once
+const maxRecommendModels = 64
+
+// recommendModelKey identifies the stored games and threshold a model was
+// built from
+type recommendModelKey struct {
+	tournament string
+	league     string
+	patches    analytics.PatchWindow
+	minGames   int
+}
+
+type cachedRecommendModel struct {
+	model   *recommend.Model
+	builtAt time.Time
+}
+
+// RecommendService ranks champions for the next action of a draft using the
+// stored pro games. Models are cached per game filter, patch window and
+// minimum games for recommendModelTTL, so requests do not read every stored
+// game again.
+type RecommendService struct {
+	matrix    *MatrixService
+	sessions  *DraftSessionService
+	catalogue *champions.Catalogue
+	now       func() time.Time
+
+	mu     sync.Mutex
+	models map[recommendModelKey]cachedRecommendModel
+}
+
+func NewRecommendService(matrix *MatrixService, sessions *DraftSessionService) *RecommendService {
+	return &RecommendService{
+		matrix:   matrix,
+		sessions: sessions,
+		now:      time.Now,
+		models:   make(map[recommendModelKey]cachedRecommendModel),
+	}
+}
+
+// SetCatalogue makes every catalogue champion a candidate, not only the
+// champions seen in the stored games, and normalizes submitted boards
+func (s *RecommendService) SetCatalogue(catalogue *champions.Catalogue) {
+	s.catalogue = catalogue
+}
+
+// Recommend rebuilds the draft and ranks candidates for its next turn
+func (s *RecommendService) Recommend(ctx context.Context, req RecommendRequest) (*recommend.Result, error) {
+	d, err := buildBoardDraft(ctx, s.sessions, s.catalogue, req.BoardRequest, ErrInvalidRecommendation)
+	if err != nil {
+		return nil, err
+	}
+
+	minGames := req.MinGames
+	if minGames == 0 {
+		minGames = analytics.DefaultMinGames
+	}
+
+	model, err := s.model(recommendModelKey{
+		tournament: req.Tournament,
+		league:     req.League,
+		patches:    req.Patches,
+		minGames:   minGames,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := model.Recommend(d, recommend.Options{Pools: req.Pools, Limit: req.Limit})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRecommendation, err)
+	}
+	return result, nil
+}
+
+// model returns the cached model for key, building it from the stored games
+// when it is missing or older than recommendModelTTL
+func (s *RecommendService) model(key recommendModelKey) (*recommend.Model, error) {
+	now := s.now()
+	s.mu.Lock()
+	cached, ok := s.models[key]
+	s.mu.Unlock()
+	if ok && now.Sub(cached.builtAt) < recommendModelTTL {
+		return cached.model, nil
+	}
+
+	// Without a database there is no history and only the draft itself
+	// informs the ranking
+	games := []analytics.Game{}
+	if s.matrix.db != nil {
+		var err error
+		games, err = s.matrix.StoredGames(database.DraftGameFilter{
+			Tournament: key.tournament,
+			League:     key.league,
+		}, key.patches)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	model := recommend.NewModel(games, key.minGames)
+	if s.catalogue != nil {
+		names := []string{}
+		for _, champion := range s.catalogue.All() {
+			names = append(names, champion.Name)
+		}
+		model.SetCandidates(names)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for k, cached := range s.models {
+		if now.Sub(cached.builtAt) >= recommendModelTTL {
+			delete(s.models, k)
+		}
+	}
+	if len(s.models) >= maxRecommendModels {
+		s.models = make(map[recommendModelKey]cachedRecommendModel)
+	}
+	s.models[key] = cachedRecommendModel{model: model, builtAt: now}
+	return model, nil
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gvieiragoulart/draft-visualizer/internal/analytics"
+	"github.com/gvieiragoulart/draft-visualizer/internal/database"
+)
+
+func TestRecommend_CachesModels(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	columns := []string{"id", "game_id", "match_id", "tournament", "league", "patch",
+		"played_at", "blue_team", "red_team", "winner", "blue", "red", "created_at", "updated_at"}
+	mock.ExpectQuery(`FROM draft_games WHERE league = \$1`).
+		WithArgs("LCK").
+		WillReturnRows(sqlmock.NewRows(columns))
+	mock.ExpectQuery(`FROM draft_games WHERE league = \$1`).
+		WithArgs("LCK").
+		WillReturnRows(sqlmock.NewRows(columns))
+
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	svc := NewRecommendService(NewMatrixService(nil, database.NewClientWithDB(db)), NewDraftSessionService())
+	svc.now = func() time.Time { return now }
+
+	req := RecommendRequest{BoardRequest: BoardRequest{BlueTeam: "T1", RedTeam: "Gen.G"}, League: "LCK"}
+	for i := 0; i < 2; i++ {
+		if _, err := svc.Recommend(context.Background(), req); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+	if err := mock.ExpectationsWereMet(); err == nil {
+		t.Fatal("expected the second request to reuse the cached model")
+	}
+
+	now = now.Add(recommendModelTTL)
+	if _, err := svc.Recommend(context.Background(), req); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expected an expired model to be rebuilt: %s", err)
+	}
+
+	req.Patches = analytics.PatchWindow{From: "14.9"}
+	if _, err := svc.Recommend(context.Background(), req); err == nil {
+		t.Error("expected a new patch window to read the stored games again")
+	}
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/champions"
+	"github.com/gvieiragoulart/draft-visualizer/internal/clients"
+	"github.com/gvieiragoulart/draft-visualizer/internal/clients/esports"
+	"github.com/gvieiragoulart/draft-visualizer/internal/draft"
+	"github.com/gvieiragoulart/draft-visualizer/internal/render"
+)
+
+var ErrInvalidRender = errors.New("invalid render request")
+
+const (
+	ImageSVG = "svg"
+	ImagePNG = "png"
+)
+
+// RenderRequest is a board to draw plus the labels shown above it. A
+// session's game number is used when GameNumber is not set.
+type RenderRequest struct {
+	BoardRequest
+	Title      string `json:"title"`
+	Patch      string `json:"patch"`
+	GameNumber int    `json:"gameNumber"`
+	Format     string `json:"imageFormat"`
+}
+
+// RenderedImage is an encoded draft image
+type RenderedImage struct {
+	ContentType string
+	Data        []byte
+}
+
+// RenderService draws drafts as shareable images
+type RenderService struct {
+	sessions      *DraftSessionService
+	esportsClient *esports.EsportsClient
+	catalogue     *champions.Catalogue
+	httpClient    clients.HTTPClient
+
+	mu      sync.Mutex
+	artwork map[string]image.Image
+}
+
+func NewRenderService(sessions *DraftSessionService, esportsClient *esports.EsportsClient) *RenderService {
+	return &RenderService{
+		sessions:      sessions,
+		esportsClient: esportsClient,
+		httpClient:    &http.Client{Timeout: 5 * time.Second},
+		artwork:       make(map[string]image.Image),
+	}
+}
+
+// SetCatalogue adds champion icons to rendered boards
+func (s *RenderService) SetCatalogue(catalogue *champions.Catalogue) {
+	s.catalogue = catalogue
+}
+
+// SetHTTPClient replaces the client logos and icons are downloaded with
+func (s *RenderService) SetHTTPClient(httpClient clients.HTTPClient) {
+	s.httpClient = httpClient
+}
+
+// Render draws a live session or posted board as SVG or PNG. Logos and icons
+// that cannot be downloaded are left out of PNG output rather than failing
+// the render.
+func (s *RenderService) Render(ctx context.Context, req RenderRequest) (*RenderedImage, error) {
+	format := strings.ToLower(req.Format)
+	if format == "" {
+		format = ImageSVG
+	}
+	if format != ImageSVG && format != ImagePNG {
+		return nil, fmt.Errorf("%w: unsupported image format %q", ErrInvalidRender, req.Format)
+	}
+
+	if req.SessionID != "" && req.GameNumber == 0 {
+		session, err := s.sessions.GetSession(ctx, req.SessionID)
+		if err != nil {
+			return nil, err
+		}
+		req.GameNumber = session.GameNumber
+	}
+
+	d, err := buildBoardDraft(ctx, s.sessions, s.catalogue, req.BoardRequest, ErrInvalidRender)
+	if err != nil {
+		return nil, err
+	}
+
+	card := render.Card{
+		Board:      d.Board(),
+		Title:      req.Title,
+		Patch:      req.Patch,
+		GameNumber: req.GameNumber,
+		Blue:       render.Team{Name: d.BlueTeam},
+		Red:        render.Team{Name: d.RedTeam},
+		Icons:      s.icons(ctx, d, format == ImagePNG),
+	}
+	s.resolveLogos(ctx, &card, format == ImagePNG)
+
+	if format == ImageSVG {
+		return &RenderedImage{ContentType: "image/svg+xml", Data: render.SVG(card)}, nil
+	}
+
+	data, err := render.PNG(card)
+	if err != nil {
+		return nil, err
+	}
+	return &RenderedImage{ContentType: "image/png", Data: data}, nil
+}
+
+// icons looks up the Data Dragon icon of every champion on the board,
+// downloading them when rasterizing
+func (s *RenderService) icons(ctx context.Context, d *draft.Draft, download bool) map[string]render.Art {
+	icons := make(map[string]render.Art)
+	if s.catalogue == nil {
+		return icons
+	}
+
+	for _, action := range d.Actions {
+		if action.Champion == "" || action.Champion == draft.NoBan {
+			continue
+		}
+		champion, ok := s.catalogue.Resolve(action.Champion)
+		if !ok {
+			continue
+		}
+		art := render.Art{URL: champion.Icon}
+		if download {
+			art.Image = s.fetchImage(ctx, champion.Icon)
+		}
+		icons[action.Champion] = art
+	}
+	return icons
+}
+
+// resolveLogos fills in the lolesports logo of each team, when the team
+// name matches a lolesports team
+func (s *RenderService) resolveLogos(ctx context.Context, card *render.Card, download bool) {
+	if s.esportsClient == nil {
+		return
+	}
+
+	teams, err := s.esportsClient.GetTeams()
+	if err != nil {
+		return
+	}
+	for _, team := range []*render.Team{&card.Blue, &card.Red} {
+		found, ok := teams.FindTeam(team.Name)
+		if !ok || found.Image == "" {
+			continue
+		}
+		team.LogoURL = found.Image
+		if download {
+			team.Logo = s.fetchImage(ctx, found.Image)
+		}
+	}
+}
+
+// fetchImage downloads and decodes an image, keeping it for later renders.
+// It returns nil when the image cannot be fetched.
+func (s *RenderService) fetchImage(ctx context.Context, url string) image.Image {
+	s.mu.Lock()
+	img, ok := s.artwork[url]
+	s.mu.Unlock()
+	if ok {
+		return img
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil
+	}
+
+	img, _, err = image.Decode(resp.Body)
+	if err != nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	s.artwork[url] = img
+	s.mu.Unlock()
+	return img
+}
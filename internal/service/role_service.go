@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/analytics"
+	"github.com/gvieiragoulart/draft-visualizer/internal/champions"
+	"github.com/gvieiragoulart/draft-visualizer/internal/clients/cargo"
+	"github.com/gvieiragoulart/draft-visualizer/internal/database"
+	"github.com/gvieiragoulart/draft-visualizer/internal/draft"
+)
+
+var ErrInvalidRoleRequest = errors.New("invalid role request")
+
+// RoleRequest names a draft to assign roles in, plus the stored games whose
+// role distribution fills in the roles no source records
+type RoleRequest struct {
+	DraftSource
+	Tournament string `json:"tournament"`
+	League     string `json:"league"`
+	PatchFrom  string `json:"patchFrom"`
+	PatchTo    string `json:"patchTo"`
+}
+
+// FlexQuery selects the stored games flex picks are detected in
+type FlexQuery struct {
+	Window   analytics.PatchWindow
+	Filter   database.DraftGameFilter
+	MinGames int
+}
+
+// DraftRoles is a draft with each side's picks ordered by position. Flex
+// lists the role distribution of the flex picks in the draft, and Games the
+// number of stored games the distribution was built from.
+type DraftRoles struct {
+	Draft *draft.Draft               `json:"draft"`
+	Blue  []analytics.RoleAssignment `json:"blue"`
+	Red   []analytics.RoleAssignment `json:"red"`
+	Flex  []analytics.ChampionRoles  `json:"flex"`
+	Games int                        `json:"games"`
+}
+
+// RoleService works out which role every pick of a draft went to, from
+// Leaguepedia scoreboards, the draft itself and the roles champions were
+// played in across stored pro games
+type RoleService struct {
+	sessions    *DraftSessionService
+	matrix      *MatrixService
+	cargoClient *cargo.Client
+	catalogue   *champions.Catalogue
+}
+
+func NewRoleService(sessions *DraftSessionService, matrix *MatrixService, cargoClient *cargo.Client) *RoleService {
+	return &RoleService{
+		sessions:    sessions,
+		matrix:      matrix,
+		cargoClient: cargoClient,
+	}
+}
+
+// SetCatalogue normalizes champion spellings so drafts and scoreboards match
+// the stored games
+func (s *RoleService) SetCatalogue(catalogue *champions.Catalogue) {
+	s.catalogue = catalogue
+}
+
+// AssignRoles resolves the draft and gives every pick a role. Scoreboard
+// roles of a stored game come first, then roles recorded in the draft, and
+// the remaining picks are inferred from the historical distribution.
+func (s *RoleService) AssignRoles(ctx context.Context, req RoleRequest) (*DraftRoles, error) {
+	var db *database.Client
+	if s.matrix != nil {
+		db = s.matrix.db
+	}
+	d, err := resolveDraftSource(ctx, s.sessions, db, s.catalogue, req.DraftSource, ErrInvalidRoleRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	scoreboard, err := s.scoreboardRoles(d, req.GameID)
+	if err != nil {
+		return nil, err
+	}
+
+	games, err := s.storedGames(
+		database.DraftGameFilter{Tournament: req.Tournament, League: req.League},
+		analytics.PatchWindow{From: req.PatchFrom, To: req.PatchTo},
+	)
+	if err != nil {
+		return nil, err
+	}
+	distribution := analytics.RoleStatistics(games)
+
+	roles := &DraftRoles{Draft: d, Flex: []analytics.ChampionRoles{}, Games: len(games)}
+	for _, side := range []draft.Side{draft.Blue, draft.Red} {
+		picks := []analytics.RoleAssignment{}
+		for _, action := range d.Actions {
+			if action.Side != side || action.Type != draft.Pick || action.Champion == "" {
+				continue
+			}
+			pick := analytics.RoleAssignment{Champion: action.Champion}
+			if role, ok := scoreboard[side][action.Champion]; ok {
+				pick.Role, pick.Source = role, analytics.RoleSourceScoreboard
+			} else if analytics.NormalizeRole(action.Role) != "" {
+				pick.Role, pick.Source = action.Role, analytics.RoleSourceDraft
+			}
+			picks = append(picks, pick)
+
+			if stats := distribution[action.Champion]; stats.Flex {
+				roles.Flex = append(roles.Flex, stats)
+			}
+		}
+
+		if side == draft.Blue {
+			roles.Blue = analytics.AssignRoles(picks, distribution)
+		} else {
+			roles.Red = analytics.AssignRoles(picks, distribution)
+		}
+	}
+	return roles, nil
+}
+
+// GetFlexPicks returns the champions played in two or more roles in the
+// stored games matching the query
+func (s *RoleService) GetFlexPicks(query FlexQuery) ([]analytics.ChampionRoles, error) {
+	if query.MinGames < 0 {
+		return nil, fmt.Errorf("%w: minGames cannot be negative", ErrInvalidRoleRequest)
+	}
+
+	games, err := s.storedGames(query.Filter, query.Window)
+	if err != nil {
+		return nil, err
+	}
+	return analytics.RoleStatistics(games).FlexPicks(query.MinGames), nil
+}
+
+// storedGames returns no games when there is no database to read history from
+func (s *RoleService) storedGames(filter database.DraftGameFilter, window analytics.PatchWindow) ([]analytics.Game, error) {
+	if s.matrix == nil || s.matrix.db == nil {
+		return []analytics.Game{}, nil
+	}
+	return s.matrix.StoredGames(filter, window)
+}
+
+// scoreboardRoles returns the role each champion of a Leaguepedia game was
+// played in, by side. Games without a scoreboard have no roles.
+func (s *RoleService) scoreboardRoles(d *draft.Draft, gameID string) (map[draft.Side]map[string]string, error) {
+	roles := map[draft.Side]map[string]string{draft.Blue: {}, draft.Red: {}}
+	if gameID == "" || s.cargoClient == nil {
+		return roles, nil
+	}
+
+	players, err := s.cargoClient.GetScoreboardPlayers(gameID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scoreboard players: %w", err)
+	}
+
+	for _, player := range players {
+		var side draft.Side
+		switch {
+		case player.Side != nil && *player.Side == 1:
+			side = draft.Blue
+		case player.Side != nil && *player.Side == 2:
+			side = draft.Red
+		default:
+			var ok bool
+			if side, ok = d.SideOf(player.Team); !ok {
+				continue
+			}
+		}
+
+		champion := player.Champion
+		if s.catalogue != nil {
+			if canonical, err := s.catalogue.Canonical(champion); err == nil {
+				champion = canonical
+			}
+		}
+		if role := analytics.NormalizeRole(player.PlayedRole()); role != "" && champion != "" {
+			roles[side][champion] = role
+		}
+	}
+	return roles, nil
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/analytics"
+	"github.com/gvieiragoulart/draft-visualizer/internal/champions"
+)
+
+func TestAssignRoles_FromNotation(t *testing.T) {
+	catalogue, err := champions.LoadBundled()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	s := NewRoleService(NewDraftSessionService(), nil, nil)
+	s.SetCatalogue(catalogue)
+
+	roles, err := s.AssignRoles(context.Background(), RoleRequest{DraftSource: DraftSource{
+		Notation: "T1 vs Gen.G: BB Azir, RB Vi, BB Rell, RB Ahri, BB Jax, RB Nautilus, BP kaisa@bot, RP Maokai, RP Orianna@mid",
+	}})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(roles.Blue) != 1 || roles.Blue[0].Champion != "Kai'Sa" || roles.Blue[0].Role != "bottom" {
+		t.Errorf("expected Kai'Sa in bottom, got %+v", roles.Blue)
+	}
+	if roles.Blue[0].Source != analytics.RoleSourceDraft {
+		t.Errorf("expected the notation role to be used, got %q", roles.Blue[0].Source)
+	}
+	if len(roles.Red) != 2 || roles.Red[1].Champion != "Orianna" || roles.Red[1].Role != "mid" {
+		t.Errorf("expected red ordered by position with Orianna in mid, got %+v", roles.Red)
+	}
+	if roles.Red[0].Source != analytics.RoleSourceHistory || roles.Games != 0 {
+		t.Errorf("expected Maokai inferred without stored games, got %+v", roles.Red[0])
+	}
+}
+
+func TestAssignRoles_Invalid(t *testing.T) {
+	s := NewRoleService(NewDraftSessionService(), nil, nil)
+
+	_, err := s.AssignRoles(context.Background(), RoleRequest{})
+	if !errors.Is(err, ErrInvalidRoleRequest) {
+		t.Errorf("expected ErrInvalidRoleRequest, got %v", err)
+	}
+}
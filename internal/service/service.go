@@ -2,14 +2,37 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
+	"github.com/gvieiragoulart/draft-visualizer/internal/champions"
+	"github.com/gvieiragoulart/draft-visualizer/internal/draft"
 	"github.com/gvieiragoulart/draft-visualizer/internal/riot"
 )
 
+var (
+	// ErrDatabaseUnavailable is returned by features that need Postgres
+	// when the server started without it
+	ErrDatabaseUnavailable = errors.New("database is not available")
+	ErrNoMatchDraft        = errors.New("match has no draft")
+)
+
 // Service provides business logic for the application
 type Service struct {
 	riotClient *riot.Client
+	catalogue  *champions.Catalogue
+}
+
+// MatchDetails is a match together with its bans and picks as an ordered
+// draft. Draft is nil when the match has none to show. PickOrderKnown is
+// false for tournament code games, whose picks are placed in draft slots
+// by participant rather than in the order they were made.
+type MatchDetails struct {
+	*riot.Match
+	Patch          string       `json:"patch,omitempty"`
+	Draft          *draft.Board `json:"draft,omitempty"`
+	PickOrderKnown bool         `json:"pickOrderKnown"`
 }
 
 // NewService creates a new service
@@ -19,20 +42,72 @@ func NewService(riotClient *riot.Client) *Service {
 	}
 }
 
-// GetSummoner retrieves a summoner by name, using cache and database
-func (s *Service) GetSummoner(ctx context.Context, region, summonerName string) (*riot.Summoner, error) {
-	// Try API
-	summoner, err := s.riotClient.GetSummonerByName(region, summonerName)
+// MatchTimeline is a match's timeline laid out for analysis: every
+// participant's state per minute and the events of the game
+type MatchTimeline struct {
+	MatchID       string                     `json:"matchId"`
+	FrameInterval int64                      `json:"frameIntervalMs"`
+	Participants  []riot.ParticipantTimeline `json:"participants"`
+	Events        []riot.TimelineEvent       `json:"events"`
+}
+
+// SetCatalogue names the champions banned in matches, which match-v5 only
+// gives by key
+func (s *Service) SetCatalogue(catalogue *champions.Catalogue) {
+	s.catalogue = catalogue
+}
+
+// GetPlayer resolves a Riot ID (gameName#tagLine) to its account and the
+// summoner it plays on region. Legacy summoner names without a tag line can
+// no longer be looked up and are rejected with riot.ErrInvalidRiotID.
+func (s *Service) GetPlayer(ctx context.Context, region, riotID string) (*riot.Player, error) {
+	platform, err := riot.ParsePlatform(region)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err := riot.ParseRiotID(riotID); err != nil {
+		return nil, err
+	}
+
+	player, err := s.riotClient.GetPlayerByRiotID(ctx, platform, riotID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get summoner from API: %w", err)
+		return nil, fmt.Errorf("failed to get player from API: %w", err)
 	}
+	return player, nil
+}
 
-	return summoner, nil
+// GetSummoner retrieves the summoner of a Riot ID
+func (s *Service) GetSummoner(ctx context.Context, region, name string) (*riot.Summoner, error) {
+	player, err := s.GetPlayer(ctx, region, name)
+	if err != nil {
+		return nil, err
+	}
+	return player.Summoner, nil
 }
 
 // GetMatches retrieves matches for a summoner by PUUID
 func (s *Service) GetMatches(ctx context.Context, puuid string, count int) ([]string, error) {
-	matchIDs, err := s.riotClient.GetMatchesByPUUID(puuid, count)
+	matchIDs, err := s.riotClient.GetMatchesByPUUID(ctx, puuid, count)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get matches from API: %w", err)
+	}
+
+	return matchIDs, nil
+}
+
+// GetMatchHistory retrieves the matches of a summoner by PUUID that pass
+// filter, from a routing region or the region of a platform. An empty
+// region uses the client's default.
+func (s *Service) GetMatchHistory(ctx context.Context, region, puuid string, filter riot.MatchFilter) ([]string, error) {
+	routing := s.riotClient.Region()
+	if region != "" {
+		var err error
+		if routing, err = riot.ParseRegion(region); err != nil {
+			return nil, err
+		}
+	}
+
+	matchIDs, err := s.riotClient.GetMatchesByPUUIDInRegion(ctx, routing, puuid, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get matches from API: %w", err)
 	}
@@ -44,10 +119,77 @@ func (s *Service) GetMatches(ctx context.Context, puuid string, count int) ([]st
 func (s *Service) GetMatch(ctx context.Context, matchID string) (*riot.Match, error) {
 
 	// Get from API
-	match, err := s.riotClient.GetMatchByID(matchID)
+	match, err := s.riotClient.GetMatchByID(ctx, matchID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get match from API: %w", err)
 	}
 
 	return match, nil
 }
+
+// GetMatchDetails retrieves a match by ID along with its draft
+func (s *Service) GetMatchDetails(ctx context.Context, matchID string) (*MatchDetails, error) {
+	match, err := s.GetMatch(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+
+	details := &MatchDetails{Match: match, Patch: match.Patch(), PickOrderKnown: match.PickOrderKnown()}
+	// the match is still worth serving without its draft
+	if d := s.matchDraft(match); d != nil {
+		board := d.Board()
+		details.Draft = &board
+	}
+	return details, nil
+}
+
+// ExportMatchDraft returns a match's draft in draft notation, JSON or CSV
+func (s *Service) ExportMatchDraft(ctx context.Context, matchID, format string) ([]byte, error) {
+	match, err := s.GetMatch(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+
+	d := s.matchDraft(match)
+	if d == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNoMatchDraft, matchID)
+	}
+	return EncodeDrafts([]draft.Record{{Draft: d}}, format)
+}
+
+// matchDraft returns the match's draft with champions named from the
+// catalogue, or nil when it has none
+func (s *Service) matchDraft(match *riot.Match) *draft.Draft {
+	d, err := match.ToDraft()
+	if err != nil || len(d.Actions) == 0 {
+		return nil
+	}
+	if s.catalogue != nil {
+		// champions newer than the catalogue keep their key
+		_ = s.catalogue.NormalizeDraft(d)
+	}
+	return d
+}
+
+// GetMatchTimeline retrieves a match's timeline, keeping only the events of
+// the given types when any are named
+func (s *Service) GetMatchTimeline(ctx context.Context, matchID string, eventTypes []string) (*MatchTimeline, error) {
+	timeline, err := s.riotClient.GetMatchTimeline(ctx, matchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get match timeline from API: %w", err)
+	}
+
+	types := make([]string, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		if eventType = strings.ToUpper(strings.TrimSpace(eventType)); eventType != "" {
+			types = append(types, eventType)
+		}
+	}
+
+	return &MatchTimeline{
+		MatchID:       matchID,
+		FrameInterval: timeline.Info.FrameInterval,
+		Participants:  timeline.Participants(),
+		Events:        timeline.Events(types...),
+	}, nil
+}
@@ -4,12 +4,15 @@ import (
 	"bytes"
 	"context"
 	"database/sql"
+	"errors"
 	"io"
 	"net/http"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gvieiragoulart/draft-visualizer/internal/champions"
+	"github.com/gvieiragoulart/draft-visualizer/internal/draft"
 	"github.com/gvieiragoulart/draft-visualizer/internal/riot"
 	"github.com/redis/go-redis/v9"
 )
@@ -97,7 +100,7 @@ func TestGetSummoner_FromAPI(t *testing.T) {
 	service := NewService(riotClient)
 	ctx := context.Background()
 
-	summoner, err := service.GetSummoner(ctx, "na1", "TestSummoner")
+	summoner, err := service.GetSummoner(ctx, "na1", "TestSummoner#NA1")
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -107,6 +110,49 @@ func TestGetSummoner_FromAPI(t *testing.T) {
 	}
 }
 
+func TestGetPlayer_RiotID(t *testing.T) {
+	mockHTTP := &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			responseBody := `{"puuid": "test-puuid", "gameName": "TestPlayer", "tagLine": "NA1"}`
+			if req.URL.Host == "na1.api.riotgames.com" {
+				responseBody = `{"puuid": "test-puuid", "summonerLevel": 100, "profileIconId": 1234}`
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(responseBody)),
+			}, nil
+		},
+	}
+
+	service := NewService(riot.NewClientWithHTTPClient("test-key", mockHTTP))
+
+	player, err := service.GetPlayer(context.Background(), "na1", "TestPlayer#NA1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if player.Account == nil || player.Account.GameName != "TestPlayer" {
+		t.Errorf("expected the account of TestPlayer#NA1, got %+v", player.Account)
+	}
+	if player.Summoner.SummonerLevel != 100 {
+		t.Errorf("expected SummonerLevel to be 100, got %d", player.Summoner.SummonerLevel)
+	}
+}
+
+func TestGetPlayer_RequiresTagLine(t *testing.T) {
+	mockHTTP := &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			t.Errorf("expected no request for a name without a tag line, got %s", req.URL)
+			return nil, errors.New("unexpected request")
+		},
+	}
+
+	service := NewService(riot.NewClientWithHTTPClient("test-key", mockHTTP))
+
+	if _, err := service.GetPlayer(context.Background(), "na1", "TestSummoner"); !errors.Is(err, riot.ErrInvalidRiotID) {
+		t.Errorf("expected ErrInvalidRiotID, got %v", err)
+	}
+}
+
 func TestGetMatches_FromAPI(t *testing.T) {
 	expectedMatches := []string{"NA1_match1", "NA1_match2", "NA1_match3"}
 
@@ -181,3 +227,109 @@ func TestGetMatch_FromAPI(t *testing.T) {
 		t.Errorf("expected match ID to be NA1_match1, got %s", match.Metadata.MatchID)
 	}
 }
+
+func TestGetMatchDetails_Draft(t *testing.T) {
+	mockHTTP := &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			responseBody := `{
+				"metadata": {"matchId": "KR_1"},
+				"info": {
+					"gameVersion": "14.10.588.4919",
+					"queueId": 420,
+					"participants": [
+						{"participantId": 1, "teamId": 100, "championName": "MonkeyKing", "teamPosition": "TOP"}
+					],
+					"teams": [
+						{"teamId": 100, "bans": [{"championId": 268, "pickTurn": 1}]},
+						{"teamId": 200, "bans": [{"championId": 103, "pickTurn": 6}]}
+					]
+				}
+			}`
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(responseBody)),
+			}, nil
+		},
+	}
+
+	catalogue, err := champions.LoadBundled()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	service := NewService(riot.NewClientWithHTTPClient("test-key", mockHTTP))
+	service.SetCatalogue(catalogue)
+
+	details, err := service.GetMatchDetails(context.Background(), "KR_1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if details.Patch != "14.10" || details.Draft == nil || !details.PickOrderKnown {
+		t.Fatalf("expected patch 14.10 with an ordered draft, got %+v", details)
+	}
+	actions := details.Draft.Actions
+	if len(actions) < 3 || actions[0].Champion != "Azir" || actions[1].Champion != "Ahri" {
+		t.Errorf("expected bans named from their keys, got %+v", actions)
+	}
+}
+
+func TestExportMatchDraft(t *testing.T) {
+	mockHTTP := &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			responseBody := `{
+				"metadata": {"matchId": "KR_1"},
+				"info": {
+					"queueId": 420,
+					"participants": [
+						{"participantId": 1, "teamId": 100, "championName": "Jax", "teamPosition": "TOP"}
+					],
+					"teams": [
+						{"teamId": 100, "bans": [{"championId": 268, "pickTurn": 1}]},
+						{"teamId": 200, "bans": [{"championId": 103, "pickTurn": 6}]}
+					]
+				}
+			}`
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(responseBody)),
+			}, nil
+		},
+	}
+
+	catalogue, err := champions.LoadBundled()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	service := NewService(riot.NewClientWithHTTPClient("test-key", mockHTTP))
+	service.SetCatalogue(catalogue)
+
+	data, err := service.ExportMatchDraft(context.Background(), "KR_1", DraftNotation)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	records, err := draft.ParseNotation(string(data))
+	if err != nil {
+		t.Fatalf("expected the notation to parse, got %v", err)
+	}
+	if len(records) != 1 || records[0].Draft.Actions[0].Champion != "Azir" {
+		t.Errorf("expected one draft opening with the Azir ban, got %s", data)
+	}
+
+	if _, err := service.ExportMatchDraft(context.Background(), "KR_1", "xml"); !errors.Is(err, ErrInvalidConversion) {
+		t.Errorf("expected ErrInvalidConversion, got %v", err)
+	}
+}
+
+func TestContentType(t *testing.T) {
+	tests := map[string]string{
+		"csv":      "text/csv; charset=utf-8",
+		"CSV":      "text/csv; charset=utf-8",
+		"Notation": "text/plain; charset=utf-8",
+		"json":     "application/json",
+		"":         "application/json",
+	}
+	for format, expected := range tests {
+		if got := ContentType(format); got != expected {
+			t.Errorf("%q: expected %s, got %s", format, expected, got)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/analytics"
+	"github.com/gvieiragoulart/draft-visualizer/internal/champions"
+	"github.com/gvieiragoulart/draft-visualizer/internal/clients/cargo"
+	"github.com/gvieiragoulart/draft-visualizer/internal/clients/cargo/model/draft_games"
+	"github.com/gvieiragoulart/draft-visualizer/internal/draft"
+)
+
+var ErrInvalidStatsQuery = errors.New("invalid stats query")
+
+// ChampionStatsQuery selects the games to aggregate and how to order them
+type ChampionStatsQuery struct {
+	Filter     draft_games.Filter
+	Sort       string
+	Descending bool
+}
+
+// StatsService aggregates pro drafts pulled from Leaguepedia
+type StatsService struct {
+	cargoClient *cargo.Client
+	catalogue   *champions.Catalogue
+}
+
+func NewStatsService(cargoClient *cargo.Client) *StatsService {
+	return &StatsService{cargoClient: cargoClient}
+}
+
+// SetCatalogue makes the service merge different spellings of a champion
+// before aggregating
+func (s *StatsService) SetCatalogue(catalogue *champions.Catalogue) {
+	s.catalogue = catalogue
+}
+
+// GetGames returns the games matching filter as analytics records
+func (s *StatsService) GetGames(filter draft_games.Filter) ([]analytics.Game, error) {
+	if filter.Empty() {
+		return nil, fmt.Errorf("%w: at least one of team, tournament, league, patch, from or to is required", ErrInvalidStatsQuery)
+	}
+
+	rows, err := s.cargoClient.GetDraftGames(filter)
+	if err != nil {
+		return nil, fmt.Errorf("error getting draft games: %w", err)
+	}
+
+	games := make([]analytics.Game, 0, len(rows))
+	for _, row := range rows {
+		if row.IsNullified != nil && *row.IsNullified {
+			continue
+		}
+		game := draftGame(row)
+		s.normalizeGame(&game)
+		games = append(games, game)
+	}
+	return games, nil
+}
+
+// GetChampionStats computes pick, ban, presence and win rates for the games
+// matching the query
+func (s *StatsService) GetChampionStats(query ChampionStatsQuery) (analytics.ChampionReport, error) {
+	games, err := s.GetGames(query.Filter)
+	if err != nil {
+		return analytics.ChampionReport{}, err
+	}
+
+	report := analytics.ChampionStatistics(games)
+	if err := analytics.SortChampionStats(report.Champions, query.Sort, query.Descending); err != nil {
+		return analytics.ChampionReport{}, fmt.Errorf("%w: %w", ErrInvalidStatsQuery, err)
+	}
+	return report, nil
+}
+
+// draftGame converts a Leaguepedia row into the record the analytics package
+// aggregates. Leaguepedia stores the blue side team as Team1.
+func draftGame(row draft_games.DraftGame) analytics.Game {
+	var winner draft.Side
+	if row.Winner != nil {
+		switch *row.Winner {
+		case 1:
+			winner = draft.Blue
+		case 2:
+			winner = draft.Red
+		}
+	}
+
+	return analytics.Game{
+		GameID:     row.GameId,
+		MatchID:    row.MatchId,
+		Tournament: row.OverviewPage,
+		League:     row.League,
+		Patch:      row.Patch,
+		Date:       row.DateTimeUTC,
+		BlueTeam:   row.Team1,
+		RedTeam:    row.Team2,
+		Winner:     winner,
+		Blue:       draft.Selections{Bans: row.Team1Bans, Picks: row.Team1Picks, Roles: row.Team1Roles},
+		Red:        draft.Selections{Bans: row.Team2Bans, Picks: row.Team2Picks, Roles: row.Team2Roles},
+	}
+}
+
+// normalizeGame rewrites champion names to their catalogue spelling. Names
+// the catalogue does not know are kept as Leaguepedia wrote them.
+func (s *StatsService) normalizeGame(game *analytics.Game) {
+	if s.catalogue == nil {
+		return
+	}
+	for _, selections := range []*draft.Selections{&game.Blue, &game.Red} {
+		for i, champion := range selections.Bans {
+			if canonical, err := s.catalogue.Canonical(champion); err == nil {
+				selections.Bans[i] = canonical
+			}
+		}
+		for i, champion := range selections.Picks {
+			if canonical, err := s.catalogue.Canonical(champion); err == nil {
+				selections.Picks[i] = canonical
+			}
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/champions"
+	"github.com/gvieiragoulart/draft-visualizer/internal/clients/cargo"
+	"github.com/gvieiragoulart/draft-visualizer/internal/clients/cargo/model/draft_games"
+	"github.com/gvieiragoulart/draft-visualizer/internal/clients/cargo/model/picks_and_bans"
+	"github.com/gvieiragoulart/draft-visualizer/internal/draft"
+)
+
+const draftGamesResponse = `{"cargoquery":[
+	{"title":{"Team1":"T1","Team2":"Gen.G","Winner":"1","Team1Ban1":"Azir","Team2Ban1":"Kaisa",
+		"Team1Pick1":"Ahri","Team2Pick1":"Corki","Patch":"14.13","DateTime UTC":"2024-06-12 08:00:00",
+		"League":"LoL Champions Korea","IsNullified":"0"}},
+	{"title":{"Team1":"Gen.G","Team2":"T1","Winner":"2","Team1Ban1":"Kai'Sa","Team2Ban1":"Ahri",
+		"Team1Pick1":"Azir","Team2Pick1":"Corki","Patch":"14.13","DateTime UTC":"2024-06-12 09:00:00",
+		"League":"LoL Champions Korea","IsNullified":"0"}},
+	{"title":{"Team1":"Gen.G","Team2":"T1","Winner":"1","Team1Pick1":"Azir","Patch":"14.13",
+		"League":"LoL Champions Korea","IsNullified":"1"}}
+]}`
+
+func TestGetChampionStats(t *testing.T) {
+	var requestedURL string
+	client := cargo.NewClientWithHTTPClient(&mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			requestedURL = req.URL.String()
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(draftGamesResponse)),
+			}, nil
+		},
+	})
+
+	catalogue, err := champions.LoadBundled()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	svc := NewStatsService(client)
+	svc.SetCatalogue(catalogue)
+
+	report, err := svc.GetChampionStats(ChampionStatsQuery{
+		Filter:     draft_games.Filter{Patch: "14.13"},
+		Descending: true,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !strings.Contains(requestedURL, "SG.Patch") {
+		t.Errorf("expected query to filter on the patch, got %s", requestedURL)
+	}
+	if report.Games != 2 {
+		t.Errorf("expected nullified game to be skipped, got %d games", report.Games)
+	}
+
+	for _, stats := range report.Champions {
+		if stats.Champion == "Kai'Sa" && stats.Bans != 2 {
+			t.Errorf("expected both Kai'Sa spellings to be merged, got %d bans", stats.Bans)
+		}
+		if stats.Champion == "Kaisa" {
+			t.Error("expected Kaisa to be normalized to Kai'Sa")
+		}
+	}
+}
+
+func TestGetChampionStats_EmptyFilter(t *testing.T) {
+	svc := NewStatsService(cargo.NewClientWithHTTPClient(&mockHTTPClient{}))
+
+	_, err := svc.GetChampionStats(ChampionStatsQuery{})
+	if !errors.Is(err, ErrInvalidStatsQuery) {
+		t.Errorf("expected ErrInvalidStatsQuery, got %v", err)
+	}
+}
+
+func TestDraftGame(t *testing.T) {
+	winner := 2
+	row := draft_games.DraftGame{
+		PicksAndBans: picks_and_bans.PicksAndBans{
+			Team1:        "T1",
+			Team2:        "Gen.G",
+			Winner:       &winner,
+			Team1Bans:    []string{"Azir"},
+			Team1Picks:   []string{"Ahri"},
+			Team2Picks:   []string{"Corki"},
+			OverviewPage: "LCK/2024 Season/Summer Season",
+			GameId:       "LCK/2024 Season/Summer Season_Week 1_1_1",
+		},
+		Patch:  "14.13",
+		League: "LoL Champions Korea",
+	}
+
+	g := draftGame(row)
+	if g.BlueTeam != "T1" || g.RedTeam != "Gen.G" {
+		t.Errorf("expected T1 on blue and Gen.G on red, got %s and %s", g.BlueTeam, g.RedTeam)
+	}
+	if g.Winner != draft.Red {
+		t.Errorf("expected red to win, got %s", g.Winner)
+	}
+	if g.Patch != "14.13" || g.League != "LoL Champions Korea" || g.Tournament != row.OverviewPage {
+		t.Errorf("expected patch, league and tournament to be copied, got %+v", g)
+	}
+	if g.Blue.Bans[0] != "Azir" || g.Red.Picks[0] != "Corki" {
+		t.Errorf("expected selections to be copied, got %+v and %+v", g.Blue, g.Red)
+	}
+}
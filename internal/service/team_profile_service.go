@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/analytics"
+	"github.com/gvieiragoulart/draft-visualizer/internal/clients/cargo/model/draft_games"
+	"github.com/gvieiragoulart/draft-visualizer/internal/clients/esports"
+	"github.com/gvieiragoulart/draft-visualizer/internal/clients/esports/dto"
+)
+
+var ErrTeamNotFound = errors.New("team not found")
+
+// TeamProfile is a team's draft tendencies together with the lolesports
+// team it was resolved from
+type TeamProfile struct {
+	Slug   string       `json:"slug"`
+	Code   string       `json:"code"`
+	Name   string       `json:"name"`
+	Image  string       `json:"image"`
+	League string       `json:"league"`
+	Roster []dto.Player `json:"roster"`
+	analytics.TeamProfile
+}
+
+// TeamProfileService builds scouting profiles from lolesports teams and
+// Leaguepedia drafts
+type TeamProfileService struct {
+	esportsClient *esports.EsportsClient
+	stats         *StatsService
+}
+
+func NewTeamProfileService(esportsClient *esports.EsportsClient, stats *StatsService) *TeamProfileService {
+	return &TeamProfileService{
+		esportsClient: esportsClient,
+		stats:         stats,
+	}
+}
+
+// GetTeamProfile resolves a team by slug or code and profiles its drafts in
+// the games matching filter. The team filter is always set to the team.
+func (s *TeamProfileService) GetTeamProfile(ctx context.Context, slugOrCode string, filter draft_games.Filter) (*TeamProfile, error) {
+	teams, err := s.esportsClient.GetTeams()
+	if err != nil {
+		return nil, fmt.Errorf("error getting teams: %w", err)
+	}
+
+	team, ok := teams.FindTeam(slugOrCode)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrTeamNotFound, slugOrCode)
+	}
+
+	filter.Team = team.Name
+	games, err := s.stats.GetGames(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	roster := make([]dto.Player, 0, len(team.Players))
+	players := make([]analytics.RosterPlayer, 0, len(team.Players))
+	for _, player := range team.Players {
+		roster = append(roster, dto.Player{
+			ID:           player.ID,
+			SummonerName: player.SummonerName,
+			FirstName:    player.FirstName,
+			LastName:     player.LastName,
+			Image:        player.Image,
+			Role:         player.Role,
+		})
+		players = append(players, analytics.RosterPlayer{Name: player.SummonerName, Role: player.Role})
+	}
+
+	return &TeamProfile{
+		Slug:        team.Slug,
+		Code:        team.Code,
+		Name:        team.Name,
+		Image:       team.Image,
+		League:      team.HomeLeague.Name,
+		Roster:      roster,
+		TeamProfile: analytics.BuildTeamProfile(team.Name, games, players),
+	}, nil
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/champions"
+	"github.com/gvieiragoulart/draft-visualizer/internal/winprob"
+)
+
+var (
+	ErrNoModel           = errors.New("no win probability model loaded")
+	ErrInvalidPrediction = errors.New("invalid prediction request")
+)
+
+// WinProbService predicts draft outcomes with a model trained offline
+type WinProbService struct {
+	sessions  *DraftSessionService
+	model     *winprob.Model
+	catalogue *champions.Catalogue
+}
+
+func NewWinProbService(sessions *DraftSessionService) *WinProbService {
+	return &WinProbService{sessions: sessions}
+}
+
+// SetModel replaces the model used for predictions
+func (s *WinProbService) SetModel(model *winprob.Model) {
+	s.model = model
+}
+
+// SetCatalogue normalizes submitted boards to the spellings the model was
+// trained on
+func (s *WinProbService) SetCatalogue(catalogue *champions.Catalogue) {
+	s.catalogue = catalogue
+}
+
+// Predict returns the win probability of each side for a complete or
+// partial draft
+func (s *WinProbService) Predict(ctx context.Context, req BoardRequest) (*winprob.Prediction, error) {
+	if s.model == nil {
+		return nil, ErrNoModel
+	}
+
+	d, err := buildBoardDraft(ctx, s.sessions, s.catalogue, req, ErrInvalidPrediction)
+	if err != nil {
+		return nil, err
+	}
+
+	prediction := s.model.Predict(d)
+	return &prediction, nil
+}
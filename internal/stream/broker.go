@@ -0,0 +1,183 @@
+package stream
+
+import (
+	"sync"
+	"time"
+)
+
+// Event is a single message published on a topic. IDs increase by one per
+// topic so clients can resume with the last ID they saw.
+type Event struct {
+	ID    int64       `json:"id"`
+	Topic string      `json:"topic"`
+	Type  string      `json:"type"`
+	Time  time.Time   `json:"time"`
+	Data  interface{} `json:"data"`
+}
+
+// Broker fans events out to subscribers and keeps a bounded history per
+// topic for replaying to clients that reconnect
+type Broker struct {
+	mu          sync.Mutex
+	topics      map[string]*topic
+	historySize int
+	bufferSize  int
+}
+
+type topic struct {
+	nextID      int64
+	history     []Event
+	subscribers map[*Subscription]struct{}
+}
+
+// Subscription receives the events of one topic until it is closed. The
+// channel is closed when the subscriber is removed, including when it falls
+// too far behind; Ended tells that apart from the topic being deleted.
+type Subscription struct {
+	Events <-chan Event
+
+	events chan Event
+	broker *Broker
+	topic  string
+	once   sync.Once
+	ended  bool
+}
+
+// NewBroker creates a broker that remembers the last historySize events of
+// every topic
+func NewBroker(historySize int) *Broker {
+	return &Broker{
+		topics:      make(map[string]*topic),
+		historySize: historySize,
+		bufferSize:  64,
+	}
+}
+
+// Publish stores an event in the topic history and sends it to every
+// subscriber
+func (b *Broker) Publish(topicName, eventType string, data interface{}) Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	t := b.topic(topicName)
+	t.nextID++
+	event := Event{
+		ID:    t.nextID,
+		Topic: topicName,
+		Type:  eventType,
+		Time:  time.Now(),
+		Data:  data,
+	}
+
+	t.history = append(t.history, event)
+	if len(t.history) > b.historySize {
+		t.history = t.history[len(t.history)-b.historySize:]
+	}
+
+	for sub := range t.subscribers {
+		select {
+		case sub.events <- event:
+		default:
+			// Drop subscribers that stopped reading; they can reconnect
+			// with their last event id
+			b.remove(t, sub)
+		}
+	}
+
+	return event
+}
+
+// Subscribe registers a subscriber on a topic. Events in the history with an
+// ID greater than lastEventID are delivered first, so passing the last ID a
+// client saw resumes the stream without gaps.
+func (b *Broker) Subscribe(topicName string, lastEventID int64) *Subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	t := b.topic(topicName)
+
+	var replay []Event
+	for _, event := range t.history {
+		if event.ID > lastEventID {
+			replay = append(replay, event)
+		}
+	}
+
+	events := make(chan Event, len(replay)+b.bufferSize)
+	for _, event := range replay {
+		events <- event
+	}
+
+	sub := &Subscription{
+		Events: events,
+		events: events,
+		broker: b,
+		topic:  topicName,
+	}
+	t.subscribers[sub] = struct{}{}
+	return sub
+}
+
+// History returns the events a topic still remembers
+func (b *Broker) History(topicName string) []Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	t, ok := b.topics[topicName]
+	if !ok {
+		return []Event{}
+	}
+	return append([]Event{}, t.history...)
+}
+
+// Delete forgets a topic and its history, closing the Events channel of
+// every subscriber
+func (b *Broker) Delete(topicName string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	t, ok := b.topics[topicName]
+	if !ok {
+		return
+	}
+	for sub := range t.subscribers {
+		sub.ended = true
+		b.remove(t, sub)
+	}
+	delete(b.topics, topicName)
+}
+
+// Ended reports whether the Events channel was closed because the topic was
+// deleted. It is only meaningful once the channel is closed.
+func (s *Subscription) Ended() bool {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	return s.ended
+}
+
+// Close unsubscribes and closes the Events channel
+func (s *Subscription) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+
+	if t, ok := s.broker.topics[s.topic]; ok {
+		s.broker.remove(t, s)
+	}
+}
+
+func (b *Broker) topic(name string) *topic {
+	t, ok := b.topics[name]
+	if !ok {
+		t = &topic{subscribers: make(map[*Subscription]struct{})}
+		b.topics[name] = t
+	}
+	return t
+}
+
+func (b *Broker) remove(t *topic, sub *Subscription) {
+	if _, ok := t.subscribers[sub]; !ok {
+		return
+	}
+	delete(t.subscribers, sub)
+	sub.once.Do(func() { close(sub.events) })
+}
@@ -0,0 +1,110 @@
+package stream
+
+import (
+	"testing"
+	"time"
+)
+
+func receive(t *testing.T, sub *Subscription) Event {
+	t.Helper()
+	select {
+	case event, ok := <-sub.Events:
+		if !ok {
+			t.Fatal("expected an event, channel was closed")
+		}
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+	return Event{}
+}
+
+func TestPublishSubscribe(t *testing.T) {
+	broker := NewBroker(10)
+	sub := broker.Subscribe("draft:1", 0)
+	defer sub.Close()
+
+	broker.Publish("draft:1", "ban", "Azir")
+	broker.Publish("draft:2", "ban", "Ahri")
+	broker.Publish("draft:1", "pick", "Ashe")
+
+	first := receive(t, sub)
+	second := receive(t, sub)
+	if first.ID != 1 || first.Type != "ban" || second.ID != 2 || second.Type != "pick" {
+		t.Errorf("expected ban then pick with ids 1 and 2, got %+v and %+v", first, second)
+	}
+}
+
+func TestSubscribe_ReplaysAfterLastEventID(t *testing.T) {
+	broker := NewBroker(10)
+	for _, champion := range []string{"Azir", "Kalista", "Ahri"} {
+		broker.Publish("draft:1", "ban", champion)
+	}
+
+	sub := broker.Subscribe("draft:1", 1)
+	defer sub.Close()
+
+	if event := receive(t, sub); event.ID != 2 || event.Data != "Kalista" {
+		t.Errorf("expected replay to resume at id 2, got %+v", event)
+	}
+	if event := receive(t, sub); event.ID != 3 {
+		t.Errorf("expected id 3, got %+v", event)
+	}
+
+	broker.Publish("draft:1", "ban", "Varus")
+	if event := receive(t, sub); event.ID != 4 {
+		t.Errorf("expected live event id 4 after replay, got %+v", event)
+	}
+}
+
+func TestHistoryIsBounded(t *testing.T) {
+	broker := NewBroker(2)
+	for i := 0; i < 5; i++ {
+		broker.Publish("draft:1", "timer", i)
+	}
+
+	history := broker.History("draft:1")
+	if len(history) != 2 || history[0].ID != 4 {
+		t.Errorf("expected the last 2 events, got %+v", history)
+	}
+}
+
+func TestSlowSubscriberIsDropped(t *testing.T) {
+	broker := NewBroker(100)
+	broker.bufferSize = 1
+	sub := broker.Subscribe("draft:1", 0)
+
+	broker.Publish("draft:1", "ban", "Azir")
+	broker.Publish("draft:1", "ban", "Ahri")
+
+	receive(t, sub)
+	if _, ok := <-sub.Events; ok {
+		t.Error("expected channel to be closed for a subscriber that fell behind")
+	}
+	if sub.Ended() {
+		t.Error("expected a dropped subscriber not to report its topic as ended")
+	}
+
+	// Closing an already dropped subscription is a no-op
+	sub.Close()
+}
+
+func TestDelete_ClosesSubscribers(t *testing.T) {
+	broker := NewBroker(10)
+	sub := broker.Subscribe("live:1", 0)
+	broker.Publish("live:1", "pick", "Azir")
+
+	broker.Delete("live:1")
+
+	receive(t, sub)
+	if _, ok := <-sub.Events; ok {
+		t.Error("expected the channel to be closed")
+	}
+	if !sub.Ended() {
+		t.Error("expected the subscriber to know the topic ended")
+	}
+	if history := broker.History("live:1"); len(history) != 0 {
+		t.Errorf("expected the history to be forgotten, got %v", history)
+	}
+	sub.Close()
+}
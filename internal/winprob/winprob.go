@@ -0,0 +1,386 @@
+package winprob
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/analytics"
+	"github.com/gvieiragoulart/draft-visualizer/internal/draft"
+)
+
+// FormatVersion is bumped whenever the model file layout changes
+const FormatVersion = 1
+
+// SideFeature is the intercept, which captures blue side advantage
+const SideFeature = "side:blue"
+
+var (
+	ErrNoTrainingData = errors.New("no games with a known winner to train on")
+	ErrModelFormat    = errors.New("unsupported model format")
+)
+
+// TrainingConfig controls how a model is fitted. The same games and config
+// always produce the same model and version.
+type TrainingConfig struct {
+	// MinPicks drops champions picked fewer times from the feature set
+	MinPicks int `json:"minPicks"`
+
+	// Bootstrap is how many models are fitted on resampled games to
+	// estimate uncertainty
+	Bootstrap    int     `json:"bootstrap"`
+	Epochs       int     `json:"epochs"`
+	LearningRate float64 `json:"learningRate"`
+	L2           float64 `json:"l2"`
+	Seed         int64   `json:"seed"`
+}
+
+// DefaultTrainingConfig works for a few thousand pro games
+var DefaultTrainingConfig = TrainingConfig{
+	MinPicks:     5,
+	Bootstrap:    20,
+	Epochs:       300,
+	LearningRate: 0.5,
+	L2:           0.01,
+	Seed:         1,
+}
+
+// TrainingData describes the games a model was fitted on. From and To are
+// the dates of the first and last of those games, when they are known.
+type TrainingData struct {
+	Games   int                   `json:"games"`
+	Patches analytics.PatchWindow `json:"patches"`
+	From    *time.Time            `json:"from,omitempty"`
+	To      *time.Time            `json:"to,omitempty"`
+}
+
+// Model is an ensemble of logistic regressions over champion features. Each
+// champion feature is +1 when blue picked it and -1 when red did, so a
+// positive weight favours the side playing the champion.
+type Model struct {
+	FormatVersion int            `json:"formatVersion"`
+	Version       string         `json:"version"`
+	TrainedAt     time.Time      `json:"trainedAt"`
+	Config        TrainingConfig `json:"config"`
+	Data          TrainingData   `json:"data"`
+	Features      []string       `json:"features"`
+	Ensemble      [][]float64    `json:"ensemble"`
+
+	index map[string]int
+}
+
+// Contribution is how much one feature moved the prediction, in log-odds
+// toward blue
+type Contribution struct {
+	Feature string     `json:"feature"`
+	Side    draft.Side `json:"side,omitempty"`
+	Value   float64    `json:"value"`
+}
+
+// Prediction is the win probability of each side for a draft. Interval is
+// the 5th to 95th percentile of the blue win probability across the
+// ensemble.
+type Prediction struct {
+	ModelVersion  string         `json:"modelVersion"`
+	BlueWin       float64        `json:"blueWin"`
+	RedWin        float64        `json:"redWin"`
+	StdDev        float64        `json:"stdDev"`
+	Interval      [2]float64     `json:"interval"`
+	Picks         int            `json:"picks"`
+	Unknown       []string       `json:"unknown,omitempty"`
+	Contributions []Contribution `json:"contributions"`
+}
+
+// Train fits a model on the games with a known winner
+func Train(games []analytics.Game, config TrainingConfig, data TrainingData) (*Model, error) {
+	if config.Bootstrap < 1 {
+		config.Bootstrap = 1
+	}
+
+	labelled := []analytics.Game{}
+	for _, game := range games {
+		if game.Winner == draft.Blue || game.Winner == draft.Red {
+			labelled = append(labelled, game)
+		}
+	}
+	if len(labelled) == 0 {
+		return nil, ErrNoTrainingData
+	}
+	data.Games = len(labelled)
+	data.From, data.To = dateRange(labelled)
+
+	m := &Model{
+		FormatVersion: FormatVersion,
+		TrainedAt:     time.Now().UTC(),
+		Config:        config,
+		Data:          data,
+		Features:      featureSet(labelled, config.MinPicks),
+	}
+	m.buildIndex()
+
+	rows := make([][]float64, len(labelled))
+	labels := make([]float64, len(labelled))
+	for i, game := range labelled {
+		rows[i], _ = m.vector(game.Blue.Picks, game.Red.Picks)
+		if game.Winner == draft.Blue {
+			labels[i] = 1
+		}
+	}
+
+	rng := rand.New(rand.NewSource(config.Seed))
+	for b := 0; b < config.Bootstrap; b++ {
+		sampleRows, sampleLabels := rows, labels
+		if b > 0 {
+			sampleRows = make([][]float64, len(rows))
+			sampleLabels = make([]float64, len(rows))
+			for i := range rows {
+				j := rng.Intn(len(rows))
+				sampleRows[i], sampleLabels[i] = rows[j], labels[j]
+			}
+		}
+		m.Ensemble = append(m.Ensemble, fit(sampleRows, sampleLabels, len(m.Features), config))
+	}
+
+	m.Version = m.fingerprint(labelled)
+	return m, nil
+}
+
+// Load reads a model written by Save
+func Load(path string) (*Model, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading model: %w", err)
+	}
+
+	var m Model
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("error decoding model: %w", err)
+	}
+	if m.FormatVersion != FormatVersion {
+		return nil, fmt.Errorf("%w: %d", ErrModelFormat, m.FormatVersion)
+	}
+	if len(m.Features) == 0 || m.Features[0] != SideFeature {
+		return nil, fmt.Errorf("%w: features must start with %s", ErrModelFormat, SideFeature)
+	}
+	if len(m.Ensemble) == 0 {
+		return nil, fmt.Errorf("%w: empty ensemble", ErrModelFormat)
+	}
+	for _, weights := range m.Ensemble {
+		if len(weights) != len(m.Features) {
+			return nil, fmt.Errorf("%w: ensemble member has %d weights for %d features", ErrModelFormat, len(weights), len(m.Features))
+		}
+	}
+	m.buildIndex()
+	return &m, nil
+}
+
+// Save writes the model as JSON
+func (m *Model) Save(path string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding model: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("error writing model: %w", err)
+	}
+	return nil
+}
+
+// Predict returns the win probability of each side given the picks made so
+// far. Bans are not used. Champions the model has no weight for are listed
+// in Unknown and do not move the prediction.
+func (m *Model) Predict(d *draft.Draft) Prediction {
+	blue, red := d.Picks(draft.Blue), d.Picks(draft.Red)
+	x, unknown := m.vector(blue, red)
+
+	probabilities := make([]float64, len(m.Ensemble))
+	mean := make([]float64, len(m.Features))
+	for i, weights := range m.Ensemble {
+		probabilities[i] = sigmoid(dot(weights, x))
+		for j, w := range weights {
+			mean[j] += w / float64(len(m.Ensemble))
+		}
+	}
+
+	var blueWin float64
+	for _, p := range probabilities {
+		blueWin += p / float64(len(probabilities))
+	}
+	var variance float64
+	for _, p := range probabilities {
+		variance += (p - blueWin) * (p - blueWin) / float64(len(probabilities))
+	}
+
+	sorted := append([]float64{}, probabilities...)
+	sort.Float64s(sorted)
+
+	return Prediction{
+		ModelVersion:  m.Version,
+		BlueWin:       blueWin,
+		RedWin:        1 - blueWin,
+		StdDev:        math.Sqrt(variance),
+		Interval:      [2]float64{percentile(sorted, 0.05), percentile(sorted, 0.95)},
+		Picks:         len(blue) + len(red),
+		Unknown:       unknown,
+		Contributions: m.contributions(mean, x),
+	}
+}
+
+func (m *Model) contributions(weights, x []float64) []Contribution {
+	contributions := []Contribution{}
+	for i, feature := range m.Features {
+		if x[i] == 0 {
+			continue
+		}
+		c := Contribution{Feature: feature, Value: weights[i] * x[i]}
+		if feature != SideFeature {
+			c.Feature = feature[len("champion:"):]
+			c.Side = draft.Blue
+			if x[i] < 0 {
+				c.Side = draft.Red
+			}
+		}
+		contributions = append(contributions, c)
+	}
+	sort.SliceStable(contributions, func(i, j int) bool {
+		return math.Abs(contributions[i].Value) > math.Abs(contributions[j].Value)
+	})
+	return contributions
+}
+
+func (m *Model) buildIndex() {
+	m.index = make(map[string]int, len(m.Features))
+	for i, feature := range m.Features {
+		m.index[feature] = i
+	}
+}
+
+// vector encodes picks as features, returning the champions with no feature
+func (m *Model) vector(blue, red []string) ([]float64, []string) {
+	x := make([]float64, len(m.Features))
+	x[m.index[SideFeature]] = 1
+
+	unknown := []string{}
+	for sign, picks := range map[float64][]string{1: blue, -1: red} {
+		for _, champion := range picks {
+			i, ok := m.index["champion:"+champion]
+			if !ok {
+				unknown = append(unknown, champion)
+				continue
+			}
+			x[i] += sign
+		}
+	}
+	sort.Strings(unknown)
+	return x, unknown
+}
+
+// fingerprint hashes everything that determines the weights, so retraining
+// on the same data with the same config yields the same version
+func (m *Model) fingerprint(games []analytics.Game) string {
+	h := sha256.New()
+	config, _ := json.Marshal(m.Config)
+	h.Write(config)
+	for _, game := range games {
+		fmt.Fprintf(h, "%s|%s|%v|%v\n", game.GameID, game.Winner, game.Blue.Picks, game.Red.Picks)
+	}
+	return "wp-" + hex.EncodeToString(h.Sum(nil))[:12]
+}
+
+// dateRange returns the earliest and latest dated game
+func dateRange(games []analytics.Game) (*time.Time, *time.Time) {
+	var from, to *time.Time
+	for _, game := range games {
+		if game.Date == nil || game.Date.IsZero() {
+			continue
+		}
+		if from == nil || game.Date.Before(*from) {
+			from = game.Date
+		}
+		if to == nil || game.Date.After(*to) {
+			to = game.Date
+		}
+	}
+	return from, to
+}
+
+// featureSet returns the intercept followed by every champion picked at
+// least minPicks times, sorted so the layout is stable
+func featureSet(games []analytics.Game, minPicks int) []string {
+	picks := map[string]int{}
+	for _, game := range games {
+		for _, champion := range append(append([]string{}, game.Blue.Picks...), game.Red.Picks...) {
+			picks[champion]++
+		}
+	}
+
+	champions := []string{}
+	for champion, count := range picks {
+		if count >= minPicks {
+			champions = append(champions, champion)
+		}
+	}
+	sort.Strings(champions)
+
+	features := []string{SideFeature}
+	for _, champion := range champions {
+		features = append(features, "champion:"+champion)
+	}
+	return features
+}
+
+// fit runs batch gradient descent on the L2-regularized log loss. The
+// intercept is not regularized.
+func fit(rows [][]float64, labels []float64, features int, config TrainingConfig) []float64 {
+	weights := make([]float64, features)
+	gradient := make([]float64, features)
+	n := float64(len(rows))
+
+	for epoch := 0; epoch < config.Epochs; epoch++ {
+		for j := range gradient {
+			gradient[j] = 0
+		}
+		for i, x := range rows {
+			err := sigmoid(dot(weights, x)) - labels[i]
+			for j, v := range x {
+				if v != 0 {
+					gradient[j] += err * v / n
+				}
+			}
+		}
+		for j := range weights {
+			if j > 0 {
+				gradient[j] += config.L2 * weights[j]
+			}
+			weights[j] -= config.LearningRate * gradient[j]
+		}
+	}
+	return weights
+}
+
+func dot(weights, x []float64) float64 {
+	var sum float64
+	for i, v := range x {
+		sum += weights[i] * v
+	}
+	return sum
+}
+
+func sigmoid(z float64) float64 {
+	return 1 / (1 + math.Exp(-z))
+}
+
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	i := int(math.Round(p * float64(len(sorted)-1)))
+	return sorted[i]
+}
@@ -0,0 +1,201 @@
+package winprob
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gvieiragoulart/draft-visualizer/internal/analytics"
+	"github.com/gvieiragoulart/draft-visualizer/internal/draft"
+)
+
+// firstDay is when the first training game was played
+var firstDay = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+// matchup is a game on the given day of training. The model only reads
+// picks, so roles are left out.
+func matchup(day int, winner draft.Side, blue, red []string) analytics.Game {
+	date := firstDay.AddDate(0, 0, day)
+	return analytics.Game{
+		Date:   &date,
+		Winner: winner,
+		Blue:   draft.Selections{Picks: blue},
+		Red:    draft.Selections{Picks: red},
+	}
+}
+
+// trainingGames has Jax win every game it is played in, on either side,
+// over ten days
+func trainingGames() []analytics.Game {
+	strong := []string{"Jax", "Vi", "Azir", "Kalista", "Rell"}
+	weak := []string{"Aatrox", "Sejuani", "Ahri", "Varus", "Nautilus"}
+
+	games := []analytics.Game{}
+	for day := 0; day < 10; day++ {
+		games = append(games, matchup(day, draft.Blue, strong, weak), matchup(day, draft.Red, weak, strong))
+	}
+	return games
+}
+
+func testConfig() TrainingConfig {
+	config := DefaultTrainingConfig
+	config.MinPicks = 1
+	config.Bootstrap = 5
+	return config
+}
+
+func draftWith(blue, red []string) *draft.Draft {
+	d := draft.New("T1", "Gen.G")
+	for i := 0; i < 6; i++ {
+		turn, _ := d.NextTurn()
+		d.Apply(draft.Action{Side: turn.Side, Type: draft.Ban})
+	}
+	for len(blue)+len(red) > 0 {
+		turn, ok := d.NextTurn()
+		if !ok || turn.Type != draft.Pick {
+			break
+		}
+		picks := &blue
+		if turn.Side == draft.Red {
+			picks = &red
+		}
+		if len(*picks) == 0 {
+			break
+		}
+		d.Apply(draft.Action{Side: turn.Side, Type: draft.Pick, Champion: (*picks)[0]})
+		*picks = (*picks)[1:]
+	}
+	return d
+}
+
+func TestTrain_NoData(t *testing.T) {
+	_, err := Train([]analytics.Game{matchup(0, "", []string{"Jax"}, []string{"Vi"})}, testConfig(), TrainingData{})
+	if !errors.Is(err, ErrNoTrainingData) {
+		t.Errorf("expected ErrNoTrainingData, got %v", err)
+	}
+}
+
+func TestTrain_DeterministicVersion(t *testing.T) {
+	first, err := Train(trainingGames(), testConfig(), TrainingData{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	second, err := Train(trainingGames(), testConfig(), TrainingData{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if first.Version != second.Version {
+		t.Errorf("expected the same version, got %s and %s", first.Version, second.Version)
+	}
+
+	config := testConfig()
+	config.Seed = 2
+	third, err := Train(trainingGames(), config, TrainingData{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if third.Version == first.Version {
+		t.Errorf("expected a different seed to change the version %s", first.Version)
+	}
+	if first.Data.Games != 20 {
+		t.Errorf("expected 20 training games, got %d", first.Data.Games)
+	}
+	if first.Data.From == nil || !first.Data.From.Equal(firstDay) || first.Data.To == nil || !first.Data.To.Equal(firstDay.AddDate(0, 0, 9)) {
+		t.Errorf("expected the training dates to span ten days from %s, got %v to %v", firstDay, first.Data.From, first.Data.To)
+	}
+}
+
+func TestPredict(t *testing.T) {
+	model, err := Train(trainingGames(), testConfig(), TrainingData{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	empty := model.Predict(draft.New("T1", "Gen.G"))
+	if empty.Picks != 0 {
+		t.Errorf("expected no picks, got %d", empty.Picks)
+	}
+
+	favoured := model.Predict(draftWith([]string{"Jax", "Vi"}, []string{"Aatrox", "Sejuani"}))
+	if favoured.BlueWin <= 0.5 {
+		t.Errorf("expected blue to be favoured, got %f", favoured.BlueWin)
+	}
+	if favoured.BlueWin+favoured.RedWin < 0.999 || favoured.BlueWin+favoured.RedWin > 1.001 {
+		t.Errorf("expected probabilities to sum to 1, got %f and %f", favoured.BlueWin, favoured.RedWin)
+	}
+	if favoured.Interval[0] > favoured.BlueWin || favoured.Interval[1] < favoured.BlueWin {
+		t.Errorf("expected %f to lie within %v", favoured.BlueWin, favoured.Interval)
+	}
+	if favoured.ModelVersion != model.Version {
+		t.Errorf("expected model version %s, got %s", model.Version, favoured.ModelVersion)
+	}
+
+	reversed := model.Predict(draftWith([]string{"Aatrox", "Sejuani"}, []string{"Jax", "Vi"}))
+	if reversed.BlueWin >= 0.5 {
+		t.Errorf("expected red to be favoured, got %f", reversed.BlueWin)
+	}
+
+	unknown := model.Predict(draftWith([]string{"Zed"}, nil))
+	if len(unknown.Unknown) != 1 || unknown.Unknown[0] != "Zed" {
+		t.Errorf("expected Zed to be unknown, got %v", unknown.Unknown)
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	model, err := Train(trainingGames(), testConfig(), TrainingData{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "model.json")
+	if err := model.Save(path); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	d := draftWith([]string{"Jax", "Vi"}, []string{"Aatrox", "Sejuani"})
+	if got, want := loaded.Predict(d).BlueWin, model.Predict(d).BlueWin; got != want {
+		t.Errorf("expected %f after loading, got %f", want, got)
+	}
+	if loaded.Version != model.Version {
+		t.Errorf("expected version %s, got %s", model.Version, loaded.Version)
+	}
+
+}
+
+func TestLoad_RejectsMalformedModels(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(m *Model)
+	}{
+		{name: "format version", modify: func(m *Model) { m.FormatVersion = FormatVersion + 1 }},
+		{name: "no features", modify: func(m *Model) { m.Features, m.Ensemble = nil, [][]float64{{}} }},
+		{name: "intercept not first", modify: func(m *Model) {
+			m.Features[0], m.Features[1] = m.Features[1], m.Features[0]
+		}},
+		{name: "empty ensemble", modify: func(m *Model) { m.Ensemble = nil }},
+		{name: "weights mismatch", modify: func(m *Model) { m.Ensemble[0] = m.Ensemble[0][1:] }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model, err := Train(trainingGames(), testConfig(), TrainingData{})
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			tt.modify(model)
+
+			path := filepath.Join(t.TempDir(), "model.json")
+			if err := model.Save(path); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if _, err := Load(path); !errors.Is(err, ErrModelFormat) {
+				t.Errorf("expected ErrModelFormat, got %v", err)
+			}
+		})
+	}
+}
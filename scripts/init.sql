@@ -27,3 +27,70 @@ CREATE INDEX IF NOT EXISTS idx_summoners_puuid ON summoners(puuid);
 CREATE INDEX IF NOT EXISTS idx_summoners_region ON summoners(region);
 CREATE INDEX IF NOT EXISTS idx_matches_match_id ON matches(match_id);
 CREATE INDEX IF NOT EXISTS idx_matches_game_creation ON matches(game_creation);
+
+-- Create draft_games table to store pro drafts imported from Leaguepedia
+CREATE TABLE IF NOT EXISTS draft_games (
+    id SERIAL PRIMARY KEY,
+    game_id VARCHAR(255) UNIQUE NOT NULL,
+    match_id VARCHAR(255),
+    tournament VARCHAR(255),
+    league VARCHAR(255),
+    patch VARCHAR(20),
+    played_at TIMESTAMP,
+    blue_team VARCHAR(255) NOT NULL,
+    red_team VARCHAR(255) NOT NULL,
+    winner VARCHAR(4),
+    blue JSONB NOT NULL,
+    red JSONB NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_draft_games_patch ON draft_games(patch);
+CREATE INDEX IF NOT EXISTS idx_draft_games_played_at ON draft_games(played_at);
+CREATE INDEX IF NOT EXISTS idx_draft_games_tournament ON draft_games(tournament);
+
+-- Create ingest_checkpoints table to resume match history crawls. A
+-- checkpoint only covers the region, queue (-1 for every queue) and match
+-- type ('' for every type) the history was listed with, between
+-- crawled_from (NULL for the start of the history) and crawled_until.
+CREATE TABLE IF NOT EXISTS ingest_checkpoints (
+    puuid VARCHAR(255) NOT NULL,
+    region VARCHAR(10) NOT NULL,
+    queue INTEGER NOT NULL DEFAULT -1,
+    match_type VARCHAR(20) NOT NULL DEFAULT '',
+    crawled_from TIMESTAMP,
+    crawled_until TIMESTAMP NOT NULL,
+    matches INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (puuid, region, queue, match_type)
+);
+
+-- Create league_snapshots and mastery_snapshots tables to chart tracked
+-- players' ranked standing and champion mastery over time
+CREATE TABLE IF NOT EXISTS league_snapshots (
+    id SERIAL PRIMARY KEY,
+    puuid VARCHAR(255) NOT NULL,
+    platform VARCHAR(10) NOT NULL,
+    queue_type VARCHAR(50) NOT NULL,
+    tier VARCHAR(20) NOT NULL,
+    rank VARCHAR(5),
+    league_points INTEGER NOT NULL DEFAULT 0,
+    wins INTEGER NOT NULL DEFAULT 0,
+    losses INTEGER NOT NULL DEFAULT 0,
+    taken_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS mastery_snapshots (
+    id SERIAL PRIMARY KEY,
+    puuid VARCHAR(255) NOT NULL,
+    platform VARCHAR(10) NOT NULL,
+    champion_id INTEGER NOT NULL,
+    champion_level INTEGER NOT NULL,
+    champion_points INTEGER NOT NULL,
+    last_play_time BIGINT,
+    taken_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_league_snapshots_puuid_taken_at ON league_snapshots(puuid, taken_at);
+CREATE INDEX IF NOT EXISTS idx_mastery_snapshots_puuid_taken_at ON mastery_snapshots(puuid, taken_at);